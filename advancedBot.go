@@ -3,7 +3,6 @@ package telego
 import (
 	"encoding/json"
 	"errors"
-	"os"
 
 	objs "github.com/hamidteimouri/telego/objects"
 )
@@ -11,47 +10,31 @@ import (
 /*
 AdvancedBot is an advanced type of bot which will give you alot more customization for the bot.
 Methods which are uniquely for advanced bot start with 'A' .
+The Sender (returned by "Sender" method of the bot) offers the same options with option based methods.
 */
 type AdvancedBot struct {
 	bot *Bot
 }
 
 /*
-ASendMessage sends a text message to a chat (not channel, use SendMessageUN method for sending messages to channles) and returns the sent message on success
+ASendMessage sends a text message to a chat or a channel and returns the sent message on success
 If you want to ignore "parseMode" pass empty string. To ignore replyTo pass 0.
 
 If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *AdvancedBot) ASendMessage(chatId int, text, parseMode string, replyTo, messageThreadId int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendMessage(chatId objs.ChatID, text, parseMode string, replyTo, messageThreadId int64, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendMessage(chatId, "", text, parseMode, entites, disabelWebPagePreview,
-		silent, allowSendingWithoutReply, protectContent, replyTo, messageThreadId, replyMarkup)
-}
-
-/*
-ASendMesssageUN sends a text message to a channel and returns the sent message on success
-If you want to ignore "parseMode" pass empty string. To ignore replyTo pass 0.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *AdvancedBot) ASendMesssageUN(chatId, text, parseMode string, replyTo, messageThreadId int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return bot.bot.apiInterface.SendMessage(0, chatId, text, parseMode, entites, disabelWebPagePreview,
+	return bot.bot.apiInterface.SendMessage(chatId, text, parseMode, entites, disabelWebPagePreview,
 		silent, allowSendingWithoutReply, protectContent, replyTo, messageThreadId, replyMarkup)
 }
 
 /*ACopyMessage returns a MessageCopier which has several methods for copying a message*/
-func (bot *AdvancedBot) ACopyMessage(messageId int, disableNotif bool, replyTo int, caption, parseMode string, captionEntites []objs.MessageEntity, allowSendingWithoutReply bool, keyboard MarkUps) *MessageCopier {
+func (bot *AdvancedBot) ACopyMessage(messageId int64, disableNotif bool, replyTo int64, caption, parseMode string, captionEntites []objs.MessageEntity, allowSendingWithoutReply bool, keyboard MarkUps) *MessageCopier {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
@@ -60,73 +43,31 @@ func (bot *AdvancedBot) ACopyMessage(messageId int, disableNotif bool, replyTo i
 }
 
 /*
-ASendSticker returns a MediaSender which has several methods for sending a sticker. This method is only used for sending a sticker to all types of chat except channels. To send a sticker to a channel use "SendStickerUN" method.
+ASendSticker returns a MediaSender which has several methods for sending a sticker.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 */
-func (bot *AdvancedBot) ASendSticker(chatId, replyTo, messageThreadId int, emoji string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendSticker(chatId objs.ChatID, replyTo, messageThreadId int64, emoji string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: PHOTO, bot: bot.bot, chatIdInt: chatId, replyTo: replyTo, messageThreadId: messageThreadId, stickerEmoji: emoji, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
+	return &MediaSender{mediaType: PHOTO, bot: bot.bot, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, stickerEmoji: emoji, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
 }
 
 /*
-ASendStickerUN returns a MediaSender which has several methods for sending a sticker. This method is only used for sending a sticker to a channels.
+ASendPhoto returns a MediaSender which has several methods for sending a photo.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 */
-func (bot *AdvancedBot) ASendStickerUN(chatId string, replyTo, messageThreadId int, emoji string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendPhoto(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: PHOTO, bot: bot.bot, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, stickerEmoji: emoji, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
+	return &MediaSender{mediaType: PHOTO, bot: bot.bot, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
 }
 
 /*
-ASendPhoto returns a MediaSender which has several methods for sending a photo. This method is only used for sending a photo to all types of chat except channels. To send a photo to a channel use "SendPhotoUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-*/
-func (bot *AdvancedBot) ASendPhoto(chatId, replyTo, messageThreadId int, caption, parseMode string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: PHOTO, bot: bot.bot, chatIdInt: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
-}
-
-/*
-ASendPhotoUN returns a MediaSender which has several methods for sending a photo. This method is only used for sending a photo to a channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-*/
-func (bot *AdvancedBot) ASendPhotoUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntites []objs.MessageEntity, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: PHOTO, bot: bot.bot, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
-}
-
-/*
-ASendVideo returns a MediaSender which has several methods for sending a video. This method is only used for sending a video to all types of chat except channels. To send a video to a channel use "SendVideoUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send video files, Telegram clients support mp4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *AdvancedBot) ASendVideo(chatId int, replyTo, messageThreadId int, caption, parseMode string, captionEntites []objs.MessageEntity, duration int, supportsStreaming, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: VIDEO, bot: bot.bot, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, duration: duration, supportsStreaming: supportsStreaming, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
-}
-
-/*
-ASendVideoUN returns a MediaSender which has several methods for sending a video. This method is only used for sending a video to a channels.
+ASendVideo returns a MediaSender which has several methods for sending a video.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -135,36 +76,16 @@ Official telegram doc :
 
 Use this method to send video files, Telegram clients support mp4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *AdvancedBot) ASendVideoUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntites []objs.MessageEntity, duration int, supportsStreaming, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: VIDEO, bot: bot.bot, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, duration: duration, supportsStreaming: supportsStreaming, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
-}
-
-/*
-ASendAudio returns a MediaSender which has several methods for sending a audio. This method is only used for sending a audio to all types of chat except channels. To send a audio to a channel use "SendAudioUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send audio files, if you want Telegram clients to display them in the music player. Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned. Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
-
-For sending voice messages, use the sendVoice method instead.
-*/
-func (bot *AdvancedBot) ASendAudio(chatId, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, performer, title string, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendVideo(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntites []objs.MessageEntity, duration int, supportsStreaming, allowSendingWithoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: AUDIO, bot: bot.bot, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, performer: performer, title: title, duration: duration, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
+	return &MediaSender{mediaType: VIDEO, bot: bot.bot, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntites, duration: duration, supportsStreaming: supportsStreaming, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
 }
 
 /*
-ASendAudioUN returns a MediaSender which has several methods for sending a audio. This method is only used for sending a audio to a channels.
+ASendAudio returns a MediaSender which has several methods for sending a audio.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -175,16 +96,16 @@ Use this method to send audio files, if you want Telegram clients to display the
 
 For sending voice messages, use the sendVoice method instead.
 */
-func (bot *AdvancedBot) ASendAudioUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, performer, title string, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendAudio(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, performer, title string, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: AUDIO, bot: bot.bot, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, performer: performer, title: title, duration: duration, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
+	return &MediaSender{mediaType: AUDIO, bot: bot.bot, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, performer: performer, title: title, duration: duration, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
 }
 
 /*
-ASendDocument returns a MediaSender which has several methods for sending a document. This method is only used for sending a document to all types of chat except channels. To send a audio to a channel use "SendDocumentUN" method.
+ASendDocument returns a MediaSender which has several methods for sending a document.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -193,34 +114,16 @@ Official telegram doc :
 
 Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *AdvancedBot) ASendDocument(chatId, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, disableContentTypeDetection, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendDocument(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntities []objs.MessageEntity, disableContentTypeDetection, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: DOCUMENT, bot: bot.bot, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, disableContentTypeDetection: disableContentTypeDetection, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
+	return &MediaSender{mediaType: DOCUMENT, bot: bot.bot, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, disableContentTypeDetection: disableContentTypeDetection, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
 }
 
 /*
-ASendDocumentUN returns a MediaSender which has several methods for sending a document. This method is only used for sending a document to a channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *AdvancedBot) ASendDocumentUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, disableContentTypeDetection, allowSendingWithoutReply bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: DOCUMENT, bot: bot.bot, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, caption: caption, parseMode: parseMode, captionEntities: captionEntities, disableContentTypeDetection: disableContentTypeDetection, allowSendingWihoutReply: allowSendingWithoutReply, replyMarkup: replyMarkup}
-}
-
-/*
-ASendAnimation returns a MediaSender which has several methods for sending an animation. This method is only used for sending an animation to all types of chat except channels. To send a audio to a channel use "SendAnimationUN" method.
+ASendAnimation returns a MediaSender which has several methods for sending an animation.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -229,52 +132,16 @@ Official telegram doc :
 
 Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *AdvancedBot) ASendAnimation(chatId int, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, width, height, duration int, allowSendingWihtoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendAnimation(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntities []objs.MessageEntity, width, height, duration int, allowSendingWihtoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: ANIMATION, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, width: width, height: height, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
+	return &MediaSender{mediaType: ANIMATION, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, width: width, height: height, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
 }
 
 /*
-ASendAnimationUN returns a MediaSender which has several methods for sending an animation. This method is only used for sending an animation to channels
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *AdvancedBot) ASendAnimationUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, width, height, duration int, allowSendingWihtoutReply, hasSpoiler bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: ANIMATION, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, width: width, height: height, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, hasSpoiler: hasSpoiler}
-}
-
-/*
-ASendVoice returns a MediaSender which has several methods for sending a voice. This method is only used for sending a voice to all types of chat except channels. To send a voice to a channel use "SendVoiceUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *AdvancedBot) ASendVoice(chatId int, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: VOICE, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
-}
-
-/*
-ASendVoiceUN returns a MediaSender which has several methods for sending a voice. This method is only used for sending a voice to channels.
+ASendVoice returns a MediaSender which has several methods for sending a voice.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -283,16 +150,16 @@ Official telegram doc :
 
 Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *AdvancedBot) ASendVoiceUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendVoice(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntities []objs.MessageEntity, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: VOICE, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
+	return &MediaSender{mediaType: VOICE, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, duration: duration, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
 }
 
 /*
-ASendVideoNote returns a MediaSender which has several methods for sending a video note. This method is only used for sending a video note to all types of chat except channels. To send a video note to a channel use "SendVideoNoteUN" method.
+ASendVideoNote returns a MediaSender which has several methods for sending a video note.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -301,46 +168,28 @@ Official telegram doc :
 
 As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
 */
-func (bot *AdvancedBot) ASendVideoNote(chatId int, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, length, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
+func (bot *AdvancedBot) ASendVideoNote(chatId objs.ChatID, replyTo, messageThreadId int64, caption, parseMode string, captionEntities []objs.MessageEntity, length, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaSender{mediaType: VIDEONOTE, chatIdInt: chatId, username: "", replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, length: length, duration: duration}
-}
-
-/*
-ASendVideoNoteUN returns an MediaSender which has several methods for sending a video note. This method is only used for sending a video note to channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
-*/
-func (bot *AdvancedBot) ASendVideoNoteUN(chatId string, replyTo, messageThreadId int, caption, parseMode string, captionEntities []objs.MessageEntity, length, duration int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaSender {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return &MediaSender{mediaType: VIDEONOTE, chatIdInt: 0, username: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, length: length, duration: duration}
+	return &MediaSender{mediaType: VIDEONOTE, chatId: chatId, replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, caption: caption, parseMode: parseMode, captionEntities: captionEntities, allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup, length: length, duration: duration}
 }
 
 /*
 ACreateAlbum creates a MediaGroup for grouping media messages.
 To ignore replyTo argument, pass 0.
 */
-func (bot *AdvancedBot) ACreateAlbum(replyTo, messageThreadId int, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaGroup {
+func (bot *AdvancedBot) ACreateAlbum(replyTo, messageThreadId int64, allowSendingWihtoutReply bool, keyboard MarkUps) *MediaGroup {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaGroup{replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
+	return &MediaGroup{replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]objs.NamedReader, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
 }
 
 /*
-ASendVenue sends a venue to all types of chat but channels. To send it to channels use "SendVenueUN" method.
+ASendVenue sends a venue to the given chat or channel.
 
 If "silent" argument is true, the message will be sent without notification.
 
@@ -352,66 +201,19 @@ Official telegram doc :
 
 Use this method to send information about a venue. On success, the sent Message is returned.
 */
-func (bot *AdvancedBot) ASendVenue(chatId, replyTo, messageThreadId int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent bool, allowSendingWihtoutReply, protectContent bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendVenue(chatId objs.ChatID, replyTo, messageThreadId int64, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent, allowSendingWihtoutReply, protectContent bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
 	return bot.bot.apiInterface.SendVenue(
-		chatId, "", latitude, longitude, title, address, foursquareId, foursquareType,
+		chatId, latitude, longitude, title, address, foursquareId, foursquareType,
 		googlePlaceId, googlePlaceType, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
 
 /*
-ASendVenueUN sends a venue to a channel.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send information about a venue. On success, the sent Message is returned.
-*/
-func (bot *AdvancedBot) ASendVenueUN(chatId string, replyTo, messageThreadId int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return bot.bot.apiInterface.SendVenue(
-		0, chatId, latitude, longitude, title, address, foursquareId, foursquareType,
-		googlePlaceId, googlePlaceType, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
-	)
-}
-
-/*
-ASendContact sends a contact to all types of chat but channels. To send it to channels use "SendContactUN" method.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send phone contacts. On success, the sent Message is returned.
-*/
-func (bot *AdvancedBot) ASendContact(chatId, replyTo, messageThreadId int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return bot.bot.apiInterface.SendContact(
-		chatId, "", phoneNumber, firstName, lastName, vCard, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
-	)
-}
-
-/*
-ASendContactUN sends a contact to a channel.
+ASendContact sends a contact to the given chat or channel.
 
 If "silent" argument is true, the message will be sent without notification.
 
@@ -423,18 +225,18 @@ Official telegram doc :
 
 Use this method to send phone contacts. On success, the sent Message is returned.
 */
-func (bot *AdvancedBot) ASendContactUN(chatId string, replyTo, messageThreadId int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendContact(chatId objs.ChatID, replyTo, messageThreadId int64, phoneNumber, firstName, lastName, vCard string, silent, protectContent, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
 	return bot.bot.apiInterface.SendContact(
-		0, chatId, phoneNumber, firstName, lastName, vCard, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
+		chatId, phoneNumber, firstName, lastName, vCard, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
 
 /*
-ASendDice sends a dice message to all types of chat but channels. To send it to channels use "SendDiceUN" method.
+ASendDice sends a dice message to the given chat or channel.
 
 Available emojies : “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
 
@@ -448,43 +250,18 @@ Official telegram doc :
 
 Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned
 */
-func (bot *AdvancedBot) ASendDice(chatId, replyTo, messageThreadId int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendDice(chatId objs.ChatID, replyTo, messageThreadId int64, emoji string, silent, protectContent, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
 	return bot.bot.apiInterface.SendDice(
-		chatId, "", emoji, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
-	)
-}
-
-/*
-ASendDiceUN sends a dice message to a channel.
-
-Available emojies : “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned
-*/
-func (bot *AdvancedBot) ASendDiceUN(chatId string, replyTo, messageThreadId int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return bot.bot.apiInterface.SendDice(
-		0, chatId, emoji, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
+		chatId, emoji, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
 
 /*ACreateLiveLocation creates a live location which has several methods for managing it.*/
-func (bot *AdvancedBot) ACreateLiveLocation(latitude, longitude, accuracy float32, livePeriod, heading, proximtyAlertRadius, replyTo, messageThreadId int, allowSendingWihtoutReply bool, keyboard MarkUps) *LiveLocation {
+func (bot *AdvancedBot) ACreateLiveLocation(latitude, longitude, accuracy float32, livePeriod, heading, proximtyAlertRadius int, replyTo, messageThreadId int64, allowSendingWihtoutReply bool, keyboard MarkUps) *LiveLocation {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
@@ -493,32 +270,7 @@ func (bot *AdvancedBot) ACreateLiveLocation(latitude, longitude, accuracy float3
 }
 
 /*
-ASendLocation sends a location (not live) to all types of chats but channels. To send it to channel use "SendLocationUN" method.
-
-You can not use this methods to send a live location. To send a live location use "ACreateLiveLocation" method.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send point on the map. On success, the sent Message is returned.
-*/
-func (bot *AdvancedBot) ASendLocation(chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo, messageThreadId int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
-	var replyMarkup objs.ReplyMarkup
-	if keyboard != nil {
-		replyMarkup = keyboard.toMarkUp()
-	}
-	return bot.bot.apiInterface.SendLocation(
-		chatId, "", latitude, longitude, accuracy, 0, 0, 0, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
-	)
-}
-
-/*
-ASendLocationUN sends a location (not live) to a channel.
+ASendLocation sends a location (not live) to the given chat or channel.
 
 You can not use this methods to send a live location. To send a live location use "ACreateLiveLocation" method.
 
@@ -532,13 +284,13 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.
 */
-func (bot *AdvancedBot) ASendLocationUN(chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo, messageThreadId int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendLocation(chatId objs.ChatID, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo, messageThreadId int64, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
 	return bot.bot.apiInterface.SendLocation(
-		0, chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
+		chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, messageThreadId, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
 
@@ -567,27 +319,11 @@ func (bot *AdvancedBot) AAnswerInlineQuery(id string, cacheTime int, isPersonal
 
 /*
 ACreateInvoice returns an InvoiceSender which has several methods for creating and sending an invoice.
+The unnamed bool argument is not used and is only kept for compatibility.
 
-This method is suitable for sending this invoice to a chat that has an id, to send the invoice to channels use "ACreateInvoiceUN" method.
+Deprecated: use "Invoice" method of the Sender, which takes the optional parameters as SendOption values (see WithInvoiceOptions).
 */
-func (bot *AdvancedBot) ACreateInvoice(chatId int, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, bool, allowSendingWithoutReply bool, keyboard *InlineKeyboard) (*Invoice, error) {
-	var replyMarkup objs.InlineKeyboardMarkup
-	if keyboard != nil {
-		if !keyboard.keys[0][0].Pay {
-			return nil, errors.New("first button in the keyboard should be a pay button")
-		}
-		replyMarkup = keyboard.toInlineKeyboardMarkup()
-	}
-	return &Invoice{
-		chatIdInt: chatId, chatIdString: "", title: title, description: description, providerToken: providerToken, currency: currency, prices: make([]objs.LabeledPrice, 0),
-		bot: bot.bot, replyMarkup: replyMarkup, suggestedTipAmounts: suggestedTipAmounts, photoURL: photoURL, startParameter: startParameter, providerData: providerData, payload: payload,
-		photoSize: photoSize, photoWidth: photoWidth, photoHeight: photoHeight, maxTipAmount: maxTipAmount, allowSendingWithoutReply: allowSendingWithoutReply, needName: needName, needPhoneNumber: needPhoneNumber,
-		needEmail: needEmail, needShippingAddress: needSippingAddress, sendPhoneNumberToProvider: sendPhoneNumberToProvider, sendEmailToProvider: sendEmailToProvider, isFlexible: isFlexible,
-	}, nil
-}
-
-/*ACreateInvoiceUN returns an InvoiceSender which has several methods for creating and sending an invoice.*/
-func (bot *AdvancedBot) ACreateInvoiceUN(chatId string, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, bool, allowSendingWithoutReply bool, keyboard *InlineKeyboard) (*Invoice, error) {
+func (bot *AdvancedBot) ACreateInvoice(chatId objs.ChatID, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, _, allowSendingWithoutReply bool, keyboard *InlineKeyboard) (*Invoice, error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		if !keyboard.keys[0][0].Pay {
@@ -596,7 +332,7 @@ func (bot *AdvancedBot) ACreateInvoiceUN(chatId string, title, description, payl
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
 	return &Invoice{
-		chatIdInt: 0, chatIdString: chatId, title: title, description: description, providerToken: providerToken, currency: currency, prices: make([]objs.LabeledPrice, 0),
+		chatId: chatId, title: title, description: description, providerToken: providerToken, currency: currency, prices: make([]objs.LabeledPrice, 0),
 		bot: bot.bot, replyMarkup: replyMarkup, suggestedTipAmounts: suggestedTipAmounts, photoURL: photoURL, startParameter: startParameter, providerData: providerData, payload: payload,
 		photoSize: photoSize, photoWidth: photoWidth, photoHeight: photoHeight, maxTipAmount: maxTipAmount, allowSendingWithoutReply: allowSendingWithoutReply, needName: needName, needPhoneNumber: needPhoneNumber,
 		needEmail: needEmail, needShippingAddress: needSippingAddress, sendPhoneNumberToProvider: sendPhoneNumberToProvider, sendEmailToProvider: sendEmailToProvider, isFlexible: isFlexible,
@@ -612,13 +348,13 @@ Official telegram doc :
 
 Use this method to send a game. On success, the sent Message is returned.
 */
-func (bot *AdvancedBot) ASendGame(chatId int, gameShortName string, silent bool, replyTo int, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
+func (bot *AdvancedBot) ASendGame(chatId int64, gameShortName string, silent bool, replyTo int64, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.Result[*objs.Message], error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
 	return bot.bot.apiInterface.SendGame(
-		chatId, gameShortName, silent, replyTo, allowSendingWithoutReply, replyMarkup,
+		chatId, gameShortName, silent, replyTo, 0, allowSendingWithoutReply, false, replyMarkup,
 	)
 }
 
@@ -639,7 +375,7 @@ Use this method to set the score of the specified user in a game message. On suc
 
 "inlineMessageId" : Required if chat_id and message_id are not specified. Identifier of the inline message.
 */
-func (bot *AdvancedBot) ASetGameScore(userId, score, chatId, messageId int, force, disableEditMessage bool, inlineMessageId string) (*objs.Result[json.RawMessage], error) {
+func (bot *AdvancedBot) ASetGameScore(userId int64, score int, chatId, messageId int64, force, disableEditMessage bool, inlineMessageId string) (*objs.Result[json.RawMessage], error) {
 	return bot.bot.apiInterface.SetGameScore(
 		userId, score, force, disableEditMessage, chatId, messageId, inlineMessageId,
 	)
@@ -650,7 +386,7 @@ SetPassportDataErrors informs a user that some of the Telegram Passport elements
 
 Use this if the data submitted by the user doesn't satisfy the standards your service requires for any reason. For example, if a birthday date seems invalid, a submitted document is blurry, a scan shows evidence of tampering, etc. Supply some details in the error message to make sure the user knows how to correct the issues.
 */
-func (bot *AdvancedBot) SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.Result[bool], error) {
+func (bot *AdvancedBot) SetPassportDataErrors(userId int64, errors []objs.PassportElementError) (*objs.Result[bool], error) {
 	return bot.bot.apiInterface.SetPassportDataErrors(
 		userId, errors,
 	)
@@ -701,6 +437,14 @@ RegisterChannel can be used to register special channels. Sepcial channels can b
 
 14. chat_join_request
 
+15. message_reaction
+
+16. message_reaction_count
+
+17. chat_boost
+
+18. removed_chat_boost
+
 bot "chatId" and "mediaType" arguments can be used together to create a channel that will be updated only if a certain type of update is received for a ceratin chat.
 
 Examples :
@@ -720,7 +464,8 @@ func (bot *AdvancedBot) RegisterChannel(chatId, mediaType string) (*chan *objs.U
 	if mediaType == "" {
 		mediaType = "all"
 	}
-	if mediaType != "all" && mediaType != "message" && mediaType != "edited_message" && mediaType != "channel_post" && mediaType != "edited_channel_post" && mediaType != "inline_query" && mediaType != "chosen_inline_result" && mediaType != "callback_query" && mediaType != "shipping_query" && mediaType != "pre_checkout_query" && mediaType != "poll_answer" && mediaType != "my_chat_member" && mediaType != "chat_member" && mediaType != "chat_join_request" {
+	//Polls are never sent to the channels, they are processed by the bot.
+	if mediaType != "all" && (!objs.IsUpdateType(mediaType) || mediaType == "poll") {
 		return nil, errors.New("unknown media type : " + mediaType)
 	}
 	return bot.getChannel(chatId, mediaType), nil
@@ -728,6 +473,8 @@ func (bot *AdvancedBot) RegisterChannel(chatId, mediaType string) (*chan *objs.U
 
 /*UnRegisterChannel can be used to unregister a channel for the given arguments*/
 func (bot *AdvancedBot) UnRegisterChannel(chatId, mediaType string) {
+	bot.bot.channelsMu.Lock()
+	defer bot.bot.channelsMu.Unlock()
 	if bot.bot.channelsMap[chatId] != nil {
 		bot.bot.channelsMap[chatId][mediaType] = nil
 		if len(bot.bot.channelsMap[chatId]) == 0 {
@@ -750,6 +497,8 @@ func (bot *AdvancedBot) AddMiddleware(md func(update *objs.Update, next func()))
 }
 
 func (bot *AdvancedBot) getChannel(chatId, media string) *chan *objs.Update {
+	bot.bot.channelsMu.Lock()
+	defer bot.bot.channelsMu.Unlock()
 	if bot.bot.channelsMap[chatId] == nil {
 		bot.bot.channelsMap[chatId] = make(map[string]*chan *objs.Update)
 	}
@@ -1,9 +1,14 @@
 package telego
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"os"
+	"strconv"
+	"sync"
+	"time"
 
 	cfg "github.com/hamidteimouri/telego/configs"
 	errs "github.com/hamidteimouri/telego/errors"
@@ -13,21 +18,61 @@ import (
 )
 
 type Bot struct {
-	botCfg                 *cfg.BotConfigs
-	apiInterface           *tba.BotAPIInterface
-	channelsMap            map[string]map[string]*chan *objs.Update
+	botCfg       *cfg.BotConfigs
+	apiInterface *tba.BotAPIInterface
+	channelsMap  map[string]map[string]*chan *objs.Update
+	//channelsMu guards channelsMap and migrationHandlers.
+	channelsMu             *sync.RWMutex
 	interfaceUpdateChannel *chan *objs.Update
 	chatUpdateChannel      *chan *objs.ChatUpdate
 	prcRoutineChannel      *chan bool
 	ab                     *AdvancedBot
-	logger                 *logger.BotLogger
+	logger                 logger.Logger
+	whReconciler           *webhookReconciler
+	webhook                *tba.Webhook
+	switchMu               sync.Mutex
+	migrationHandlers      *[]func(oldChatId, newChatId int64)
+	subscribers            *subscriberTracker
+}
+
+/*
+WithContext returns a copy of the bot whose API calls are traced as children of the span in the given context. Pass the context of an update (update.Context()) to trace the API calls made for that update :
+
+	bot.WithContext(update.Context()).SendMessage(...)
+
+The copy shares everything else with this bot. Use it only for calling API methods, not for starting, stopping or switching the bot.
+*/
+func (bot *Bot) WithContext(ctx context.Context) *Bot {
+	nb := &Bot{
+		botCfg:                 bot.botCfg,
+		apiInterface:           bot.apiInterface.WithContext(ctx),
+		channelsMap:            bot.channelsMap,
+		channelsMu:             bot.channelsMu,
+		migrationHandlers:      bot.migrationHandlers,
+		subscribers:            bot.subscribers,
+		interfaceUpdateChannel: bot.interfaceUpdateChannel,
+		chatUpdateChannel:      bot.chatUpdateChannel,
+		prcRoutineChannel:      bot.prcRoutineChannel,
+		logger:                 bot.logger,
+		whReconciler:           bot.whReconciler,
+		webhook:                bot.webhook,
+	}
+	nb.ab = &AdvancedBot{bot: nb}
+	return nb
 }
 
 /*Run starts the bot. If the bot has already been started it returns an error.*/
 func (bot *Bot) Run(autoPause bool) error {
-	logger.InitTheLogger(bot.botCfg)
-	if !bot.checkWebHook() {
-		bot.logger.GetRaw().Fatalln("Webhook check failed. See the logs for more info.")
+	forceSetWebhook := false
+	if bot.botCfg.Webhook {
+		generated, err := tba.EnsureWebhookCertificate(bot.botCfg.WebHookConfigs)
+		if err != nil {
+			return err
+		}
+		forceSetWebhook = generated
+	}
+	if !bot.checkWebHook(forceSetWebhook) {
+		return errors.New("webhook check failed. See the logs for more info")
 	}
 	go bot.startChatUpdateRoutine()
 	go bot.startUpdateProcessing()
@@ -35,10 +80,7 @@ func (bot *Bot) Run(autoPause bool) error {
 	go bot.botCfg.StartCfgUpdateRoutine()
 	var err error
 	if bot.botCfg.Webhook {
-		wh := tba.Webhook{
-			Logger: bot.logger,
-		}
-		err = wh.StartWebHook(bot.botCfg, bot.apiInterface.GetUpdateParser())
+		err = bot.startWebhook()
 	} else {
 		err = bot.apiInterface.StartUpdateRoutine()
 	}
@@ -52,40 +94,170 @@ func (bot *Bot) Run(autoPause bool) error {
 	return nil
 }
 
-func (bot *Bot) checkWebHook() bool {
+/*Starts the webhook server and the webhook reconciler.*/
+func (bot *Bot) startWebhook() error {
+	wh := &tba.Webhook{
+		Logger:               bot.logger,
+		OnCertificateRotated: bot.setWebhook,
+	}
+	err := wh.StartWebHook(bot.botCfg, bot.apiInterface.GetUpdateParser())
+	if err != nil {
+		return err
+	}
+	bot.webhook = wh
+	if bot.botCfg.WebHookConfigs.ReconcileInterval > 0 {
+		bot.whReconciler.run(bot.botCfg.WebHookConfigs.ReconcileInterval)
+	}
+	return nil
+}
+
+/*Stops the webhook reconciler and the webhook server. Requests that are being processed are completed before this method returns.*/
+func (bot *Bot) stopWebhook() error {
+	bot.whReconciler.halt()
+	if bot.webhook == nil {
+		return nil
+	}
+	err := bot.webhook.StopWebHook()
+	bot.webhook = nil
+	return err
+}
+
+/*
+SwitchToPolling switches a running bot that receives the updates via webhook to polling (getUpdates) without restarting the bot.
+
+The webhook reconciler is stopped first so it doesn't set the webhook again. Then the webhook is deleted from the API server without dropping the pending updates, the webhook server is stopped after the requests being processed are completed and finally the update routine is started. Updates that are sent in the meantime are kept by the API server and are received by the update routine, so no update is lost.
+If deleting the webhook fails, the bot keeps using webhook.
+
+If "UpdateConfigs" field of the bot configs is nil, the default update configs are used.
+*/
+func (bot *Bot) SwitchToPolling() error {
+	bot.switchMu.Lock()
+	defer bot.switchMu.Unlock()
+	if !bot.botCfg.Webhook {
+		return errors.New("the bot is already using polling")
+	}
+	bot.whReconciler.halt()
+	bot.botCfg.Webhook = false
+	err := bot.deleteWebhook()
+	if err != nil {
+		bot.botCfg.Webhook = true
+		if bot.webhook != nil && bot.botCfg.WebHookConfigs.ReconcileInterval > 0 {
+			bot.whReconciler.run(bot.botCfg.WebHookConfigs.ReconcileInterval)
+		}
+		return err
+	}
+	err = bot.stopWebhook()
+	if err != nil {
+		bot.logger.Error("Error stopping the webhook server", logger.Err(err))
+	}
+	if bot.botCfg.UpdateConfigs == nil {
+		bot.botCfg.UpdateConfigs = cfg.DefaultUpdateConfigs()
+	}
+	//The configs are saved right away, otherwise the config update routine reverts the changes.
+	err = cfg.Dump(bot.botCfg)
+	if err != nil {
+		bot.logger.Error("Unable to save the configs", logger.Err(err))
+	}
+	return bot.apiInterface.StartUpdateRoutine()
+}
+
+/*
+SwitchToWebhook switches a running bot that receives the updates via polling (getUpdates) to webhook without restarting the bot.
+
+"whcfg" is the webhook configs. If it is nil, "WebHookConfigs" field of the bot configs is used.
+
+The update routine is stopped first and the remaining updates are received until there is none left, so all the received updates are confirmed on the API server. Then the webhook server is started and the webhook is set. Updates that are sent in the meantime are kept by the API server and are delivered via webhook, so no update is lost or received twice. If starting the webhook fails, the bot goes back to polling.
+*/
+func (bot *Bot) SwitchToWebhook(whcfg *cfg.WebHookConfigs) error {
+	bot.switchMu.Lock()
+	defer bot.switchMu.Unlock()
+	if bot.botCfg.Webhook {
+		return errors.New("the bot is already using webhook")
+	}
+	oldCfg := bot.botCfg.WebHookConfigs
+	if whcfg != nil {
+		bot.botCfg.WebHookConfigs = whcfg
+	}
+	bot.botCfg.Webhook = true
+	if !bot.botCfg.Check() {
+		bot.botCfg.Webhook = false
+		bot.botCfg.WebHookConfigs = oldCfg
+		return errors.New("webhook configs check failed. Please check the configs")
+	}
+	//The configs are saved right away, otherwise the config update routine reverts the changes.
+	err := cfg.Dump(bot.botCfg)
+	if err != nil {
+		bot.logger.Error("Unable to save the configs", logger.Err(err))
+	}
+	bot.apiInterface.StopUpdateRoutine()
+	err = bot.apiInterface.FlushUpdates()
+	if err == nil {
+		_, err = tba.EnsureWebhookCertificate(bot.botCfg.WebHookConfigs)
+	}
+	if err == nil {
+		err = bot.startWebhook()
+	}
+	if err == nil {
+		err = bot.setWebhook()
+		if err != nil {
+			_ = bot.stopWebhook()
+		}
+	}
+	if err != nil {
+		bot.logger.Error("Unable to switch to webhook. Switching back to polling", logger.Err(err))
+		bot.botCfg.Webhook = false
+		bot.botCfg.WebHookConfigs = oldCfg
+		_ = cfg.Dump(bot.botCfg)
+		err2 := bot.apiInterface.StartUpdateRoutine()
+		if err2 != nil {
+			bot.logger.Error("Unable to start the update routine", logger.Err(err2))
+		}
+		return err
+	}
+	return nil
+}
+
+/*Checks the webhook set on the API server. If forceSet is true, the webhook is set again even if it has not changed (for example when the certificate has been renewed).*/
+func (bot *Bot) checkWebHook(forceSet bool) bool {
 	wi, err := bot.apiInterface.GetWebhookInfo()
 	if err != nil {
-		bot.logger.GetRaw().Println(err)
+		bot.logger.Error("Unable to get the webhook info", logger.Method("getWebhookInfo"), logger.Err(err))
 		return false
 	}
 	if wi.Result.URL == "" {
 		if bot.botCfg.Webhook {
 			err2 := bot.setWebhook()
 			if err2 != nil {
-				bot.logger.GetRaw().Println("Unable to set a new webhook.", err2)
+				bot.logger.Error("Unable to set a new webhook", logger.Method("setWebhook"), logger.Err(err2))
 				return false
 			}
 		}
 	} else {
 		if bot.botCfg.Webhook {
-			if wi.Result.URL != bot.botCfg.WebHookConfigs.URL {
-				bot.logger.GetRaw().Println("A webhook is already set in the API server to this url :", wi.Result.URL, ". Deleting the webhook ...")
+			if forceSet && wi.Result.URL == bot.botCfg.WebHookConfigs.URL {
+				err2 := bot.setWebhook()
+				if err2 != nil {
+					bot.logger.Error("Unable to set webhook", logger.Method("setWebhook"), logger.Err(err2))
+					return false
+				}
+			} else if wi.Result.URL != bot.botCfg.WebHookConfigs.URL {
+				bot.logger.Warn("A webhook is already set in the API server to another url. Deleting the webhook", logger.String("url", wi.Result.URL))
 				err2 := bot.deleteWebhook()
 				if err2 != nil {
-					bot.logger.GetRaw().Println("Unable to delete webhook.", err2)
+					bot.logger.Error("Unable to delete webhook", logger.Method("deleteWebhook"), logger.Err(err2))
 					return false
 				}
 				err3 := bot.setWebhook()
 				if err3 != nil {
-					bot.logger.GetRaw().Println("Unable to set webhook.", err3)
+					bot.logger.Error("Unable to set webhook", logger.Method("setWebhook"), logger.Err(err3))
 					return false
 				}
 			}
 		} else {
-			bot.logger.GetRaw().Println("A webhook has been set.")
+			bot.logger.Warn("A webhook has been set. Deleting the webhook", logger.String("url", wi.Result.URL))
 			err2 := bot.deleteWebhook()
 			if err2 != nil {
-				bot.logger.GetRaw().Println("Unable to delete webhook.", err2)
+				bot.logger.Error("Unable to delete webhook", logger.Method("deleteWebhook"), logger.Err(err2))
 				return false
 			}
 		}
@@ -95,15 +267,16 @@ func (bot *Bot) checkWebHook() bool {
 
 /*Sets a new webhook*/
 func (bot *Bot) setWebhook() error {
-	bot.logger.GetRaw().Println("Setting webhook ...")
+	bot.logger.Info("Setting webhook", logger.String("url", bot.botCfg.WebHookConfigs.URL))
 	whcfg := bot.botCfg.WebHookConfigs
-	var fl *os.File
+	var fl objs.NamedReader
 	if whcfg.SelfSigned {
-		var err2 error
-		fl, err2 = os.Open(whcfg.CertFile)
+		certFile, err2 := os.Open(whcfg.CertFile)
 		if err2 != nil {
 			return err2
 		}
+		defer certFile.Close()
+		fl = certFile
 	}
 	res, err3 := bot.apiInterface.SetWebhook(whcfg.URL, whcfg.IP, whcfg.MaxConnections, whcfg.AllowedUpdates, whcfg.DropPendingUpdates, fl)
 	if err3 != nil {
@@ -117,7 +290,7 @@ func (bot *Bot) setWebhook() error {
 
 /*Deletes a webhook*/
 func (bot *Bot) deleteWebhook() error {
-	bot.logger.GetRaw().Println("Deleting the webhook ...")
+	bot.logger.Info("Deleting the webhook")
 	res, err2 := bot.apiInterface.DeleteWebhook(false)
 	if err2 != nil {
 		return err2
@@ -128,6 +301,19 @@ func (bot *Bot) deleteWebhook() error {
 	return nil
 }
 
+/*
+GetWebhookStatus returns the status of the webhook as reported by the API server in the last check. The status is checked periodically if "ReconcileInterval" field of the webhook configs is not zero.
+If the bot does not use webhook or no check has been done yet, a zero WebhookStatus is returned.
+*/
+func (bot *Bot) GetWebhookStatus() WebhookStatus {
+	return bot.whReconciler.getStatus()
+}
+
+/*ReconcileWebhook checks the webhook status on the API server right away and sets the webhook again if its URL or allowed updates differ from the configs. Returns the new status.*/
+func (bot *Bot) ReconcileWebhook() WebhookStatus {
+	return bot.whReconciler.reconcile()
+}
+
 // BlockUser blocks a user based on their ID and username.
 func (bot *Bot) BlockUser(user *objs.User) {
 	for _, us := range bot.botCfg.BlockedUsers {
@@ -164,6 +350,43 @@ func (bot *Bot) AddHandler(pattern string, handler func(*objs.Update), chatTypes
 
 }
 
+/*
+AddMessageReactionHandler adds a handler which is called when a user changes the reactions to a message ("message_reaction" updates). The previous handler is replaced.
+
+The bot must be an administrator in the chat and "message_reaction" must be in the allowed updates of the bot to receive these updates.
+*/
+func (bot *Bot) AddMessageReactionHandler(handler func(*objs.Update)) {
+	bot.apiInterface.GetUpdateParser().AddUpdateTypeHandler("message_reaction", handler)
+}
+
+/*
+AddMessageReactionCountHandler adds a handler which is called when the anonymous reactions to a message change ("message_reaction_count" updates). The previous handler is replaced.
+
+The bot must be an administrator in the chat and "message_reaction_count" must be in the allowed updates of the bot to receive these updates.
+*/
+func (bot *Bot) AddMessageReactionCountHandler(handler func(*objs.Update)) {
+	bot.apiInterface.GetUpdateParser().AddUpdateTypeHandler("message_reaction_count", handler)
+}
+
+/*AddChatBoostHandler adds a handler which is called when a chat boost is added or changed ("chat_boost" updates). The previous handler is replaced. The bot must be an administrator in the chat to receive these updates.*/
+func (bot *Bot) AddChatBoostHandler(handler func(*objs.Update)) {
+	bot.apiInterface.GetUpdateParser().AddUpdateTypeHandler("chat_boost", handler)
+}
+
+/*AddRemovedChatBoostHandler adds a handler which is called when a boost is removed from a chat ("removed_chat_boost" updates). The previous handler is replaced. The bot must be an administrator in the chat to receive these updates.*/
+func (bot *Bot) AddRemovedChatBoostHandler(handler func(*objs.Update)) {
+	bot.apiInterface.GetUpdateParser().AddUpdateTypeHandler("removed_chat_boost", handler)
+}
+
+/*
+AddAlbumHandler sets the handler of the albums. Each message of an album is received in a separate update, so the messages which have a media group id are buffered until no other message of the album is received for "timeout" and then the whole album is passed to the handler once, sorted by the message ids.
+If timeout is not positive, one second is used. Albums are not passed to the other handlers and channels while the album handler is set. Calling it again replaces the previous handler and passing nil removes it.
+The albums which are still being collected are passed to the handler when it is replaced or removed and when the bot stops. Each album is handled in a new tracing span, since the spans of its updates have already ended.
+*/
+func (bot *Bot) AddAlbumHandler(handler func(album []*objs.Update), timeout time.Duration) {
+	bot.apiInterface.GetUpdateParser().SetAlbumHandler(handler, timeout)
+}
+
 /*
 GetMe returns the received informations about the bot from api server.
 
@@ -183,53 +406,56 @@ func (bot *Bot) GetBotManager() *BotManager {
 }
 
 /*
-SendMessage sens a text message to a chat (not channel, use SendMessageUN method for sending messages to channles) and returns the sent message on success
+SendMessage sends a text message to a chat or a channel and returns the sent message on success
 If you want to ignore "parseMode" pass empty string. To ignore replyTo pass 0.
 
 If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) SendMessage(chatId int, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendMessage(chatId, "", text, parseMode, nil, false, silent, false, protectContent, replyTo, 0, nil)
+func (bot *Bot) SendMessage(chatId objs.ChatID, text, parseMode string, replyTo int64, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+	return bot.apiInterface.SendMessage(chatId, text, parseMode, nil, false, silent, false, protectContent, replyTo, 0, nil)
 }
 
-/*
-SendMessageUN sens a text message to a channel and returns the sent message on success
-If you want to ignore "parseMode" pass empty string. To ignore replyTo pass 0.
-If "silent" argument is true, the message will be sent without notification.
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *Bot) SendMessageUN(chatId, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendMessage(0, chatId, text, parseMode, nil, false, silent, false, protectContent, replyTo, 0, nil)
+func (bot *Bot) PinChatMessage(chatId objs.ChatID, messageId int64, disableNotification bool) (*objs.Result[bool], error) {
+	return bot.apiInterface.PinChatMessage(chatId, messageId, disableNotification)
 }
 
-func (bot *Bot) PinChatMessage(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.Result[bool], error) {
-	return bot.apiInterface.PinChatMessage(chatIdInt, chatIdString, messageId, disableNotification)
+func (bot *Bot) UnpinChatMessage(chatId objs.ChatID, messageId int64) (*objs.Result[bool], error) {
+	return bot.apiInterface.UnpinChatMessage(chatId, messageId)
 }
 
-func (bot *Bot) UnpinChatMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error) {
-	return bot.apiInterface.UnpinChatMessage(chatIdInt, chatIdString, messageId)
+func (bot *Bot) UnpinAllChatMessages(chatId objs.ChatID) (*objs.Result[bool], error) {
+	return bot.apiInterface.UnpinAllChatMessages(chatId)
 }
 
-func (bot *Bot) UnpinAllChatMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	return bot.apiInterface.UnpinAllChatMessages(chatIdInt, chatIdString)
+func (bot *Bot) CreateChatInviteLink(chatId objs.ChatID, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
+	return bot.apiInterface.CreateChatInviteLink(chatId, name, expireDate, memberLimit, createsJoinRequest)
 }
 
-func (bot *Bot) CreateChatInviteLink(chatIdInt int, chatIdString, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
-	return bot.apiInterface.CreateChatInviteLink(chatIdInt, chatIdString, name, expireDate, memberLimit, createsJoinRequest)
+func (bot *Bot) GetChatMember(chatId objs.ChatID, userId int64) (*objs.Result[objs.ChatMember], error) {
+	return bot.apiInterface.GetChatMember(chatId, userId)
 }
 
-func (bot *Bot) GetChatMember(chatIdInt int, chatIdString string, userId int) (*objs.Result[json.RawMessage], error) {
-	return bot.apiInterface.GetChatMember(chatIdInt, chatIdString, userId)
+func (bot *Bot) BanChatMember(chatId objs.ChatID, userId int64, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
+	return bot.apiInterface.BanChatMember(chatId, userId, untilDate, revokeMessages)
 }
 
-func (bot *Bot) BanChatMember(chatIdInt int, chatIdString string, userId, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
-	return bot.apiInterface.BanChatMember(chatIdInt, chatIdString, userId, untilDate, revokeMessages)
+func (bot *Bot) UnbanChatMember(chatId objs.ChatID, userId int64, onlyIfBanned bool) (*objs.Result[bool], error) {
+	return bot.apiInterface.UnbanChatMember(chatId, userId, onlyIfBanned)
+}
+
+/*
+SetMessageReaction changes the reactions of the bot to the given message. Pass no reaction to remove the reactions of the bot. "isBig" sets the reaction with a big animation.
+Use objs.EmojiReaction and objs.CustomEmojiReaction to create the reactions.
+*/
+func (bot *Bot) SetMessageReaction(chatId objs.ChatID, messageId int64, isBig bool, reactions ...objs.ReactionType) (*objs.Result[bool], error) {
+	return bot.apiInterface.SetMessageReaction(&objs.SetMessageReactionArgs{ChatId: chatId, MessageId: messageId, Reaction: reactions, IsBig: isBig})
 }
 
-func (bot *Bot) UnbanChatMember(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.Result[bool], error) {
-	return bot.apiInterface.UnbanChatMember(chatIdInt, chatIdString, userId, onlyIfBanned)
+/*GetUserChatBoosts returns the list of the boosts added to the chat by the user. The bot must be an administrator in the chat.*/
+func (bot *Bot) GetUserChatBoosts(chatId objs.ChatID, userId int64) (*objs.Result[*objs.UserChatBoosts], error) {
+	return bot.apiInterface.GetUserChatBoosts(&objs.GetUserChatBoostsArgs{ChatId: chatId, UserId: userId})
 }
 
 func (bot *Bot) SetMyCommands(commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error) {
@@ -241,7 +467,7 @@ ForwardMessage returns a MessageForwarder which has several methods for forwardi
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) ForwardMessage(messageId int, disableNotif, protectContent bool) *MessageForwarder {
+func (bot *Bot) ForwardMessage(messageId int64, disableNotif, protectContent bool) *MessageForwarder {
 	return &MessageForwarder{bot: bot, messageId: messageId, disableNotif: disableNotif, protectContent: protectContent}
 }
 
@@ -250,28 +476,20 @@ CopyMessage returns a MessageCopier which has several methods for copying a mess
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) CopyMessage(messageId int, disableNotif, protectContent bool) *MessageCopier {
+func (bot *Bot) CopyMessage(messageId int64, disableNotif, protectContent bool) *MessageCopier {
 	return &MessageCopier{bot: bot, messageId: messageId, disableNotif: disableNotif, protectContent: protectContent}
 }
 
 /*
-SendPhoto returns a MediaSender which has several methods for sending a photo. This method is only used for sending a photo to all types of chat except channels. To send a photo to a channel use "SendPhotoUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-*/
-func (bot *Bot) SendPhoto(chatId, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: PHOTO, bot: bot, chatIdInt: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
-}
-
-/*
-SendPhotoUN returns a MediaSender which has several methods for sending a photo. This method is only used for sending a photo to a channels.
+SendPhoto returns a MediaSender which has several methods for sending a photo.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 */
-func (bot *Bot) SendPhotoUN(chatId string, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: PHOTO, bot: bot, chatIdInt: 0, username: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
+func (bot *Bot) SendPhoto(chatId objs.ChatID, replyTo int64, caption, parseMode string, hasSpoiler bool) *MediaSender {
+	return &MediaSender{mediaType: PHOTO, bot: bot, chatId: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
 }
 
 /*
-SendVideo returns a MediaSender which has several methods for sending a video. This method is only used for sending a video to all types of chat except channels. To send a video to a channel use "SendVideoUN" method.
+SendVideo returns a MediaSender which has several methods for sending a video.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -280,42 +498,12 @@ Official telegram doc :
 
 Use this method to send video files, Telegram clients support mp4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *Bot) SendVideo(chatId int, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: VIDEO, bot: bot, chatIdInt: chatId, username: "", replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
+func (bot *Bot) SendVideo(chatId objs.ChatID, replyTo int64, caption, parseMode string, hasSpoiler bool) *MediaSender {
+	return &MediaSender{mediaType: VIDEO, bot: bot, chatId: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
 }
 
 /*
-SendVideoUN returns a MediaSender which has several methods for sending a video. This method is only used for sending a video to a channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send video files, Telegram clients support mp4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *Bot) SendVideoUN(chatId string, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: VIDEO, bot: bot, chatIdInt: 0, username: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
-}
-
-/*
-SendAudio returns a MediaSender which has several methods for sending a audio. This method is only used for sending a audio to all types of chat except channels. To send a audio to a channel use "SendAudioUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send audio files, if you want Telegram clients to display them in the music player. Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned. Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
-
-For sending voice messages, use the sendVoice method instead.
-*/
-func (bot *Bot) SendAudio(chatId, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: AUDIO, bot: bot, chatIdInt: chatId, username: "", replyTo: replyTo, caption: caption, parseMode: parseMode}
-}
-
-/*
-SendAudioUN returns a MediaSender which has several methods for sending a audio. This method is only used for sending a audio to a channels.
+SendAudio returns a MediaSender which has several methods for sending a audio.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -326,12 +514,12 @@ Use this method to send audio files, if you want Telegram clients to display the
 
 For sending voice messages, use the sendVoice method instead.
 */
-func (bot *Bot) SendAudioUN(chatId string, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: AUDIO, bot: bot, chatIdInt: 0, username: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode}
+func (bot *Bot) SendAudio(chatId objs.ChatID, replyTo int64, caption, parseMode string) *MediaSender {
+	return &MediaSender{mediaType: AUDIO, bot: bot, chatId: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode}
 }
 
 /*
-SendDocument returns a MediaSender which has several methods for sending a document. This method is only used for sending a document to all types of chat except channels. To send a audio to a channel use "SendDocumentUN" method.
+SendDocument returns a MediaSender which has several methods for sending a document.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -340,40 +528,12 @@ Official telegram doc :
 
 Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *Bot) SendDocument(chatId, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: DOCUMENT, bot: bot, chatIdInt: chatId, username: "", replyTo: replyTo, caption: caption, parseMode: parseMode}
+func (bot *Bot) SendDocument(chatId objs.ChatID, replyTo int64, caption, parseMode string) *MediaSender {
+	return &MediaSender{mediaType: DOCUMENT, bot: bot, chatId: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode}
 }
 
 /*
-SendDocumentUN returns a MediaSender which has several methods for sending a document. This method is only used for sending a document to a channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *Bot) SendDocumentUN(chatId string, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: DOCUMENT, bot: bot, chatIdInt: 0, username: chatId, replyTo: replyTo, caption: caption, parseMode: parseMode}
-}
-
-/*
-SendAnimation returns a MediaSender which has several methods for sending an animation. This method is only used for sending an animation to all types of chat except channels. To send a audio to a channel use "SendAnimationUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *Bot) SendAnimation(chatId int, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: ANIMATION, chatIdInt: chatId, username: "", replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
-}
-
-/*
-SendAnimationUN returns a MediaSender which has several methods for sending an animation. This method is only used for sending an animation to channels
+SendAnimation returns a MediaSender which has several methods for sending an animation.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -382,12 +542,12 @@ Official telegram doc :
 
 Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *Bot) SendAnimationUN(chatId string, replyTo int, caption, parseMode string, hasSpoiler bool) *MediaSender {
-	return &MediaSender{mediaType: ANIMATION, chatIdInt: 0, username: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
+func (bot *Bot) SendAnimation(chatId objs.ChatID, replyTo int64, caption, parseMode string, hasSpoiler bool) *MediaSender {
+	return &MediaSender{mediaType: ANIMATION, chatId: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode, hasSpoiler: hasSpoiler}
 }
 
 /*
-SendVoice returns a MediaSender which has several methods for sending a voice. This method is only used for sending a voice to all types of chat except channels. To send a voice to a channel use "SendVoiceUN" method.
+SendVoice returns a MediaSender which has several methods for sending a voice.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -396,40 +556,12 @@ Official telegram doc :
 
 Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 */
-func (bot *Bot) SendVoice(chatId int, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: VOICE, chatIdInt: chatId, username: "", replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
+func (bot *Bot) SendVoice(chatId objs.ChatID, replyTo int64, caption, parseMode string) *MediaSender {
+	return &MediaSender{mediaType: VOICE, chatId: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
 }
 
 /*
-SendVoiceUN returns an MediaSender which has several methods for sending a voice. This method is only used for sending a voice to channels.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
-*/
-func (bot *Bot) SendVoiceUN(chatId string, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: VOICE, chatIdInt: 0, username: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
-}
-
-/*
-SendVideoNote returns a MediaSender which has several methods for sending a video note. This method is only used for sending a video note to all types of chat except channels. To send a video note to a channel use "SendVideoNoteUN" method.
-To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
-
----------------------------------
-
-Official telegram doc :
-
-As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
-*/
-func (bot *Bot) SendVideoNote(chatId int, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: VIDEONOTE, chatIdInt: chatId, username: "", replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
-}
-
-/*
-SendVideoNoteUN returns an MediaSender which has several methods for sending a video note. This method is only used for sending a video note to channels.
+SendVideoNote returns a MediaSender which has several methods for sending a video note.
 To ignore int arguments pass 0 and to ignore string arguments pass empty string ("")
 
 ---------------------------------
@@ -438,20 +570,20 @@ Official telegram doc :
 
 As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
 */
-func (bot *Bot) SendVideoNoteUN(chatId string, replyTo int, caption, parseMode string) *MediaSender {
-	return &MediaSender{mediaType: VIDEONOTE, chatIdInt: 0, username: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
+func (bot *Bot) SendVideoNote(chatId objs.ChatID, replyTo int64, caption, parseMode string) *MediaSender {
+	return &MediaSender{mediaType: VIDEONOTE, chatId: chatId, replyTo: replyTo, bot: bot, caption: caption, parseMode: parseMode}
 }
 
 /*
 CreateAlbum returns a MediaGroup for grouping media messages.
 To ignore replyTo argument, pass 0.
 */
-func (bot *Bot) CreateAlbum(replyTo int) *MediaGroup {
-	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0)}
+func (bot *Bot) CreateAlbum(replyTo int64) *MediaGroup {
+	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]objs.NamedReader, 0)}
 }
 
 /*
-SendVenue sends a venue to all types of chat but channels. To send it to channels use "SendVenueUN" method.
+SendVenue sends a venue to the given chat or channel.
 
 ---------------------------------
 
@@ -463,33 +595,14 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) SendVenue(chatId, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+func (bot *Bot) SendVenue(chatId objs.ChatID, replyTo int64, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
 	return bot.apiInterface.SendVenue(
-		chatId, "", latitude, longitude, title, address, "", "", "", "", replyTo, 0, silent, false, protectContent, nil,
+		chatId, latitude, longitude, title, address, "", "", "", "", replyTo, 0, silent, false, protectContent, nil,
 	)
 }
 
 /*
-SendVenueUN sends a venue to a channel.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send information about a venue. On success, the sent Message is returned.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *Bot) SendVenueUN(chatId string, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendVenue(
-		0, chatId, latitude, longitude, title, address, "", "", "", "", replyTo, 0, silent, false, protectContent, nil,
-	)
-}
-
-/*
-SendContact sends a contact to all types of chat but channels. To send it to channels use "SendContactUN" method.
+SendContact sends a contact to the given chat or channel.
 
 ---------------------------------
 
@@ -501,78 +614,26 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) SendContact(chatId, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+func (bot *Bot) SendContact(chatId objs.ChatID, replyTo int64, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
 	return bot.apiInterface.SendContact(
-		chatId, "", phoneNumber, firstName, lastName, "", replyTo, 0, silent, false, protectContent, nil,
+		chatId, phoneNumber, firstName, lastName, "", replyTo, 0, silent, false, protectContent, nil,
 	)
 }
 
 /*
-SendContactUN sends a contact to a channel.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send phone contacts. On success, the sent Message is returned.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *Bot) SendContactUN(chatId string, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendContact(
-		0, chatId, phoneNumber, firstName, lastName, "", replyTo, 0, silent, false, protectContent, nil,
-	)
-}
-
-/*
-CreatePoll creates a poll for all types of chat but channels. To create a poll for channels use "CreatePollForChannel" method.
-
-The poll type can be "regular" or "quiz"
-*/
-func (bot *Bot) CreatePoll(chatId int, question, pollType string) (*Poll, error) {
-	if pollType != "quiz" && pollType != "regular" {
-		return nil, errors.New("poll type invalid : " + pollType)
-	}
-	return &Poll{bot: bot, pollType: pollType, chatIdInt: chatId, question: question, options: make([]string, 0)}, nil
-}
-
-/*
-CreatePollForChannel creates a poll for a channel.
+CreatePoll creates a poll for the given chat or channel.
 
 The poll type can be "regular" or "quiz"
 */
-func (bot *Bot) CreatePollForChannel(chatId, question, pollType string) (*Poll, error) {
+func (bot *Bot) CreatePoll(chatId objs.ChatID, question, pollType string) (*Poll, error) {
 	if pollType != "quiz" && pollType != "regular" {
 		return nil, errors.New("poll type invalid : " + pollType)
 	}
-	return &Poll{bot: bot, pollType: pollType, chatIdString: chatId, question: question, options: make([]string, 0)}, nil
-}
-
-/*
-SendDice sends a dice message to all types of chat but channels. To send it to channels use "SendDiceUN" method.
-
-Available emojies : “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *Bot) SendDice(chatId, replyTo int, emoji string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendDice(
-		chatId, "", emoji, replyTo, 0, silent, false, protectContent, nil,
-	)
+	return &Poll{bot: bot, pollType: pollType, chatId: chatId, question: question, options: make([]string, 0)}, nil
 }
 
 /*
-SendDiceUN sends a dice message to a channel.
+SendDice sends a dice message to the given chat or channel.
 
 Available emojies : “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
 
@@ -586,14 +647,14 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) SendDiceUN(chatId string, replyTo int, emoji string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+func (bot *Bot) SendDice(chatId objs.ChatID, replyTo int64, emoji string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
 	return bot.apiInterface.SendDice(
-		0, chatId, emoji, replyTo, 0, silent, false, protectContent, nil,
+		chatId, emoji, replyTo, 0, silent, false, protectContent, nil,
 	)
 }
 
 /*
-SendChatAction sends a chat action message to all types of chat but channels. To send it to channels use "SendChatActionUN" method.
+SendChatAction sends a chat action message to the given chat or channel.
 Note : messageThreadId is unique identifier for the target message thread (supergroups only) which can be used for sending chat actions to a specific message thread or a forum topic.
 
 ---------------------------------
@@ -608,53 +669,12 @@ We only recommend using this method when a response from the bot will take a not
 
 action is the type of action to broadcast. Choose one, depending on what the user is about to receive: typing for text messages, upload_photo for photos, record_video or upload_video for videos, record_voice or upload_voice for voice notes, upload_document for general files, choose_sticker for stickers, find_location for location data, record_video_note or upload_video_note for video notes.
 */
-func (bot *Bot) SendChatAction(chatId, messageThreadId int, action string) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendChatAction(chatId, messageThreadId, "", action)
+func (bot *Bot) SendChatAction(chatId objs.ChatID, messageThreadId int64, action string) (*objs.Result[*objs.Message], error) {
+	return bot.apiInterface.SendChatAction(chatId, messageThreadId, action)
 }
 
 /*
-SendChatActionUN sends a chat action message to a channel.
-Note : messageThreadId is unique identifier for the target message thread (supergroups only) which can be used for sending chat actions to a specific message thread or a forum topic.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method when you need to tell the user that something is happening on the bot's side. The status is set for 5 seconds or less (when a message arrives from your bot, Telegram clients clear its typing status). Returns True on success.
-
-Example: The ImageBot needs some time to process a request and upload the image. Instead of sending a text message along the lines of “Retrieving image, please wait…”, the bot may use sendChatAction with action = upload_photo. The user will see a “sending photo” status for the bot.
-
-We only recommend using this method when a response from the bot will take a noticeable amount of time to arrive.
-
-action is the type of action to broadcast. Choose one, depending on what the user is about to receive: typing for text messages, upload_photo for photos, record_video or upload_video for videos, record_voice or upload_voice for voice notes, upload_document for general files, choose_sticker for stickers, find_location for location data, record_video_note or upload_video_note for video notes.
-*/
-func (bot *Bot) SendChatActionUN(chatId, action string, messageThreadId int) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendChatAction(0, messageThreadId, chatId, action)
-}
-
-/*
-SendLocation sends a location (not live) to all types of chats but channels. To send it to channel use "SendLocationUN" method.
-
-You can not use this methods to send a live location. To send a live location use AdvancedBot.
-
----------------------------------
-
-Official telegram doc :
-
-Use this method to send point on the map. On success, the sent Message is returned.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (bot *Bot) SendLocation(chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.Result[*objs.Message], error) {
-	return bot.apiInterface.SendLocation(
-		chatId, "", latitude, longitude, accuracy, 0, 0, 0, replyTo, 0, silent, false, protectContent, nil,
-	)
-}
-
-/*
-SendLocationUN sends a location (not live) to a channel.
+SendLocation sends a location (not live) to the given chat or channel.
 
 You can not use this methods to send a live location. To send a live location use AdvancedBot.
 
@@ -668,9 +688,9 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (bot *Bot) SendLocationUN(chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.Result[*objs.Message], error) {
+func (bot *Bot) SendLocation(chatId objs.ChatID, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int64) (*objs.Result[*objs.Message], error) {
 	return bot.apiInterface.SendLocation(
-		0, chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, 0, silent, false, protectContent, nil,
+		chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, 0, silent, false, protectContent, nil,
 	)
 }
 
@@ -685,7 +705,7 @@ Official telegram doc :
 
 Use this method to get a list of profile pictures for a user. Returns a UserProfilePhotos object.
 */
-func (bot *Bot) GetUserProfilePhotos(userId, offset, limit int) (*objs.Result[*objs.UserProfilePhotos], error) {
+func (bot *Bot) GetUserProfilePhotos(userId int64, offset, limit int) (*objs.Result[*objs.UserProfilePhotos], error) {
 	return bot.apiInterface.GetUserProfilePhotos(userId, offset, limit)
 }
 
@@ -693,6 +713,7 @@ func (bot *Bot) GetUserProfilePhotos(userId, offset, limit int) (*objs.Result[*o
 GetFile gets a file from telegram server. If it is successful the File object is returned.
 
 If "download option is true, the file will be saved into the given file and if the given file is nil file will be saved in the same name as it has been saved in telegram servers.
+The given file is not closed. To download the file into any io.Writer use "DownloadFile" method.
 */
 func (bot *Bot) GetFile(fileId string, download bool, file *os.File) (*objs.File, error) {
 	res, err := bot.apiInterface.GetFile(fileId)
@@ -709,21 +730,38 @@ func (bot *Bot) GetFile(fileId string, download bool, file *os.File) (*objs.File
 }
 
 /*
-GetChatManagerById creates and returns a ChatManager for groups and other chats witch an integer id.
+DownloadFile gets the file with the given id and writes its content into the writer. The writer is not closed. If it is successful the File object is returned.
 
-To manage supergroups and channels which have usernames use "GetChatManagerByUsername".
+The file is downloaded from the configured API server and the download is canceled when the context is done. Options can be nil, otherwise the download fails with errs.FileTooLargeError if the file is larger than "MaxSize" and "OnProgress" is called while the file is being downloaded.
 */
-func (bot *Bot) GetChatManagerById(chatId int) *ChatManager {
-	return &ChatManager{bot: bot, chatIdInt: chatId, chatIdString: ""}
+func (bot *Bot) DownloadFile(ctx context.Context, fileId string, wr io.Writer, opts *objs.DownloadOptions) (*objs.File, error) {
+	res, err := bot.apiInterface.GetFile(fileId)
+	if err != nil {
+		return nil, err
+	}
+	_, err = bot.apiInterface.DownloadFileTo(ctx, res.Result, wr, opts)
+	return res.Result, err
 }
 
 /*
-GetChatManagerByUsrename creates and returns a ChatManager for supergroups and channels which have usernames
-
-To manage groups and other chats witch an integer id use "GetChatManagerById".
+OpenFile gets the file with the given id and returns a reader which streams its content from the API server. The reader should be closed.
+See "DownloadFile" for the options.
 */
-func (bot *Bot) GetChatManagerByUsrename(userName string) *ChatManager {
-	return &ChatManager{bot: bot, chatIdInt: 0, chatIdString: userName}
+func (bot *Bot) OpenFile(ctx context.Context, fileId string, opts *objs.DownloadOptions) (io.ReadCloser, *objs.File, error) {
+	res, err := bot.apiInterface.GetFile(fileId)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := bot.apiInterface.OpenFile(ctx, res.Result, opts)
+	if err != nil {
+		return nil, res.Result, err
+	}
+	return rc, res.Result, nil
+}
+
+/*GetChatManager creates and returns a ChatManager for the given chat. Supergroups and channels can be identified by their usernames.*/
+func (bot *Bot) GetChatManager(chatId objs.ChatID) *ChatManager {
+	return &ChatManager{bot: bot, chatId: chatId}
 }
 
 /*
@@ -741,22 +779,14 @@ func (bot *Bot) GetCommandManager() *CommandsManager {
 }
 
 /*
-GetMsgEditor returns a MessageEditor for a chat with id which has several methods for editing messages.
-
-To edit messages in a channel or a chat with username, use "GetMsgEditorWithUN"
+GetMsgEditor returns a MessageEditor for the given chat or channel which has several methods for editing messages.
 */
-func (bot *Bot) GetMsgEditor(chatId int) *MessageEditor {
-	return &MessageEditor{bot: bot, chatIdInt: chatId}
-}
-
-/*GetMsgEditorWithUN returns a MessageEditor for a chat with username which has several methods for editing messages.*/
-func (bot *Bot) GetMsgEditorWithUN(chatId string) *MessageEditor {
-	return &MessageEditor{bot: bot, chatIdInt: 0, chatIdString: chatId}
+func (bot *Bot) GetMsgEditor(chatId objs.ChatID) *MessageEditor {
+	return &MessageEditor{bot: bot, chatId: chatId}
 }
 
 /*
-SendSticker returns a MediaSender which has several methods for sending an sticker to all types of chats but channels.
-To send it to a channel use "SendStickerWithUN".
+SendSticker returns a MediaSender which has several methods for sending an sticker to the given chat or channel.
 
 --------------------
 
@@ -764,21 +794,8 @@ Official telegram doc :
 
 Use this method to send static .WEBP or animated .TGS stickers. On success, the sent Message is returned
 */
-func (bot *Bot) SendSticker(chatId, replyTo int, eomji string) *MediaSender {
-	return &MediaSender{mediaType: STICKER, bot: bot, chatIdInt: chatId, username: "", replyTo: replyTo, stickerEmoji: eomji}
-}
-
-/*
-SendStickerWithUn returns a MediaSender which has several methods for sending an sticker to channels.
-
---------------------
-
-Official telegram doc :
-
-Use this method to send static .WEBP or animated .TGS stickers. On success, the sent Message is returned
-*/
-func (bot *Bot) SendStickerWithUn(chatId string, replyTo int, eomji string) *MediaSender {
-	return &MediaSender{mediaType: STICKER, bot: bot, chatIdInt: 0, username: chatId, replyTo: replyTo, stickerEmoji: eomji}
+func (bot *Bot) SendSticker(chatId objs.ChatID, replyTo int64, eomji string) *MediaSender {
+	return &MediaSender{mediaType: STICKER, bot: bot, chatId: chatId, replyTo: replyTo, stickerEmoji: eomji}
 }
 
 /*GetStickerSet returns an sticker set with the given name*/
@@ -791,13 +808,21 @@ func (bot *Bot) GetStickerSet(name string) (*StickerSet, error) {
 }
 
 /*UploadStickerFile can be used to upload a .PNG file with a sticker for later use in CreateNewStickerSet and AddStickerToSet methods (can be used multiple times). Returns the uploaded File on success.*/
-func (bot *Bot) UploadStickerFile(userId int, stickerFormat string, eomjis, keywords []string, stickerFile *os.File) (*objs.Result[*objs.File], error) {
-	stat, err := stickerFile.Stat()
-	if err != nil {
+func (bot *Bot) UploadStickerFile(userId int64, stickerFormat string, eomjis, keywords []string, stickerFile *os.File) (*objs.Result[*objs.File], error) {
+	if _, err := stickerFile.Stat(); err != nil {
 		return nil, err
 	}
+	return bot.uploadStickerFile(userId, stickerFormat, eomjis, keywords, stickerFile)
+}
+
+/*UploadStickerByReader works like UploadStickerFile but uploads the content of the reader as a file with the given name.*/
+func (bot *Bot) UploadStickerByReader(userId int64, stickerFormat string, eomjis, keywords []string, name string, reader io.Reader) (*objs.Result[*objs.File], error) {
+	return bot.uploadStickerFile(userId, stickerFormat, eomjis, keywords, objs.NewNamedReader(name, reader))
+}
+
+func (bot *Bot) uploadStickerFile(userId int64, stickerFormat string, eomjis, keywords []string, stickerFile objs.NamedReader) (*objs.Result[*objs.File], error) {
 	return bot.apiInterface.UploadStickerFile(userId, stickerFormat, &objs.InputSticker{
-		Sticker:   "attach://" + stat.Name(),
+		Sticker:   objs.AttachName(stickerFile),
 		EmojiList: eomjis,
 		KeyWords:  keywords,
 	}, stickerFile)
@@ -815,7 +840,7 @@ func (bot *Bot) GetStickerEditor(stickerFileId string) *StickerEditor {
 /*
 Deprecated : This method has been completely deprecated. Use CreateStickerSet instead.
 */
-func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrUrl string, pngStickerFile *os.File, tgsSticker *os.File, webmSticker *os.File, emojies string, containsMask bool, maskPosition *objs.MaskPosition) (*StickerSet, error) {
+func (bot *Bot) CreateNewStickerSet(userId int64, name, title, pngStickerFileIdOrUrl string, pngStickerFile, tgsSticker, webmSticker *os.File, emojies string, containsMask bool, maskPosition *objs.MaskPosition) (*StickerSet, error) {
 	// var res *objs.Result[bool]
 	// var err error
 	// if tgsSticker == nil {
@@ -885,11 +910,11 @@ Arguments :
 
 6. needsRepainting : Pass True if stickers in the sticker set must be repainted to the color of text when used in messages, the accent color if used as emoji status, white on chat photos, or another appropriate color based on context; for custom emoji sticker sets only
 */
-func (bot *Bot) CreateStickerSet(userId int, name, title, stickerFormat, stickerType string, needsRepainting bool) *StickerSet {
+func (bot *Bot) CreateStickerSet(userId int64, name, title, stickerFormat, stickerType string, needsRepainting bool) *StickerSet {
 	return &StickerSet{
 		bot:             bot,
 		initStickers:    make([]*objs.InputSticker, 0),
-		initFiles:       make([]*os.File, 0),
+		initFiles:       make([]objs.NamedReader, 0),
 		userId:          userId,
 		name:            name,
 		title:           title,
@@ -931,24 +956,11 @@ func (bot *Bot) AnswerWebAppQuery(webAppQueryId string) *InlineQueryResponder {
 /*
 CreateInvoice returns an InvoiceSender which has several methods for creating and sending an invoice.
 
-This method is suitable for sending this invoice to a chat that has an id, to send the invoice to channels use "CreateInvoiceUN" method.
-
-To access more options, use "ACreateInvoice" method in advanced mode.
-*/
-func (bot *Bot) CreateInvoice(chatId int, title, description, payload, providerToken, currency string) *Invoice {
-	return &Invoice{
-		bot: bot, chatIdInt: chatId, chatIdString: "", title: title, description: description, providerToken: providerToken, payload: payload, currency: currency, prices: make([]objs.LabeledPrice, 0),
-	}
-}
-
-/*
-CreateInvoiceUN returns an InvoiceSender which has several methods for creating and sending an invoice.
-
-To access more options, use "ACreateInvoiceUN" method in advanced mode.
+To send an invoice with more options, use "Invoice" method of the Sender.
 */
-func (bot *Bot) CreateInvoiceUN(chatId, title, description, payload, providerToken, currency string) *Invoice {
+func (bot *Bot) CreateInvoice(chatId objs.ChatID, title, description, payload, providerToken, currency string) *Invoice {
 	return &Invoice{
-		chatIdInt: 0, chatIdString: chatId, title: title, description: description, providerToken: providerToken, currency: currency, payload: payload, prices: make([]objs.LabeledPrice, 0),
+		bot: bot, chatId: chatId, title: title, description: description, providerToken: providerToken, payload: payload, currency: currency, prices: make([]objs.LabeledPrice, 0),
 	}
 }
 
@@ -999,9 +1011,9 @@ Official telegram doc :
 
 Use this method to send a game. On success, the sent Message is returned.
 */
-func (bot *Bot) SendGame(chatId int, gameShortName string, silent bool, replyTo int) (*objs.Result[*objs.Message], error) {
+func (bot *Bot) SendGame(chatId int64, gameShortName string, silent bool, replyTo int64) (*objs.Result[*objs.Message], error) {
 	return bot.apiInterface.SendGame(
-		chatId, gameShortName, silent, replyTo, false, nil,
+		chatId, gameShortName, silent, replyTo, 0, false, false, nil,
 	)
 }
 
@@ -1017,7 +1029,7 @@ Use this method to set the score of the specified user in a game message. On suc
 
 "score" is new score, must be non-negative.
 */
-func (bot *Bot) SetGameScore(userId, score, chatId, messageId int) (*objs.Result[json.RawMessage], error) {
+func (bot *Bot) SetGameScore(userId int64, score int, chatId, messageId int64) (*objs.Result[json.RawMessage], error) {
 	return bot.apiInterface.SetGameScore(
 		userId, score, false, false, chatId, messageId, "",
 	)
@@ -1040,7 +1052,7 @@ This method will currently return scores for the target user, plus two of their
 
 "inlineMessageId" : Required if chat_id and message_id are not specified. Identifier of the inline message.
 */
-func (bot *Bot) GetGameHighScores(userId, chatId, messageId int, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error) {
+func (bot *Bot) GetGameHighScores(userId, chatId, messageId int64, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error) {
 	return bot.apiInterface.GetGameHighScores(userId, chatId, messageId, inlineMessageId)
 }
 
@@ -1123,7 +1135,7 @@ func (bot *Bot) GetForumTopicIconStickers() (*objs.Result[[]*objs.Sticker], erro
 }
 
 /*
-CreateForumTopic creates a forum topic. To create forum topic by username, use CreateForumTopicUN.
+CreateForumTopic creates a forum topic.
 
 -------------------------
 
@@ -1141,61 +1153,24 @@ Arguments (as described in telegram bot api):
 
 4. iconCustomEmojiId  : Unique identifier of the custom emoji shown as the topic icon. Use getForumTopicIconStickers to get all allowed custom emoji identifiers.
 */
-func (bot *Bot) CreateForumTopic(chatId int, name string, iconColor int, iconCustomEmojiId string) (*objs.Result[*objs.ForumTopic], error) {
-	return bot.apiInterface.CreateForumTopic(chatId, "", name, iconCustomEmojiId, iconColor)
-}
-
-/*
-CreateForumTopicUN creates a forum topic by username. username should start with @.
-
--------------------------
-
-Official telegram doc :
-
-Use this method to create a topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns information about the created topic as a ForumTopic object.
-
-Arguments (as described in telegram bot api):
-
-1. username : username of the target supergroup (in the format @supergroupusername)
-
-2. name : Topic name, 1-128 characters
-
-3. iconColor : 	Color of the topic icon in RGB format. Currently, must be one of 7322096 (0x6FB9F0), 16766590 (0xFFD67E), 13338331 (0xCB86DB), 9367192 (0x8EEE98), 16749490 (0xFF93B2), or 16478047 (0xFB6F5F)
-
-4. iconCustomEmojiId  : Unique identifier of the custom emoji shown as the topic icon. Use getForumTopicIconStickers to get all allowed custom emoji identifiers.
-*/
-func (bot *Bot) CreateForumTopicUN(username, name string, iconColor int, iconCustomEmojiId string) (*objs.Result[*objs.ForumTopic], error) {
-	return bot.apiInterface.CreateForumTopic(0, username, name, iconCustomEmojiId, iconColor)
+func (bot *Bot) CreateForumTopic(chatId objs.ChatID, name string, iconColor int, iconCustomEmojiId string) (*objs.Result[*objs.ForumTopic], error) {
+	return bot.apiInterface.CreateForumTopic(chatId, name, iconCustomEmojiId, iconColor)
 }
 
 /*
 GetForumTopicManager returns a forum topic manager which can be used for managing forum topics.
 */
-func (bot *Bot) GetForumTopicManager(chatId, messageThreadId int) *ForumTopicManager {
+func (bot *Bot) GetForumTopicManager(chatId objs.ChatID, messageThreadId int64) *ForumTopicManager {
 	return &ForumTopicManager{bot: bot, messageThreadId: messageThreadId, chatId: chatId}
 }
 
-/*
-GetForumTopicManager returns a forum topic manager which can be used for managing forum topics.
-*/
-func (bot *Bot) GetForumTopicManagerUN(username string, messageThreadId int) *ForumTopicManager {
-	return &ForumTopicManager{bot: bot, messageThreadId: messageThreadId, chatIdString: username}
-}
-
 /*
 GetGeneralForumTopicManager returns a general forum topic manager which can be used for managing general forum topics.
 */
-func (bot *Bot) GetGeneralForumTopicManager(chatId, messageThreadId int) *GeneralForumTopicManager {
+func (bot *Bot) GetGeneralForumTopicManager(chatId objs.ChatID, messageThreadId int64) *GeneralForumTopicManager {
 	return &GeneralForumTopicManager{bot: bot, chatId: chatId}
 }
 
-/*
-GetGeneralForumTopicManagerUN returns a general forum topic manager which can be used for managing general forum topics.
-*/
-func (bot *Bot) GetGeneralForumTopicManagerUN(username string, messageThreadId int) *GeneralForumTopicManager {
-	return &GeneralForumTopicManager{bot: bot, chatIdString: username}
-}
-
 /*
 CreateKeyboard creates a keyboard an returns it. The created keyboard has some methods for adding buttons to it.
 
@@ -1243,14 +1218,19 @@ func (bot *Bot) GetTextFormatter() *TextFormatter {
 }
 
 /*VerifyJoin verifies if the user has joined the given channel or supergroup. Returns true if the user is present in the given chat, returns false if not or an error has occured.*/
-func (bot *Bot) VerifyJoin(userID int, UserName string) bool {
-	_, err := bot.apiInterface.GetChatMember(0, UserName, userID)
-	return err == nil
+func (bot *Bot) VerifyJoin(userID int64, chatId objs.ChatID) bool {
+	res, err := bot.apiInterface.GetChatMember(chatId, userID)
+	return err == nil && res.Result.InChat()
 }
 
 /*Stop stops the bot*/
 func (bot *Bot) Stop() {
+	err := bot.stopWebhook()
+	if err != nil {
+		bot.logger.Error("Error stopping the webhook server", logger.Err(err))
+	}
 	bot.apiInterface.StopUpdateRoutine()
+	bot.apiInterface.GetUpdateParser().FlushAlbums()
 	*bot.prcRoutineChannel <- true
 }
 
@@ -1259,13 +1239,33 @@ func (bot *Bot) AdvancedMode() *AdvancedBot {
 	return bot.ab
 }
 
+/*
+GetAPIInterface returns the interface which sends the raw requests to the bot API.
+It has the generated methods of the bot API (like CopyMessages or SetMessageReaction) which take their arguments as the argument types of the objects package.
+*/
+func (bot *Bot) GetAPIInterface() *tba.BotAPIInterface {
+	return bot.apiInterface
+}
+
+/*
+Call calls the given method of the bot API with the given arguments and returns the result of the method decoded into T.
+It can be used for the methods of the bot API which don't have a dedicated method in the bot yet. Use objs.Args to pass the arguments as a map :
+
+	ok, err := telego.Call[bool](bot, "setMyName", objs.Args{"name": "my bot"})
+
+Files in objs.Args (like *os.File) are uploaded. The errors are the same as the other methods.
+*/
+func Call[T any](bot *Bot, method string, args objs.MethodArguments) (T, error) {
+	return tba.Call[T](bot.apiInterface, method, args)
+}
+
 func (bot *Bot) processUpdate(update *objs.Update, mapKey string) bool {
 	out := true
 	upType := update.GetType()
 	if upType == "poll" {
 		bot.processPoll(update)
 	} else {
-		ch := bot.channelsMap[mapKey][upType]
+		ch := bot.getChannel(mapKey, upType)
 		if ch != nil {
 			*ch <- update
 		} else {
@@ -1293,12 +1293,12 @@ func (bot *Bot) processPoll(update *objs.Update) {
 	id := update.Poll.Id
 	pl := Polls[id]
 	if pl == nil {
-		bot.logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`. Not found in the Polls map", "917", logger.BOLD+logger.FAIL, logger.WARNING, "")
+		bot.logger.Warn("Could not update poll. Not found in the Polls map", logger.String("poll_id", id), logger.UpdateId(update.Update_id))
 		*bot.channelsMap["global"]["all"] <- update
 	} else {
 		err3 := pl.Update(update.Poll)
 		if err3 != nil {
-			bot.logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`."+err3.Error(), "922", logger.BOLD+logger.FAIL, logger.WARNING, "")
+			bot.logger.Error("Could not update poll", logger.String("poll_id", id), logger.UpdateId(update.Update_id), logger.Err(err3))
 		}
 	}
 }
@@ -1310,8 +1310,10 @@ loop:
 		case <-*bot.prcRoutineChannel:
 			break loop
 		case up := <-*bot.chatUpdateChannel:
-			if !bot.processUpdate(up.Update, up.ChatId) {
-				chatChannel := bot.channelsMap[up.ChatId]["all"]
+			chatId := bot.checkChatMigration(up)
+			bot.subscribers.checkUpdate(up.Update)
+			if !bot.processUpdate(up.Update, chatId) {
+				chatChannel := bot.getChannel(chatId, "all")
 				if chatChannel != nil {
 					*chatChannel <- up.Update
 				} else {
@@ -1322,19 +1324,119 @@ loop:
 	}
 }
 
+/*Returns the registered channel for the given chat and update type. Returns nil if there is no channel.*/
+func (bot *Bot) getChannel(chatId, upType string) *chan *objs.Update {
+	bot.channelsMu.RLock()
+	defer bot.channelsMu.RUnlock()
+	return bot.channelsMap[chatId][upType]
+}
+
+/*
+Records the migration if the update is a service message about migrating a group to a supergroup.
+Returns the key of the chat channels which the update should be sent to. Updates of a migrated group are sent to the channels of the supergroup.
+*/
+func (bot *Bot) checkChatMigration(up *objs.ChatUpdate) string {
+	if msg := up.Update.Message; msg != nil && msg.Chat != nil {
+		if msg.MigrateToChatId != 0 {
+			bot.apiInterface.MigrateChat(msg.Chat.Id, msg.MigrateToChatId)
+		}
+		if msg.MigrateFromChatId != 0 {
+			bot.apiInterface.MigrateChat(msg.MigrateFromChatId, msg.Chat.Id)
+		}
+	}
+	chatId, err := strconv.ParseInt(up.ChatId, 10, 64)
+	if err != nil {
+		return up.ChatId
+	}
+	return strconv.FormatInt(bot.apiInterface.MigratedChatId(chatId), 10)
+}
+
+/*Moves the channels registered for the old chat to the new chat and calls the migration handlers.*/
+func (bot *Bot) onChatMigrated(oldChatId, newChatId int64) {
+	oldKey, newKey := strconv.FormatInt(oldChatId, 10), strconv.FormatInt(newChatId, 10)
+	bot.channelsMu.Lock()
+	if chs, ok := bot.channelsMap[oldKey]; ok {
+		if bot.channelsMap[newKey] == nil {
+			bot.channelsMap[newKey] = chs
+		} else {
+			for upType, ch := range chs {
+				if bot.channelsMap[newKey][upType] == nil {
+					bot.channelsMap[newKey][upType] = ch
+				}
+			}
+		}
+		delete(bot.channelsMap, oldKey)
+	}
+	handlers := append([]func(int64, int64){}, *bot.migrationHandlers...)
+	bot.channelsMu.Unlock()
+	bot.logger.Info("Group has been migrated to a supergroup", logger.ChatId(oldChatId), logger.Any("new_chat_id", newChatId))
+	for _, handler := range handlers {
+		go handler(oldChatId, newChatId)
+	}
+}
+
+/*
+AddChatMigrationHandler adds a function which is called when a group is migrated to a supergroup.
+
+When a group is migrated, the bot sends all the later requests for the old chat id to the new chat id (including the failed request that revealed the migration) and moves the channels registered for the group to the supergroup. Use this handler to update the chat ids you have stored.
+*/
+func (bot *Bot) AddChatMigrationHandler(handler func(oldChatId, newChatId int64)) {
+	bot.channelsMu.Lock()
+	*bot.migrationHandlers = append(*bot.migrationHandlers, handler)
+	bot.channelsMu.Unlock()
+}
+
 /*NewBot returns a new bot instance with the specified configs*/
 func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 	if cfg == nil {
 		return nil, errors.New("cfg is nil")
 	}
+	//The configs are checked before the logger is created, since the check sets the name of the bot used in the logs.
 	if !cfg.Check() {
 		return nil, errors.New("config check failed. Please check the configs")
 	}
-	botLogger := logger.InitTheLogger(cfg)
+	botLogger, err := logger.InitTheLogger(cfg)
+	if err != nil {
+		return nil, errors.New("could not init the logger. Reason : " + err.Error())
+	}
+	bot, err := createBot(cfg, botLogger)
+	if err != nil {
+		//The log file opened by the logger is not used by any bot.
+		if closer, ok := botLogger.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return bot, nil
+}
+
+/*
+NewBotWithLogger returns a new bot instance with the specified configs which writes its logs into the given logger.
+Use this function to plug in your own logger (for example a log/slog logger using logger.NewSlogLogger) so the bot logs join the logs of your service. "LogFileAddress", "LogLevel" and "LogFormat" fields of the configs are ignored.
+*/
+func NewBotWithLogger(cfg *cfg.BotConfigs, botLogger logger.Logger) (*Bot, error) {
+	if cfg == nil {
+		return nil, errors.New("cfg is nil")
+	}
+	if botLogger == nil {
+		return nil, errors.New("botLogger is nil")
+	}
+	if !cfg.Check() {
+		return nil, errors.New("config check failed. Please check the configs")
+	}
+	return createBot(cfg, botLogger)
+}
+
+/*Creates the API interface and the bot. The configs must have been checked.*/
+func createBot(cfg *cfg.BotConfigs, botLogger logger.Logger) (*Bot, error) {
 	api, err := tba.CreateInterface(cfg, botLogger)
 	if err != nil {
 		return nil, err
 	}
+	return newBot(cfg, api, botLogger), nil
+}
+
+func newBot(cfg *cfg.BotConfigs, api *tba.BotAPIInterface, botLogger logger.Logger) *Bot {
 	ch := make(chan bool)
 	uc := make(chan *objs.Update)
 	bt := &Bot{botCfg: cfg,
@@ -1343,10 +1445,16 @@ func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 		chatUpdateChannel:      api.GetChatUpdateChannel(),
 		prcRoutineChannel:      &ch,
 		channelsMap:            make(map[string]map[string]*chan *objs.Update),
+		channelsMu:             &sync.RWMutex{},
 		logger:                 botLogger,
+		migrationHandlers:      &[]func(oldChatId, newChatId int64){},
+		subscribers:            &subscriberTracker{store: NewMemorySubscriberStore(), logger: botLogger},
 	}
 	bt.channelsMap["global"] = make(map[string]*chan *objs.Update)
 	bt.channelsMap["global"]["all"] = &uc
 	bt.ab = &AdvancedBot{bot: bt}
-	return bt, nil
+	bt.whReconciler = &webhookReconciler{bot: bt}
+	api.SetChatMigrationHandler(bt.onChatMigrated)
+	api.SetBotBlockedHandler(bt.subscribers.onBotBlocked)
+	return bt
 }
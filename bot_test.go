@@ -0,0 +1,116 @@
+package telego
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+	"github.com/hamidteimouri/telego/logger"
+	"github.com/hamidteimouri/telego/tba"
+)
+
+/*Only one API interface can be created in a process, so all the test bots share it and its configs.*/
+var (
+	testAPIOnce sync.Once
+	testAPI     *tba.BotAPIInterface
+	testCfg     = &cfg.BotConfigs{}
+)
+
+/*
+Creates a bot whose API server is a test server. handler is called for each request with the name of the method and returns the response body.
+The tests that use it must not run in parallel.
+*/
+func newTestBot(t *testing.T, handler func(method string, r *http.Request) string) *Bot {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(handler(path.Base(r.URL.Path), r)))
+	}))
+	t.Cleanup(srv.Close)
+	testAPIOnce.Do(func() {
+		var err error
+		testAPI, err = tba.CreateInterface(testCfg, logger.Nop())
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	*testCfg = cfg.BotConfigs{
+		BotAPI:        srv.URL + "/bot",
+		APIKey:        "token",
+		UpdateConfigs: cfg.DefaultUpdateConfigs(),
+		ConfigFile:    filepath.Join(t.TempDir(), "configs.json"),
+	}
+	return newBot(testCfg, testAPI, logger.Nop())
+}
+
+/*Returns the json response of a successful request.*/
+func okResult(result any) string {
+	bt, _ := json.Marshal(map[string]any{"ok": true, "result": result})
+	return string(bt)
+}
+
+/*Returns the json response of a failed request.*/
+func errResult(code int, description string, parameters map[string]any) string {
+	res := map[string]any{"ok": false, "error_code": code, "description": description}
+	if parameters != nil {
+		res["parameters"] = parameters
+	}
+	bt, _ := json.Marshal(res)
+	return string(bt)
+}
+
+/*Returns the parameters of a json or multipart request. Strings are unquoted and the other values are kept as json.*/
+func requestParams(r *http.Request) map[string]string {
+	params := make(map[string]string)
+	if r.Header.Get("Content-Type") == "application/json" {
+		var body map[string]json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for key, value := range body {
+			var s string
+			if json.Unmarshal(value, &s) == nil {
+				params[key] = s
+			} else {
+				params[key] = string(value)
+			}
+		}
+		return params
+	}
+	if r.ParseMultipartForm(1<<20) == nil {
+		for key, values := range r.MultipartForm.Value {
+			params[key] = values[0]
+		}
+	}
+	return params
+}
+
+func TestNewBotClosesTheLogFile(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the open files are read from /proc")
+	}
+	//Only one API interface can be created, so creating another bot fails after the logger is created.
+	newTestBot(t, func(string, *http.Request) string { return okResult(true) })
+	bc := cfg.Default("token")
+	bc.LogFileAddress = filepath.Join(t.TempDir(), "bot.log")
+	if _, err := NewBot(bc); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := os.Stat(bc.LogFileAddress); err != nil {
+		t.Fatalf("the log file should be created : %v", err)
+	}
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, fd := range fds {
+		if target, _ := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); target == bc.LogFileAddress {
+			t.Fatal("the log file should be closed when the bot can't be created")
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package telego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+// The media types which can be broadcasted.
+const (
+	BroadcastPhoto     = "photo"
+	BroadcastVideo     = "video"
+	BroadcastAnimation = "animation"
+	BroadcastAudio     = "audio"
+	BroadcastDocument  = "document"
+)
+
+// BroadcastMessage is the message which is sent to all the chats of a broadcast.
+type BroadcastMessage struct {
+	/*The text of the message. If MediaType is set, it is used as the caption of the media.*/
+	Text string
+	/*Parse mode of the text.*/
+	ParseMode string
+	/*Special entities of the text. Can be used instead of ParseMode.*/
+	Entities []objs.MessageEntity
+	/*Type of the media (BroadcastPhoto, BroadcastVideo, BroadcastAnimation, BroadcastAudio or BroadcastDocument). Leave it empty to send a text message.*/
+	MediaType string
+	/*File id or URL of the media. Upload the file once (for example to yourself) and use its file id here, so it's not uploaded for each chat.*/
+	FileIdOrUrl string
+	/*The keyboard attached to the message. Optional.*/
+	Keyboard MarkUps
+	/*Disables link previews of the text message.*/
+	DisableWebPagePreview bool
+	/*Sends the message silently.*/
+	Silent bool
+	/*Protects the message from forwarding and saving.*/
+	ProtectContent bool
+}
+
+// ChatIdIterator returns the chat ids of a broadcast one by one. ok is false when there is no chat id left. It must return the chat ids in the same order each time it is created so a broadcast can be resumed.
+type ChatIdIterator func() (chatId int64, ok bool)
+
+// ChatIdsFromSlice returns an iterator over the given chat ids.
+func ChatIdsFromSlice(chatIds []int64) ChatIdIterator {
+	i := 0
+	return func() (int64, bool) {
+		if i >= len(chatIds) {
+			return 0, false
+		}
+		i++
+		return chatIds[i-1], true
+	}
+}
+
+// BroadcastConfigs contains the configs of a broadcast.
+type BroadcastConfigs struct {
+	/*Maximum number of messages sent per second. Defaults to 25. Telegram allows about 30 messages per second for a bot.*/
+	Rate int
+	/*Number of messages sent at the same time. Defaults to 4.*/
+	Workers int
+	/*Number of times a message is sent again when the flood control is exceeded. Defaults to 3.*/
+	MaxRetries int
+	/*The file which the progress of the broadcast is saved in. If the file exists when the broadcast starts, the broadcast is resumed from the saved progress. Optional.*/
+	CheckpointFile string
+	/*Interval of saving the progress in CheckpointFile. Defaults to one second.*/
+	CheckpointInterval time.Duration
+	/*If not nil, it is called with the current progress after each message.*/
+	OnProgress func(BroadcastProgress)
+}
+
+func (bc *BroadcastConfigs) fixDefaults() {
+	if bc.Rate <= 0 {
+		bc.Rate = 25
+	}
+	if bc.Workers <= 0 {
+		bc.Workers = 4
+	}
+	if bc.MaxRetries <= 0 {
+		bc.MaxRetries = 3
+	}
+	if bc.CheckpointInterval <= 0 {
+		bc.CheckpointInterval = time.Second
+	}
+}
+
+// BroadcastProgress is the progress of a broadcast. It is also the content of the checkpoint file.
+type BroadcastProgress struct {
+	/*Number of chat ids processed from the start of the iterator. All the chat ids before this position have been processed.*/
+	Position int `json:"position"`
+	/*Number of successfully sent messages.*/
+	Sent int `json:"sent"`
+	/*Number of chats skipped or failed because the user has blocked the bot.*/
+	Blocked int `json:"blocked"`
+	/*Number of messages which could not be sent for other reasons.*/
+	Failed int `json:"failed"`
+	/*True if all the chat ids have been processed.*/
+	Done bool `json:"done"`
+}
+
+/*
+Broadcast sends a message to a list of chats while respecting the rate limits.
+Chats that have blocked the bot are skipped, flood control errors are retried after the requested time and the progress is saved in a checkpoint file so the broadcast can be resumed after a crash.
+Since the messages are sent concurrently, up to "Workers" messages after the saved position may be sent again when a broadcast is resumed.
+*/
+type Broadcast struct {
+	bot     *Bot
+	msg     *BroadcastMessage
+	chatIds ChatIdIterator
+	cfg     BroadcastConfigs
+
+	mu          sync.Mutex
+	progress    BroadcastProgress
+	done        map[int]bool
+	pausedUntil time.Time
+}
+
+type broadcastJob struct {
+	chatId int64
+	index  int
+}
+
+/*NewBroadcast creates a broadcast which sends msg to the chats returned by chatIds. If cfg is nil default configs are used. Call Run to start the broadcast.*/
+func (bot *Bot) NewBroadcast(msg *BroadcastMessage, chatIds ChatIdIterator, cfg *BroadcastConfigs) *Broadcast {
+	b := &Broadcast{bot: bot, msg: msg, chatIds: chatIds, done: make(map[int]bool)}
+	if cfg != nil {
+		b.cfg = *cfg
+	}
+	b.cfg.fixDefaults()
+	return b
+}
+
+/*
+Run runs the broadcast and blocks until all the chats are processed or ctx is canceled. The progress is returned in both cases.
+If the checkpoint file exists, the chat ids before the saved position are skipped.
+*/
+func (b *Broadcast) Run(ctx context.Context) (BroadcastProgress, error) {
+	if b.msg == nil || b.chatIds == nil {
+		return b.progress, errors.New("message and chat ids are required")
+	}
+	if err := b.loadCheckpoint(); err != nil {
+		return b.progress, err
+	}
+	if b.progress.Done {
+		return b.progress, nil
+	}
+	for i := 0; i < b.progress.Position; i++ {
+		if _, ok := b.chatIds(); !ok {
+			break
+		}
+	}
+	jobs := make(chan broadcastJob)
+	var wg sync.WaitGroup
+	for i := 0; i < b.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				b.send(ctx, job)
+			}
+		}()
+	}
+	stopCheckpoint := make(chan bool)
+	checkpointDone := make(chan bool)
+	go b.checkpointRoutine(stopCheckpoint, checkpointDone)
+
+	ticker := time.NewTicker(time.Second / time.Duration(b.cfg.Rate))
+	defer ticker.Stop()
+	index := b.progress.Position
+	var err error
+loop:
+	for {
+		chatId, ok := b.chatIds()
+		if !ok {
+			break
+		}
+		if err = b.waitTurn(ctx, ticker); err != nil {
+			break loop
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		case jobs <- broadcastJob{index: index, chatId: chatId}:
+		}
+		index++
+	}
+	close(jobs)
+	wg.Wait()
+	close(stopCheckpoint)
+	<-checkpointDone
+	b.mu.Lock()
+	if err == nil {
+		b.progress.Done = true
+	}
+	progress := b.progress
+	b.mu.Unlock()
+	if err2 := b.saveCheckpoint(); err == nil {
+		err = err2
+	}
+	return progress, err
+}
+
+/*Waits for the rate limiter and for the end of a flood control pause.*/
+func (b *Broadcast) waitTurn(ctx context.Context, ticker *time.Ticker) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ticker.C:
+	}
+	b.mu.Lock()
+	wait := time.Until(b.pausedUntil)
+	b.mu.Unlock()
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return nil
+}
+
+func (b *Broadcast) send(ctx context.Context, job broadcastJob) {
+	if b.bot.IsBlocked(job.chatId) {
+		b.finish(job.index, func(p *BroadcastProgress) { p.Blocked++ })
+		return
+	}
+	var err error
+	for try := 0; try <= b.cfg.MaxRetries; try++ {
+		err = b.sendMessage(job.chatId)
+		var apiErr *errs.APIError
+		if err == nil || !errors.Is(err, errs.ErrTooManyRequests) || !errors.As(err, &apiErr) {
+			break
+		}
+		//Pause the whole broadcast, since the flood control applies to the bot and not to a single chat.
+		b.mu.Lock()
+		until := time.Now().Add(apiErr.RetryAfter)
+		if until.After(b.pausedUntil) {
+			b.pausedUntil = until
+		}
+		b.mu.Unlock()
+		if try == b.cfg.MaxRetries {
+			//The chat is not retried, so only the other workers wait.
+			break
+		}
+		select {
+		case <-ctx.Done():
+			//Not marked as finished, so the chat is not skipped when the broadcast is resumed.
+			return
+		case <-time.After(apiErr.RetryAfter):
+		}
+	}
+	switch {
+	case err == nil:
+		b.finish(job.index, func(p *BroadcastProgress) { p.Sent++ })
+	case errors.Is(err, errs.ErrBotBlocked):
+		b.finish(job.index, func(p *BroadcastProgress) { p.Blocked++ })
+	default:
+		b.bot.logger.Warn("Broadcast : Unable to send the message", logger.ChatId(job.chatId), logger.Err(err))
+		b.finish(job.index, func(p *BroadcastProgress) { p.Failed++ })
+	}
+}
+
+func (b *Broadcast) sendMessage(id int64) error {
+	m := b.msg
+	chatId := objs.IntChatID(id)
+	ab := b.bot.AdvancedMode()
+	var ms *MediaSender
+	switch m.MediaType {
+	case "":
+		_, err := ab.ASendMessage(chatId, m.Text, m.ParseMode, 0, 0, m.Silent, m.ProtectContent, m.Entities, m.DisableWebPagePreview, false, m.Keyboard)
+		return err
+	case BroadcastPhoto:
+		ms = ab.ASendPhoto(chatId, 0, 0, m.Text, m.ParseMode, m.Entities, false, false, m.Keyboard)
+	case BroadcastVideo:
+		ms = ab.ASendVideo(chatId, 0, 0, m.Text, m.ParseMode, m.Entities, 0, false, false, false, m.Keyboard)
+	case BroadcastAnimation:
+		ms = ab.ASendAnimation(chatId, 0, 0, m.Text, m.ParseMode, m.Entities, 0, 0, 0, false, false, m.Keyboard)
+	case BroadcastAudio:
+		ms = ab.ASendAudio(chatId, 0, 0, m.Text, m.ParseMode, m.Entities, 0, "", "", false, m.Keyboard)
+	case BroadcastDocument:
+		ms = ab.ASendDocument(chatId, 0, 0, m.Text, m.ParseMode, m.Entities, false, false, m.Keyboard)
+	default:
+		return errors.New("unknown media type : " + m.MediaType)
+	}
+	_, err := ms.SendByFileIdOrUrl(m.FileIdOrUrl, m.Silent, m.ProtectContent)
+	return err
+}
+
+/*Marks the chat at the given index as processed and advances the position over the processed chats.*/
+func (b *Broadcast) finish(index int, update func(*BroadcastProgress)) {
+	b.mu.Lock()
+	update(&b.progress)
+	b.done[index] = true
+	for b.done[b.progress.Position] {
+		delete(b.done, b.progress.Position)
+		b.progress.Position++
+	}
+	progress := b.progress
+	onProgress := b.cfg.OnProgress
+	b.mu.Unlock()
+	if onProgress != nil {
+		onProgress(progress)
+	}
+}
+
+/*Progress returns the current progress of the broadcast.*/
+func (b *Broadcast) Progress() BroadcastProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.progress
+}
+
+func (b *Broadcast) checkpointRoutine(stop, done chan bool) {
+	defer close(done)
+	if b.cfg.CheckpointFile == "" {
+		return
+	}
+	ticker := time.NewTicker(b.cfg.CheckpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := b.saveCheckpoint(); err != nil {
+				b.bot.logger.Error("Broadcast : Unable to save the checkpoint", logger.String("file", b.cfg.CheckpointFile), logger.Err(err))
+			}
+		}
+	}
+}
+
+func (b *Broadcast) loadCheckpoint() error {
+	if b.cfg.CheckpointFile == "" {
+		return nil
+	}
+	bts, err := os.ReadFile(b.cfg.CheckpointFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return json.Unmarshal(bts, &b.progress)
+}
+
+/*Writes the progress into a temporary file and renames it to the checkpoint file, so a crash while writing does not corrupt the checkpoint.*/
+func (b *Broadcast) saveCheckpoint() error {
+	if b.cfg.CheckpointFile == "" {
+		return nil
+	}
+	b.mu.Lock()
+	bts, err := json.Marshal(b.progress)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp := b.cfg.CheckpointFile + ".tmp"
+	if err := os.WriteFile(tmp, bts, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.cfg.CheckpointFile)
+}
@@ -0,0 +1,156 @@
+package telego
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*A test API server for the broadcasts. The response of each chat can be set and the requests are recorded with their time.*/
+type broadcastServer struct {
+	mu        sync.Mutex
+	responses map[string][]string
+	chatIds   []string
+	times     []time.Time
+}
+
+func (bs *broadcastServer) handle(method string, r *http.Request) string {
+	chatId := requestParams(r)["chat_id"]
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.chatIds = append(bs.chatIds, chatId)
+	bs.times = append(bs.times, time.Now())
+	if res := bs.responses[chatId]; len(res) != 0 {
+		bs.responses[chatId] = res[1:]
+		return res[0]
+	}
+	id, _ := strconv.ParseInt(chatId, 10, 64)
+	return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": id, "type": "private"}})
+}
+
+func TestBroadcastResume(t *testing.T) {
+	bs := &broadcastServer{}
+	bot := newTestBot(t, bs.handle)
+	checkpoint := filepath.Join(t.TempDir(), "broadcast.json")
+	saved, _ := json.Marshal(BroadcastProgress{Position: 3, Sent: 2, Failed: 1})
+	if err := os.WriteFile(checkpoint, saved, 0600); err != nil {
+		t.Fatal(err)
+	}
+	chatIds := []int64{101, 102, 103, 104, 105, 106}
+	cfg := &BroadcastConfigs{Rate: 1000, Workers: 2, CheckpointFile: checkpoint}
+	progress, err := bot.NewBroadcast(&BroadcastMessage{Text: "news"}, ChatIdsFromSlice(chatIds), cfg).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BroadcastProgress{Position: 6, Sent: 5, Failed: 1, Done: true}
+	if progress != want {
+		t.Errorf("expected %+v, got %+v", want, progress)
+	}
+	sent := map[string]bool{}
+	for _, chatId := range bs.chatIds {
+		sent[chatId] = true
+	}
+	if !reflect.DeepEqual(sent, map[string]bool{"104": true, "105": true, "106": true}) {
+		t.Errorf("only the chats after the saved position should be sent, got %v", bs.chatIds)
+	}
+	var reloaded BroadcastProgress
+	bts, _ := os.ReadFile(checkpoint)
+	if err := json.Unmarshal(bts, &reloaded); err != nil || reloaded != want {
+		t.Errorf("the final progress should be saved, got %s", bts)
+	}
+	//A finished broadcast is not sent again.
+	bs.chatIds = nil
+	progress, err = bot.NewBroadcast(&BroadcastMessage{Text: "news"}, ChatIdsFromSlice(chatIds), cfg).Run(context.Background())
+	if err != nil || progress != want || len(bs.chatIds) != 0 {
+		t.Errorf("unexpected progress %+v, error %v and requests %v", progress, err, bs.chatIds)
+	}
+}
+
+func TestBroadcastFloodWait(t *testing.T) {
+	bs := &broadcastServer{responses: map[string][]string{
+		"202": {errResult(429, "Too Many Requests: retry after 1", map[string]any{"retry_after": 1})},
+		"203": {errResult(403, "Forbidden: bot was blocked by the user", nil)},
+		"204": {errResult(400, "Bad Request: chat not found", nil)},
+	}}
+	bot := newTestBot(t, bs.handle)
+	//A chat which is known to have blocked the bot is skipped.
+	if err := bot.subscribers.getStore().SetStatus(205, SubscriberBlocked, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var progresses []BroadcastProgress
+	cfg := &BroadcastConfigs{Rate: 1000, Workers: 1, OnProgress: func(p BroadcastProgress) { progresses = append(progresses, p) }}
+	chatIds := []int64{201, 202, 203, 204, 205, 206}
+	progress, err := bot.NewBroadcast(&BroadcastMessage{Text: "news"}, ChatIdsFromSlice(chatIds), cfg).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BroadcastProgress{Position: 6, Sent: 3, Blocked: 2, Failed: 1, Done: true}
+	if progress != want {
+		t.Errorf("expected %+v, got %+v", want, progress)
+	}
+	if !reflect.DeepEqual(bs.chatIds, []string{"201", "202", "202", "203", "204", "206"}) {
+		t.Fatalf("unexpected requests %v", bs.chatIds)
+	}
+	if wait := bs.times[2].Sub(bs.times[1]); wait < time.Second {
+		t.Errorf("the message should be sent again after retry_after, it was sent after %v", wait)
+	}
+	if len(progresses) != 6 || progresses[len(progresses)-1].Position != 6 {
+		t.Errorf("the progress should be reported after each chat, got %+v", progresses)
+	}
+	if !bot.IsBlocked(203) {
+		t.Error("the chat which has blocked the bot should be marked as blocked")
+	}
+}
+
+func TestBroadcastFloodWaitPausesAllWorkers(t *testing.T) {
+	bs := &broadcastServer{responses: map[string][]string{
+		"301": {errResult(429, "Too Many Requests: retry after 1", map[string]any{"retry_after": 1})},
+	}}
+	bot := newTestBot(t, bs.handle)
+	var chatIds []int64
+	for id := int64(301); id <= 310; id++ {
+		chatIds = append(chatIds, id)
+	}
+	cfg := &BroadcastConfigs{Rate: 100, Workers: 4}
+	progress, err := bot.NewBroadcast(&BroadcastMessage{Text: "news"}, ChatIdsFromSlice(chatIds), cfg).Run(context.Background())
+	if err != nil || progress.Sent != 10 {
+		t.Fatalf("unexpected progress %+v and error %v", progress, err)
+	}
+	pausedAt := bs.times[0]
+	for i, chatId := range bs.chatIds[1:] {
+		at := bs.times[i+1]
+		//The first chat is sent after one tick, the second one may be sent before the pause starts.
+		if at.After(pausedAt.Add(50*time.Millisecond)) && at.Before(pausedAt.Add(900*time.Millisecond)) {
+			t.Errorf("chat %s was sent during the pause, %v after the flood control error", chatId, at.Sub(pausedAt))
+		}
+	}
+}
+
+func TestBroadcastLastFloodWait(t *testing.T) {
+	floodWait := errResult(429, "Too Many Requests: retry after 1", map[string]any{"retry_after": 1})
+	bs := &broadcastServer{responses: map[string][]string{"301": {floodWait, floodWait}}}
+	bot := newTestBot(t, bs.handle)
+	cfg := &BroadcastConfigs{Rate: 1000, Workers: 1, MaxRetries: 1}
+	start := time.Now()
+	progress, err := bot.NewBroadcast(&BroadcastMessage{Text: "news"}, ChatIdsFromSlice([]int64{301}), cfg).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (BroadcastProgress{Position: 1, Failed: 1, Done: true}); progress != want {
+		t.Errorf("expected %+v, got %+v", want, progress)
+	}
+	//Only the wait before the retry is needed, the chat is not retried after the last flood wait.
+	if elapsed := time.Since(start); elapsed >= 1900*time.Millisecond {
+		t.Errorf("the worker should not wait after the last retry, the broadcast took %v", elapsed)
+	}
+	if len(bs.chatIds) != 2 {
+		t.Errorf("expected 2 requests, got %v", bs.chatIds)
+	}
+}
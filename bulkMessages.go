@@ -0,0 +1,264 @@
+package telego
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*The maximum number of messages in one deleteMessages, forwardMessages or copyMessages request.*/
+const bulkLimit = 100
+
+// BulkConfigs contains the configs of the bulk message operations.
+type BulkConfigs struct {
+	/*Maximum number of requests sent per second. Defaults to 20.*/
+	Rate int
+	/*Number of times a request is sent again when the flood control is exceeded. Defaults to 3.*/
+	MaxRetries int
+	/*Sends one request for each message even if the API server supports the bulk methods. It can be used for the local API servers which don't support them.*/
+	Sequential bool
+}
+
+func (bc *BulkConfigs) fixDefaults() {
+	if bc.Rate <= 0 {
+		bc.Rate = 20
+	}
+	if bc.MaxRetries <= 0 {
+		bc.MaxRetries = 3
+	}
+}
+
+// MessageResult is the result of a bulk operation for one message.
+type MessageResult struct {
+	/*Identifier of the message in the source chat.*/
+	MessageId int64
+	/*Identifier of the new message for forwarded and copied messages. It is zero for deleted messages and when Err is not nil.*/
+	NewMessageId int64
+	/*The error of the operation. It is nil if the operation was successful.*/
+	Err error
+}
+
+/*Runs a bulk operation. bulk sends one bulk request (nil if the bulk method can't be used) and single sends the request of one message.*/
+type bulkRunner struct {
+	cfg    BulkConfigs
+	method string
+	bulk   func(messageIds []int64) (newIds []int64, err error)
+	single func(messageId int64) (newId int64, err error)
+}
+
+func newBulkRunner(cfg *BulkConfigs, method string) *bulkRunner {
+	br := &bulkRunner{method: method}
+	if cfg != nil {
+		br.cfg = *cfg
+	}
+	br.cfg.fixDefaults()
+	return br
+}
+
+/*
+Runs the operation for the given messages and returns the results in ascending order of the message ids. Duplicate ids are processed once.
+The messages are sent in bulk requests of up to 100 messages. If the API server doesn't have the bulk method, the messages are processed one by one.
+*/
+func (br *bulkRunner) run(ctx context.Context, messageIds []int64) []MessageResult {
+	ids := sortedUniqueIds(messageIds)
+	results := make([]MessageResult, len(ids))
+	for i, id := range ids {
+		results[i].MessageId = id
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(br.cfg.Rate))
+	defer ticker.Stop()
+	start := 0
+	if br.bulk != nil && !br.cfg.Sequential {
+		start = br.runBulk(ctx, ticker, ids, results)
+	}
+	for i := start; i < len(ids); i++ {
+		if err := waitTick(ctx, ticker); err != nil {
+			failFrom(results, i, err)
+			break
+		}
+		err := br.retry(ctx, func() (err error) {
+			results[i].NewMessageId, err = br.single(ids[i])
+			return err
+		})
+		if err != nil {
+			results[i].NewMessageId, results[i].Err = 0, err
+		}
+	}
+	return results
+}
+
+/*Sends the bulk requests and returns the index of the first message which should be processed one by one.*/
+func (br *bulkRunner) runBulk(ctx context.Context, ticker *time.Ticker, ids []int64, results []MessageResult) int {
+	for start := 0; start < len(ids); start += bulkLimit {
+		end := start + bulkLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := waitTick(ctx, ticker); err != nil {
+			failFrom(results, start, err)
+			return len(ids)
+		}
+		var newIds []int64
+		err := br.retry(ctx, func() (err error) {
+			newIds, err = br.bulk(ids[start:end])
+			return err
+		})
+		switch {
+		case errors.Is(err, errs.ErrNotFound):
+			//The API server doesn't have the bulk method.
+			return start
+		case err != nil:
+			for i := start; i < end; i++ {
+				results[i].Err = err
+			}
+		case newIds == nil:
+		case len(newIds) == end-start:
+			for i := start; i < end; i++ {
+				results[i].NewMessageId = newIds[i-start]
+			}
+		default:
+			//The sent messages are not sent again one by one, otherwise they would be duplicated. Their ids are kept in the error instead.
+			skipped := &errs.MessagesSkippedError{Method: br.method, Requested: end - start, Done: len(newIds), NewMessageIds: newIds}
+			for i := start; i < end; i++ {
+				results[i].Err = skipped
+			}
+		}
+	}
+	return len(ids)
+}
+
+/*Calls the function and calls it again after the requested time while the flood control is exceeded.*/
+func (br *bulkRunner) retry(ctx context.Context, call func() error) error {
+	for try := 0; ; try++ {
+		err := call()
+		var apiErr *errs.APIError
+		if err == nil || try >= br.cfg.MaxRetries || !errors.Is(err, errs.ErrTooManyRequests) || !errors.As(err, &apiErr) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(apiErr.RetryAfter):
+		}
+	}
+}
+
+func waitTick(ctx context.Context, ticker *time.Ticker) error {
+	//select picks randomly if the tick is ready too.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ticker.C:
+		return nil
+	}
+}
+
+/*Sets the error of the results which have not been processed.*/
+func failFrom(results []MessageResult, start int, err error) {
+	for i := start; i < len(results); i++ {
+		results[i].Err = err
+	}
+}
+
+/*The bulk methods require the message ids in strictly increasing order.*/
+func sortedUniqueIds(messageIds []int64) []int64 {
+	ids := append([]int64{}, messageIds...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	out := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+/*
+DeleteMessages deletes the given messages of the chat and returns the result of each message in ascending order of the message ids. All the rules of DeleteMessage method apply to this method too.
+
+The messages are deleted with deleteMessages method in requests of up to 100 messages. Messages which can't be found are skipped by the API server and are reported as successful.
+If the API server doesn't have deleteMessages method (or cfg.Sequential is true), the messages are deleted one by one while respecting cfg.Rate. If cfg is nil default configs are used.
+If ctx is canceled, the remaining messages are not deleted and their error is the error of ctx.
+*/
+func (me *MessageEditor) DeleteMessages(ctx context.Context, messageIds []int64, cfg *BulkConfigs) []MessageResult {
+	br := newBulkRunner(cfg, "deleteMessages")
+	br.bulk = func(ids []int64) ([]int64, error) {
+		_, err := me.bot.apiInterface.DeleteMessages(&objs.DeleteMessagesArgs{ChatId: me.chatId, MessageIds: ids})
+		return nil, err
+	}
+	br.single = func(id int64) (int64, error) {
+		_, err := me.DeleteMessage(id)
+		return 0, err
+	}
+	return br.run(ctx, messageIds)
+}
+
+/*
+ForwardMessages forwards the given messages from the chat with "fromChatId" to the chat with "chatId" and returns the result of each message in ascending order of the message ids. Albums are kept when the bulk method is used.
+
+The messages are forwarded with forwardMessages method in requests of up to 100 messages. If the API server skips some of the messages of a request, it is not known which messages were skipped, so the error of all the messages of the request is an *errs.MessagesSkippedError which contains the ids of the messages that were sent.
+If the API server doesn't have forwardMessages method (or cfg.Sequential is true), the messages are forwarded one by one while respecting cfg.Rate. If cfg is nil default configs are used.
+*/
+func (mf *MessageForwarder) ForwardMessages(ctx context.Context, chatId, fromChatId objs.ChatID, messageIds []int64, cfg *BulkConfigs) []MessageResult {
+	br := newBulkRunner(cfg, "forwardMessages")
+	br.bulk = func(ids []int64) ([]int64, error) {
+		res, err := mf.bot.apiInterface.ForwardMessages(&objs.ForwardMessagesArgs{
+			ChatId: chatId, FromChatId: fromChatId, MessageIds: ids, MessageThreadId: mf.messageThreadId,
+			DisableNotification: mf.disableNotif, ProtectContent: mf.protectContent,
+		})
+		return newMessageIds(res, err)
+	}
+	br.single = func(id int64) (int64, error) {
+		res, err := mf.bot.apiInterface.ForwardMessage(chatId, fromChatId, mf.disableNotif, mf.protectContent, id, mf.messageThreadId)
+		if err != nil {
+			return 0, err
+		}
+		return res.Result.MessageId, nil
+	}
+	return br.run(ctx, messageIds)
+}
+
+/*
+CopyMessages copies the given messages from the chat with "fromChatId" to the chat with "chatId" and returns the result of each message in ascending order of the message ids.
+
+The messages are copied with copyMessages method in requests of up to 100 messages. If the API server skips some of the messages of a request, it is not known which messages were skipped, so the error of all the messages of the request is an *errs.MessagesSkippedError which contains the ids of the messages that were sent.
+copyMessages can't set the caption, the keyboard or the replied message, so if any of them is set in the copier the messages are copied one by one. They are also copied one by one if the API server doesn't have copyMessages method (or cfg.Sequential is true) while respecting cfg.Rate. If cfg is nil default configs are used.
+*/
+func (mf *MessageCopier) CopyMessages(ctx context.Context, chatId, fromChatId objs.ChatID, messageIds []int64, cfg *BulkConfigs) []MessageResult {
+	br := newBulkRunner(cfg, "copyMessages")
+	if mf.caption == "" && mf.parseMode == "" && mf.captionEntities == nil && mf.replyTo == 0 && mf.replyMarkup == nil {
+		br.bulk = func(ids []int64) ([]int64, error) {
+			res, err := mf.bot.apiInterface.CopyMessages(&objs.CopyMessagesArgs{
+				ChatId: chatId, FromChatId: fromChatId, MessageIds: ids,
+				DisableNotification: mf.disableNotif, ProtectContent: mf.protectContent,
+			})
+			return newMessageIds(res, err)
+		}
+	}
+	br.single = func(id int64) (int64, error) {
+		res, err := mf.bot.apiInterface.CopyMessage(chatId, fromChatId, id, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
+		if err != nil {
+			return 0, err
+		}
+		return res.Result.MessageId, nil
+	}
+	return br.run(ctx, messageIds)
+}
+
+func newMessageIds(res *objs.Result[[]objs.MessageId], err error) ([]int64, error) {
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int64, len(res.Result))
+	for i, id := range res.Result {
+		out[i] = id.MessageId
+	}
+	return out, nil
+}
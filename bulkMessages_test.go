@@ -0,0 +1,242 @@
+package telego
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func apiError(method string, code int, retryAfter time.Duration) *errs.APIError {
+	err := errs.NewAPIError(method, &objs.FailureResult{ErrorCode: code, Description: "test error"})
+	err.RetryAfter = retryAfter
+	return err
+}
+
+func idRange(from, to int64) []int64 {
+	var ids []int64
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+/*A fake bulk method. fail returns the error of each call (nil for success) and skip is the number of messages skipped from each request.*/
+type fakeBulk struct {
+	calls   [][]int64
+	singles []int64
+	fail    func(call int) error
+	skip    int
+	cancel  func(single int)
+}
+
+func (fb *fakeBulk) bulk(ids []int64) ([]int64, error) {
+	fb.calls = append(fb.calls, append([]int64{}, ids...))
+	if fb.fail != nil {
+		if err := fb.fail(len(fb.calls)); err != nil {
+			return nil, err
+		}
+	}
+	newIds := make([]int64, 0, len(ids))
+	for _, id := range ids[fb.skip:] {
+		newIds = append(newIds, id+1000)
+	}
+	return newIds, nil
+}
+
+func (fb *fakeBulk) single(id int64) (int64, error) {
+	fb.singles = append(fb.singles, id)
+	if fb.cancel != nil {
+		fb.cancel(len(fb.singles))
+	}
+	return id + 1000, nil
+}
+
+func TestBulkRunner(t *testing.T) {
+	unsorted := append(idRange(101, 250), idRange(1, 100)...)
+	unsorted = append(unsorted, 5, 5, 250)
+	tests := []struct {
+		name       string
+		cfg        BulkConfigs
+		ids        []int64
+		fake       fakeBulk
+		noBulk     bool
+		cancel     int
+		wantChunks []int
+		wantSingle int
+		check      func(t *testing.T, results []MessageResult)
+	}{
+		{
+			name:       "chunks",
+			ids:        unsorted,
+			wantChunks: []int{100, 100, 50},
+		},
+		{
+			name:       "sequential",
+			cfg:        BulkConfigs{Sequential: true},
+			ids:        idRange(1, 5),
+			wantSingle: 5,
+		},
+		{
+			name:       "no bulk method",
+			ids:        idRange(1, 5),
+			noBulk:     true,
+			wantSingle: 5,
+		},
+		{
+			name:       "bulk method not found",
+			ids:        idRange(1, 5),
+			fake:       fakeBulk{fail: func(int) error { return apiError("forwardMessages", 404, 0) }},
+			wantChunks: []int{5},
+			wantSingle: 5,
+		},
+		{
+			name: "bulk method not found after the first chunk",
+			ids:  idRange(1, 150),
+			fake: fakeBulk{fail: func(call int) error {
+				if call == 2 {
+					return apiError("forwardMessages", 404, 0)
+				}
+				return nil
+			}},
+			wantChunks: []int{100, 50},
+			wantSingle: 50,
+		},
+		{
+			name: "flood wait",
+			ids:  idRange(1, 5),
+			fake: fakeBulk{fail: func(call int) error {
+				if call == 1 {
+					return apiError("forwardMessages", 429, time.Millisecond)
+				}
+				return nil
+			}},
+			wantChunks: []int{5, 5},
+		},
+		{
+			name:       "flood wait retries exceeded",
+			cfg:        BulkConfigs{MaxRetries: 2},
+			ids:        idRange(1, 5),
+			fake:       fakeBulk{fail: func(int) error { return apiError("forwardMessages", 429, time.Millisecond) }},
+			wantChunks: []int{5, 5, 5},
+			check: func(t *testing.T, results []MessageResult) {
+				for _, res := range results {
+					if !errors.Is(res.Err, errs.ErrTooManyRequests) || res.NewMessageId != 0 {
+						t.Errorf("unexpected result %+v", res)
+					}
+				}
+			},
+		},
+		{
+			name:       "skipped messages",
+			ids:        idRange(1, 5),
+			fake:       fakeBulk{skip: 2},
+			wantChunks: []int{5},
+			check: func(t *testing.T, results []MessageResult) {
+				for _, res := range results {
+					var skipped *errs.MessagesSkippedError
+					if !errors.As(res.Err, &skipped) {
+						t.Fatalf("unexpected error %v", res.Err)
+					}
+					if skipped.Requested != 5 || skipped.Done != 3 || !reflect.DeepEqual(skipped.NewMessageIds, []int64{1003, 1004, 1005}) {
+						t.Errorf("unexpected error %+v", skipped)
+					}
+				}
+			},
+		},
+		{
+			name:       "canceled",
+			cfg:        BulkConfigs{Sequential: true},
+			ids:        idRange(1, 5),
+			cancel:     2,
+			wantSingle: 2,
+			check: func(t *testing.T, results []MessageResult) {
+				for i, res := range results {
+					if i < 2 && (res.Err != nil || res.NewMessageId != res.MessageId+1000) {
+						t.Errorf("message %d should be sent, got %+v", res.MessageId, res)
+					}
+					if i >= 2 && (!errors.Is(res.Err, context.Canceled) || res.NewMessageId != 0) {
+						t.Errorf("message %d should not be sent, got %+v", res.MessageId, res)
+					}
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tt.cfg.Rate = 1000
+			fake := &tt.fake
+			if tt.cancel != 0 {
+				fake.cancel = func(n int) {
+					if n == tt.cancel {
+						cancel()
+					}
+				}
+			}
+			br := newBulkRunner(&tt.cfg, "forwardMessages")
+			if !tt.noBulk {
+				br.bulk = fake.bulk
+			}
+			br.single = fake.single
+			results := br.run(ctx, tt.ids)
+			ids := sortedUniqueIds(tt.ids)
+			if len(results) != len(ids) {
+				t.Fatalf("expected %d results, got %d", len(ids), len(results))
+			}
+			for i, res := range results {
+				if res.MessageId != ids[i] {
+					t.Fatalf("result %d is for message %d, expected %d", i, res.MessageId, ids[i])
+				}
+			}
+			var chunks []int
+			for _, call := range fake.calls {
+				chunks = append(chunks, len(call))
+				for i := 1; i < len(call); i++ {
+					if call[i] <= call[i-1] {
+						t.Fatalf("the ids of a bulk request should be strictly increasing, got %v", call)
+					}
+				}
+			}
+			if !reflect.DeepEqual(chunks, tt.wantChunks) {
+				t.Errorf("expected chunks %v, got %v", tt.wantChunks, chunks)
+			}
+			if len(fake.singles) != tt.wantSingle {
+				t.Errorf("expected %d single requests, got %d", tt.wantSingle, len(fake.singles))
+			}
+			if tt.cancel == 0 && tt.wantSingle > 0 && !reflect.DeepEqual(fake.singles, ids[len(ids)-tt.wantSingle:]) {
+				t.Errorf("the last %d messages should be sent one by one, got %v", tt.wantSingle, fake.singles)
+			}
+			if tt.check != nil {
+				tt.check(t, results)
+				return
+			}
+			for _, res := range results {
+				if res.Err != nil || res.NewMessageId != res.MessageId+1000 {
+					t.Errorf("unexpected result %+v", res)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkRunnerCanceledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fake := &fakeBulk{}
+	br := newBulkRunner(nil, "deleteMessages")
+	br.bulk, br.single = fake.bulk, fake.single
+	for _, res := range br.run(ctx, idRange(1, 150)) {
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Errorf("unexpected result %+v", res)
+		}
+	}
+	if len(fake.calls) != 0 || len(fake.singles) != 0 {
+		t.Error("no request should be sent")
+	}
+}
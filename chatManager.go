@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"io"
 	"os"
 
 	objs "github.com/hamidteimouri/telego/objects"
@@ -8,9 +9,8 @@ import (
 
 // ChatManager is a tool for managing chats via the bot.
 type ChatManager struct {
-	bot          *Bot
-	chatIdInt    int
-	chatIdString string
+	bot    *Bot
+	chatId objs.ChatID
 }
 
 func (cm *ChatManager) fixThePerms(canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) objs.ChatPermissions {
@@ -27,16 +27,16 @@ func (cm *ChatManager) fixThePerms(canSendMessages, canSendMediaMessages, canSen
 }
 
 /*BanMember bans a user in a group, a supergroup or a channel. In the case of supergroups and channels, the user will not be able to return to the chat on their own using invite links, etc., unless unbanned first. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
-func (cm *ChatManager) BanMember(userId, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
+func (cm *ChatManager) BanMember(userId int64, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.BanChatMember(
-		cm.chatIdInt, cm.chatIdString, userId, untilDate, revokeMessages,
+		cm.chatId, userId, untilDate, revokeMessages,
 	)
 }
 
 /*UnbanMember ubans a previously banned user in a supergroup or channel. The user will not return to the group or channel automatically, but will be able to join via link, etc. The bot must be an administrator for this to work. By default, this method guarantees that after the call the user is not a member of the chat, but will be able to join it. So if the user is a member of the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned. Returns True on success.*/
-func (cm *ChatManager) UnbanMember(userId int, onlyIfBanned bool) (*objs.Result[bool], error) {
+func (cm *ChatManager) UnbanMember(userId int64, onlyIfBanned bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.UnbanChatMember(
-		cm.chatIdInt, cm.chatIdString, userId, onlyIfBanned,
+		cm.chatId, userId, onlyIfBanned,
 	)
 }
 
@@ -45,41 +45,41 @@ Use this method to restrict a user in a supergroup. The bot must be an administr
 
 useIndependentChatPermissions : Pass True if chat permissions are set independently. Otherwise, the can_send_other_messages and can_add_web_page_previews permissions will imply the can_send_messages, can_send_audios, can_send_documents, can_send_photos, can_send_videos, can_send_video_notes, and can_send_voice_notes permissions; the can_send_polls permission will imply the can_send_messages permission.
 */
-func (cm *ChatManager) RestrictMember(userId int, untilDate int, useIndependentChatPermissions bool, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.Result[bool], error) {
+func (cm *ChatManager) RestrictMember(userId int64, untilDate int, useIndependentChatPermissions, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.RestrictChatMember(
-		cm.chatIdInt, cm.chatIdString, userId, cm.fixThePerms(
+		cm.chatId, userId, cm.fixThePerms(
 			canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages,
 		), useIndependentChatPermissions, untilDate,
 	)
 }
 
 /*PromoteChatMember promotes or demote a user in a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Pass False for all boolean parameters to demote a user. Returns True on success.*/
-func (cm *ChatManager) PromoteChatMember(userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics bool) (*objs.Result[bool], error) {
+func (cm *ChatManager) PromoteChatMember(userId int64, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.PromoteChatMember(
-		cm.chatIdInt, cm.chatIdString, userId, isAnonymous, canManageChat,
+		cm.chatId, userId, isAnonymous, canManageChat,
 		canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats,
 		canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics,
 	)
 }
 
 /*SetCustomTitle sets a custom title for an administrator in a supergroup promoted by the bot. Returns True on success.*/
-func (cm *ChatManager) SetCustomTitle(userId int, customTitle string) (*objs.Result[bool], error) {
+func (cm *ChatManager) SetCustomTitle(userId int64, customTitle string) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatAdministratorCustomTitle(
-		cm.chatIdInt, cm.chatIdString, userId, customTitle,
+		cm.chatId, userId, customTitle,
 	)
 }
 
 /*BanChatSender bans a channel chat in a supergroup or a channel. Until the chat is unbanned, the owner of the banned chat won't be able to send messages on behalf of any of their channels. The bot must be an administrator in the supergroup or channel for this to work and must have the appropriate administrator rights. Returns True on success.*/
-func (cm *ChatManager) BanChatSender(senderChatId int) (*objs.Result[bool], error) {
+func (cm *ChatManager) BanChatSender(senderChatId int64) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.BanOrUnbanChatSenderChat(
-		cm.chatIdInt, cm.chatIdString, senderChatId, true,
+		cm.chatId, senderChatId, true,
 	)
 }
 
 /*UnbanChatSender unbans a previously banned channel chat in a supergroup or channel. The bot must be an administrator for this to work and must have the appropriate administrator rights. Returns True on success.*/
-func (cm *ChatManager) UnbanChatSender(senderChatId int) (*objs.Result[bool], error) {
+func (cm *ChatManager) UnbanChatSender(senderChatId int64) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.BanOrUnbanChatSenderChat(
-		cm.chatIdInt, cm.chatIdString, senderChatId, false,
+		cm.chatId, senderChatId, false,
 	)
 }
 
@@ -90,7 +90,7 @@ useIndependentChatPermissions : Pass True if chat permissions are set independen
 */
 func (cm *ChatManager) SetGeneralPermissions(useIndependentChatPermissions, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatPermissions(
-		cm.chatIdInt, cm.chatIdString, useIndependentChatPermissions, cm.fixThePerms(
+		cm.chatId, useIndependentChatPermissions, cm.fixThePerms(
 			canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages,
 		),
 	)
@@ -103,144 +103,159 @@ Note: Each administrator in a chat generates their own invite links. Bots can't
 */
 func (cm *ChatManager) ExportInviteLink() (*objs.Result[string], error) {
 	return cm.bot.apiInterface.ExportChatInviteLink(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*CreateInviteLink creates an additional invite link for a chat. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. The link can be revoked using the method RevokeInviteLink. Returns the new invite link as ChatInviteLink object.*/
 func (cm *ChatManager) CreateInviteLink(name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
 	return cm.bot.apiInterface.CreateChatInviteLink(
-		cm.chatIdInt, cm.chatIdString, name, expireDate, memberLimit, createsJoinRequest,
+		cm.chatId, name, expireDate, memberLimit, createsJoinRequest,
 	)
 }
 
 /*EditInviteLink edits a non-primary invite link created by the bot. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the edited invite link as a ChatInviteLink object.*/
 func (cm *ChatManager) EditInviteLink(inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
 	return cm.bot.apiInterface.EditChatInviteLink(
-		cm.chatIdInt, cm.chatIdString, inviteLink, name, expireDate, memberLimit, createsJoinRequest,
+		cm.chatId, inviteLink, name, expireDate, memberLimit, createsJoinRequest,
 	)
 }
 
 /*RevokeInviteLink revokes an invite link created by the bot. If the primary link is revoked, a new link is automatically generated. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the revoked invite link as ChatInviteLink object.*/
 func (cm *ChatManager) RevokeInviteLink(inviteLink string) (*objs.Result[*objs.ChatInviteLink], error) {
 	return cm.bot.apiInterface.RevokeChatInviteLink(
-		cm.chatIdInt, cm.chatIdString, inviteLink,
+		cm.chatId, inviteLink,
 	)
 }
 
 /*ApproveJoinRequest approves a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.*/
-func (cm *ChatManager) ApproveJoinRequest(userId int) (*objs.Result[bool], error) {
+func (cm *ChatManager) ApproveJoinRequest(userId int64) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.ApproveChatJoinRequest(
-		cm.chatIdInt, cm.chatIdString, userId,
+		cm.chatId, userId,
 	)
 }
 
 /*DeclineJoinRequest can be used to decline a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.*/
-func (cm *ChatManager) DeclineJoinRequest(userId int) (*objs.Result[bool], error) {
+func (cm *ChatManager) DeclineJoinRequest(userId int64) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.DeclineChatJoinRequest(
-		cm.chatIdInt, cm.chatIdString, userId,
+		cm.chatId, userId,
 	)
 }
 
 /*SetPhoto can be used to set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetPhoto(photoFile *os.File) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatPhoto(
-		cm.chatIdInt, cm.chatIdString, photoFile,
+		cm.chatId, photoFile,
+	)
+}
+
+/*SetPhotoByReader works like SetPhoto but uploads the content of the reader as a photo with the given file name.*/
+func (cm *ChatManager) SetPhotoByReader(name string, reader io.Reader) (*objs.Result[bool], error) {
+	return cm.bot.apiInterface.SetChatPhoto(
+		cm.chatId, objs.NewNamedReader(name, reader),
 	)
 }
 
 /*DeletePhoto can be used to delete a chat photo. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) DeletePhoto() (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.DeleteChatPhoto(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*SetTitle changes the title of a chat. Titles can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetTitle(title string) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatTitle(
-		cm.chatIdInt, cm.chatIdString, title,
+		cm.chatId, title,
 	)
 }
 
 /*SetDescription changes the description of a group, a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetDescription(description string) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatDescription(
-		cm.chatIdInt, cm.chatIdString, description,
+		cm.chatId, description,
 	)
 }
 
 /*PinMessage adds a message to the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
-func (cm *ChatManager) PinMessage(messageId int, disableNotif bool) (*objs.Result[bool], error) {
+func (cm *ChatManager) PinMessage(messageId int64, disableNotif bool) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.PinChatMessage(
-		cm.chatIdInt, cm.chatIdString, messageId, disableNotif,
+		cm.chatId, messageId, disableNotif,
 	)
 }
 
 /*UnpinMessage removes a message from the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
-func (cm *ChatManager) UnpinMessage(messageId int) (*objs.Result[bool], error) {
+func (cm *ChatManager) UnpinMessage(messageId int64) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.UnpinChatMessage(
-		cm.chatIdInt, cm.chatIdString, messageId,
+		cm.chatId, messageId,
 	)
 }
 
 /*UnpinAllMessages clears the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
 func (cm *ChatManager) UnpinAllMessages() (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.UnpinAllChatMessages(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*Leave can be used for your bot to leave a group, supergroup or channel. Returns True on success.*/
 func (cm *ChatManager) Leave() (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.LeaveChat(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*GetChatInfo gets up to date information about the chat (current name of the user for one-on-one conversations, current username of a user, group or channel, etc.). Returns a Chat object on success.*/
 func (cm *ChatManager) GetChatInfo() (*objs.Result[*objs.Chat], error) {
 	return cm.bot.apiInterface.GetChat(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*GetAdmins gets a list of administrators in a chat. On success, returns an Array of ChatMember objects that contains information about all chat administrators except other bots. If the chat is a group or a supergroup and no administrators were appointed, only the creator will be returned.*/
-func (cm *ChatManager) GetAdmins() (*objs.Result[[]objs.ChatMemberOwner], error) {
+func (cm *ChatManager) GetAdmins() (*objs.Result[[]objs.ChatMember], error) {
 	return cm.bot.apiInterface.GetChatAdministrators(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
 
 /*GetMembersCount gets the number of members in a chat. Returns Int on success.*/
 func (cm *ChatManager) GetMembersCount() (*objs.Result[int], error) {
 	return cm.bot.apiInterface.GetChatMemberCount(
-		cm.chatIdInt,
-		cm.chatIdString,
+		cm.chatId,
 	)
 }
 
-/*GetMember gets information about a member of a chat. Returns a json serialized object of the member in string form on success.*/
-func (cm *ChatManager) GetMember(userid int) (string, error) {
+/*GetMember gets information about a member of a chat. Returns a ChatMember object on success. Use a type switch to access the fields of each status.*/
+func (cm *ChatManager) GetMember(userid int64) (objs.ChatMember, error) {
 	res, err := cm.bot.apiInterface.GetChatMember(
-		cm.chatIdInt, cm.chatIdString, userid,
+		cm.chatId, userid,
 	)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+/*IsAdmin returns true if the user is the owner or an administrator of the chat.*/
+func (cm *ChatManager) IsAdmin(userId int64) (bool, error) {
+	member, err := cm.GetMember(userId)
+	if err != nil {
+		return false, err
 	}
-	return string(res.Result), nil
+	return member.IsAdmin(), nil
 }
 
 /*SetStickerSet sets a new group sticker set for a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in "GetChatInfo" to check if the bot can use this method. Returns True on success.*/
 func (cm *ChatManager) SetStickerSet(stickerSetName string) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatStickerSet(
-		cm.chatIdInt, cm.chatIdString, stickerSetName,
+		cm.chatId, stickerSetName,
 	)
 }
 
 /*DeleteStickerSet deletes a group sticker set from a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in "GetChatInfo" to check if the bot can use this method. Returns True on success.*/
 func (cm *ChatManager) DeleteStickerSet() (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.DeleteChatStickerSet(
-		cm.chatIdInt, cm.chatIdString,
+		cm.chatId,
 	)
 }
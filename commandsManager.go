@@ -31,7 +31,7 @@ SetScope sets the scope of this command manager.
 
 Scope can have these values : "defaut","all_group_chats","all_private_chats","all_chat_administrators","chat","chat_administrator","chat_member". If scope is not valid error is returned.
 */
-func (cm *CommandsManager) SetScope(scope string, chatId []byte, userId int) error {
+func (cm *CommandsManager) SetScope(scope string, chatId []byte, userId int64) error {
 	switch scope {
 	case "default":
 		cm.scope = &objs.BotCommandScopeDefault{}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -11,7 +13,7 @@ import (
 
 // BlockedUser is a struct used for storing a blocked user informations.
 type BlockedUser struct {
-	UserID   int    `json:"user_id"`
+	UserID   int64  `json:"user_id"`
 	UserName string `json:"username"`
 }
 
@@ -21,6 +23,24 @@ const DefaultBotAPI = "https://api.telegram.org/bot"
 // DefaultLogFile is a default file for saving the bot logs in it.
 const DefaultLogFile = "STDOUT"
 
+// TextLogFormat writes the logs as text lines.
+const TextLogFormat = "text"
+
+// JSONLogFormat writes the logs as JSON objects, one per line.
+const JSONLogFormat = "json"
+
+// DefaultWebhookCertFile is the default file for saving the generated webhook certificate in it.
+const DefaultWebhookCertFile = "webhook_cert.pem"
+
+// DefaultACMEDirectory is the directory url of Let's Encrypt ACME server.
+const DefaultACMEDirectory = "https://acme-v02.api.letsencrypt.org/directory"
+
+// DefaultACMECacheDir is the default directory for caching the certificates obtained from the ACME server.
+const DefaultACMECacheDir = "acme-cache"
+
+// DefaultWebhookKeyFile is the default file for saving the private key of the generated webhook certificate in it.
+const DefaultWebhookKeyFile = "webhook_key.pem"
+
 // BotConfigs is a struct holding the bots configs.
 type BotConfigs struct {
 	/*Name is the bot's custom name. This is used in logging*/
@@ -38,6 +58,12 @@ type BotConfigs struct {
 	WebHookConfigs *WebHookConfigs `json:"webhook_configs,omitempty"`
 	/*All the logs related to bot will be written in this file. You can use configs.DefaultLogFile for default value*/
 	LogFileAddress string `json:"log_file"`
+	/*The minimum level of the logs that are written. Can be "debug", "info", "warn" or "error". Defaults to "info".*/
+	LogLevel string `json:"log_level,omitempty"`
+	/*The format of the logs. Can be configs.TextLogFormat or configs.JSONLogFormat. Defaults to text.*/
+	LogFormat string `json:"log_format,omitempty"`
+	/*The settings for rotating the log file. Only used when LogFileAddress is a file. If nil, the logs are appended to a single file forever.*/
+	LogRotation *LogRotationConfigs `json:"log_rotation,omitempty"`
 	//BlockedUsers is a list of blocked users.
 	BlockedUsers []BlockedUser `json:"blocked_users"`
 	/*Config name is the address of the config file. This filed has been added on PULL REQUEST #13 by https://github.com/felipeflores
@@ -123,7 +149,7 @@ func LoadInto(bc *BotConfigs) error {
 
 // Dump saves the given BotConfigs struct in a json format in the config file (configs.json).
 func Dump(bc *BotConfigs) error {
-	fl, err := os.OpenFile(bc.ConfigFile, os.O_CREATE|os.O_WRONLY, 0666)
+	fl, err := os.OpenFile(bc.ConfigFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 	defer fl.Close()
 	if err != nil {
 		return err
@@ -159,17 +185,61 @@ type WebHookConfigs struct {
 	DropPendingUpdates bool `json:"drop_pending_reqs"`
 	/*A secret token to be sent in a header “X-Telegram-Bot-Api-Secret-Token” in every webhook request, 1-256 characters. Only characters A-Z, a-z, 0-9, _ and - are allowed. The header is useful to ensure that the request comes from a webhook set by you.*/
 	SecretToken string `json:"secret_token,omitempty"`
+	/*If true, a self signed certificate is generated for the host of the URL (and IP if it is given) on the first start and is saved in CertFile and KeyFile. The certificate is uploaded to the API server and is rotated before it expires. SelfSigned is set to true automatically. If CertFile and KeyFile are empty, configs.DefaultWebhookCertFile and configs.DefaultWebhookKeyFile are used.*/
+	GenerateCertificate bool `json:"generate_certificate,omitempty"`
+	/*Validity period of the generated certificate. Defaults to one year.*/
+	CertificateValidity time.Duration `json:"certificate_validity,omitempty"`
+	/*The generated (or ACME) certificate is renewed when less than this duration is left until it expires. Defaults to 30 days.*/
+	CertificateRenewBefore time.Duration `json:"certificate_renew_before,omitempty"`
+	/*If populated, the certificate of the webhook server is obtained and renewed automatically from an ACME server (like Let's Encrypt) for the host of the URL. CertFile and KeyFile are not needed in this case. Can't be used together with GenerateCertificate or with a URL whose host is an IP address.*/
+	ACME *ACMEConfigs `json:"acme,omitempty"`
+	/*Interval of checking the webhook status on the API server (getWebhookInfo). The webhook is set again if its URL or allowed updates differ from these configs. Pass 0 to disable the check.*/
+	ReconcileInterval time.Duration `json:"reconcile_interval,omitempty"`
+	/*If not empty, the metrics of the bot are served on this path of the webhook server (for example "/metrics") in Prometheus text format.*/
+	MetricsPath string `json:"metrics_path,omitempty"`
+}
+
+// ACMEConfigs contains the configs for obtaining the webhook certificate from an ACME server.
+type ACMEConfigs struct {
+	/*Directory url of the ACME server. Defaults to configs.DefaultACMEDirectory (Let's Encrypt).*/
+	DirectoryURL string `json:"directory_url,omitempty"`
+	/*Contact email of the ACME account. Optional.*/
+	Email string `json:"email,omitempty"`
+	/*The directory which the account key and the obtained certificates are cached in. Defaults to configs.DefaultACMECacheDir.*/
+	CacheDir string `json:"cache_dir,omitempty"`
+	/*Port of the HTTP server that answers the http-01 challenges. ACME servers use port 80 for this challenge. Pass 0 to only use the tls-alpn-01 challenge, which needs the webhook server to be reachable on port 443.*/
+	HTTPChallengePort int `json:"http_challenge_port,omitempty"`
+	/*Address of a PEM file containing the root certificates of the ACME server. Only needed for test servers with a private root certificate such as Pebble.*/
+	RootCAFile string `json:"root_ca_file,omitempty"`
+	/*Pass True to accept the terms of service of the ACME server. Certificates are not obtained until this field is true.*/
+	AcceptTOS bool `json:"accept_tos"`
 }
 
 func (whc *WebHookConfigs) check(apiKey string) bool {
 	if whc.URL == "" {
 		return false
 	}
-	if whc.KeyFile == "" {
-		return false
-	}
-	if whc.CertFile == "" {
-		return false
+	if whc.ACME != nil {
+		if whc.GenerateCertificate {
+			return false
+		}
+		//ACME servers only issue certificates for domain names.
+		u, err := url.Parse(whc.URL)
+		if err != nil || u.Hostname() == "" || net.ParseIP(u.Hostname()) != nil {
+			return false
+		}
+		whc.ACME.FixDefaults()
+		whc.SelfSigned = false
+	} else {
+		if whc.GenerateCertificate {
+			whc.fixCertificateDefaults()
+		}
+		if whc.KeyFile == "" {
+			return false
+		}
+		if whc.CertFile == "" {
+			return false
+		}
 	}
 	if whc.Port == 0 {
 		whc.Port = 443
@@ -183,6 +253,46 @@ func (whc *WebHookConfigs) check(apiKey string) bool {
 	return true
 }
 
+func (whc *WebHookConfigs) fixCertificateDefaults() {
+	whc.SelfSigned = true
+	if whc.CertFile == "" {
+		whc.CertFile = DefaultWebhookCertFile
+	}
+	if whc.KeyFile == "" {
+		whc.KeyFile = DefaultWebhookKeyFile
+	}
+	if whc.CertificateValidity <= 0 {
+		whc.CertificateValidity = 365 * 24 * time.Hour
+	}
+	if whc.CertificateRenewBefore <= 0 {
+		whc.CertificateRenewBefore = 30 * 24 * time.Hour
+	}
+}
+
+// FixDefaults sets the default directory url and cache directory if they are empty.
+func (ac *ACMEConfigs) FixDefaults() {
+	if ac.DirectoryURL == "" {
+		ac.DirectoryURL = DefaultACMEDirectory
+	}
+	if ac.CacheDir == "" {
+		ac.CacheDir = DefaultACMECacheDir
+	}
+}
+
+// LogRotationConfigs contains the configs for rotating the log file.
+type LogRotationConfigs struct {
+	/*The log file is rotated when its size reaches this many megabytes. Pass 0 to disable size based rotation.*/
+	MaxSize int `json:"max_size"`
+	/*The log file is rotated when it has been open for this long. Pass 0 to disable time based rotation.*/
+	Interval time.Duration `json:"interval,omitempty"`
+	/*Maximum number of rotated files to keep. Pass 0 to keep all of them.*/
+	MaxBackups int `json:"max_backups"`
+	/*Rotated files older than this are removed. Pass 0 to keep the files regardless of their age.*/
+	MaxAge time.Duration `json:"max_age,omitempty"`
+	/*If true, rotated files are compressed using gzip.*/
+	Compress bool `json:"compress"`
+}
+
 // UpdateConfigs contains the necessary configs for receiving updates.
 type UpdateConfigs struct {
 	/*Limits the number of updates to be retrieved. Values between 1-100 are accepted. Defaults to 100.*/
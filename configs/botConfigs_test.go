@@ -22,6 +22,19 @@ func TestConfigCheck(t *testing.T) {
 	}
 }
 
+func TestGenerateCertificateDefaults(t *testing.T) {
+	whc := &WebHookConfigs{URL: "https://example.com", GenerateCertificate: true}
+	if !whc.check("sisduifhdsfsdf") {
+		t.Fatal("check failed")
+	}
+	if !whc.SelfSigned || whc.CertFile != DefaultWebhookCertFile || whc.KeyFile != DefaultWebhookKeyFile {
+		t.Error("certificate defaults are not set")
+	}
+	if whc.CertificateValidity <= whc.CertificateRenewBefore {
+		t.Error("certificate would be renewed right after it is generated")
+	}
+}
+
 func TestLoadAndDump(t *testing.T) {
 	bc1 := Default("123hUHASDa66aDTDAFshdASDKabda6dg982edua")
 	err := Dump(bc1)
@@ -47,5 +60,10 @@ func initTheCfgs() {
 	cfg4 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{}}, false}
 	cfg5 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: false}, false}
 	cfg6 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: false, UpdateConfigs: DefaultUpdateConfigs()}, true}
-	cfgs = []cfgTest{cfg1, cfg2, cfg3, cfg4, cfg5, cfg6}
+	cfg7 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", GenerateCertificate: true}}, true}
+	cfg8 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", ACME: &ACMEConfigs{}}}, true}
+	cfg9 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", ACME: &ACMEConfigs{}, GenerateCertificate: true}}, false}
+	cfg10 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://203.0.113.5:8443", ACME: &ACMEConfigs{}}}, false}
+	cfg11 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://[2001:db8::1]", ACME: &ACMEConfigs{}}}, false}
+	cfgs = []cfgTest{cfg1, cfg2, cfg3, cfg4, cfg5, cfg6, cfg7, cfg8, cfg9, cfg10, cfg11}
 }
@@ -0,0 +1,78 @@
+package telego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-b * * * *",
+		"@every",
+	} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("%q should be rejected", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 * * * *", date(2024, 6, 3, 10, 7), date(2024, 6, 3, 10, 15)},
+		//The result is always after the given time.
+		{"*/15 * * * *", date(2024, 6, 3, 10, 15), date(2024, 6, 3, 10, 30)},
+		{"* * * * *", date(2024, 6, 3, 10, 15).Add(30 * time.Second), date(2024, 6, 3, 10, 16)},
+		{"0-30/10 9-17 * * *", date(2024, 6, 3, 17, 31), date(2024, 6, 4, 9, 0)},
+		{"5/20 * * * *", date(2024, 6, 3, 10, 46), date(2024, 6, 3, 11, 5)},
+		{"0,30 8,20 * * *", date(2024, 6, 3, 8, 1), date(2024, 6, 3, 8, 30)},
+		{"0 0 1 1 *", date(2024, 12, 31, 23, 59), date(2025, 1, 1, 0, 0)},
+		{"@monthly", date(2024, 1, 31, 12, 0), date(2024, 2, 1, 0, 0)},
+		{"@hourly", date(2024, 1, 31, 23, 0), date(2024, 2, 1, 0, 0)},
+		//Months without the day are skipped.
+		{"0 0 31 * *", date(2024, 4, 1, 0, 0), date(2024, 5, 31, 0, 0)},
+		//Day of week : 2024-06-01 is a Saturday.
+		{"0 9 * * 1-5", date(2024, 6, 1, 0, 0), date(2024, 6, 3, 9, 0)},
+		{"0 0 * * 0", date(2024, 6, 1, 0, 0), date(2024, 6, 2, 0, 0)},
+		{"0 0 * * 7", date(2024, 6, 1, 0, 0), date(2024, 6, 2, 0, 0)},
+		//If both day of month and day of week are restricted, a day matching either of them is accepted. 2024-09-06 is a Friday.
+		{"0 0 13 * 5", date(2024, 9, 1, 0, 0), date(2024, 9, 6, 0, 0)},
+		{"0 0 13 * 5", date(2024, 9, 10, 0, 0), date(2024, 9, 13, 0, 0)},
+		//If one of them starts with "*", both of them should match.
+		{"0 0 13 * *", date(2024, 9, 1, 0, 0), date(2024, 9, 13, 0, 0)},
+		{"0 0 */10 * 5", date(2024, 9, 1, 0, 0), date(2024, 10, 11, 0, 0)},
+		//Leap days.
+		{"0 0 29 2 *", date(2023, 1, 1, 0, 0), date(2024, 2, 29, 0, 0)},
+		{"0 0 29 2 *", date(2024, 3, 1, 0, 0), date(2028, 2, 29, 0, 0)},
+		{"0 0 28 2 *", date(2024, 2, 28, 0, 0), date(2025, 2, 28, 0, 0)},
+		//Never matches.
+		{"0 0 31 2 *", date(2024, 1, 1, 0, 0), time.Time{}},
+		{"0 0 30 2 *", date(2024, 1, 1, 0, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		cs, err := parseCron(tt.expr)
+		if err != nil {
+			t.Errorf("%q : %v", tt.expr, err)
+			continue
+		}
+		if got := cs.next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q from %v : expected %v, got %v", tt.expr, tt.from, tt.want, got)
+		}
+	}
+}
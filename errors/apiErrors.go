@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+// Kind is a category of Bot API failures. Use errors.Is to check if an error returned by the bot belongs to a category :
+//
+//	if errors.Is(err, errs.ErrBotBlocked) { ... }
+type Kind string
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+const (
+	// ErrBadRequest means the API server rejected the request (error code 400).
+	ErrBadRequest Kind = "bad request"
+	// ErrUnauthorized means the API key is invalid (error code 401).
+	ErrUnauthorized Kind = "unauthorized"
+	// ErrForbidden means the bot is not allowed to do the requested action (error code 403).
+	ErrForbidden Kind = "forbidden"
+	// ErrBotBlocked means the user has blocked the bot. It is a subcategory of ErrForbidden.
+	ErrBotBlocked Kind = "bot was blocked by the user"
+	// ErrChatNotFound means the chat does not exist or the bot is not a member of it. It is a subcategory of ErrBadRequest.
+	ErrChatNotFound Kind = "chat not found"
+	// ErrMessageNotModified means the new content of an edited message is the same as the old one. It is a subcategory of ErrBadRequest.
+	ErrMessageNotModified Kind = "message is not modified"
+	// ErrChatMigrated means the group has been migrated to a supergroup. The id of the supergroup is in MigrateToChatId field of APIError.
+	ErrChatMigrated Kind = "group chat was upgraded to a supergroup chat"
+	// ErrNotFound means the method does not exist (error code 404).
+	ErrNotFound Kind = "not found"
+	// ErrConflict means another getUpdates request or a webhook is active (error code 409).
+	ErrConflict Kind = "conflict"
+	// ErrTooManyRequests means the flood control of the API server has been exceeded (error code 429). The time to wait is in RetryAfter field of APIError.
+	ErrTooManyRequests Kind = "too many requests"
+	// ErrServer means the API server failed to process the request (error codes 5xx).
+	ErrServer Kind = "server error"
+	// ErrNetwork means the request could not be sent or the response could not be received.
+	ErrNetwork Kind = "network error"
+)
+
+/*
+APIError is the error returned when the API server responds with "ok" : false. Use errors.As to get it from an error returned by the bot :
+
+	var apiErr *errs.APIError
+	if errors.As(err, &apiErr) && errors.Is(err, errs.ErrTooManyRequests) {
+		time.Sleep(apiErr.RetryAfter)
+	}
+*/
+type APIError struct {
+	// Method is the name of the failed method.
+	Method string
+	// ErrorCode is the error code returned by the API server.
+	ErrorCode int
+	// Description is the description of the error returned by the API server.
+	Description string
+	// RetryAfter is the time to wait before the request can be repeated. Only set when the flood control has been exceeded.
+	RetryAfter time.Duration
+	// MigrateToChatId is the id of the supergroup which the group has been migrated to. Only set when the group has been migrated.
+	MigrateToChatId int64
+	kinds           []Kind
+}
+
+// NewAPIError creates an APIError from the failure result returned by the API server for the given method.
+func NewAPIError(method string, fr *objs.FailureResult) *APIError {
+	ae := &APIError{Method: method, ErrorCode: fr.ErrorCode, Description: fr.Description}
+	if fr.Parameters != nil {
+		ae.RetryAfter = time.Duration(fr.Parameters.RetryAfter) * time.Second
+		ae.MigrateToChatId = fr.Parameters.MigrateToChatId
+	}
+	ae.kinds = classify(ae)
+	return ae
+}
+
+func classify(ae *APIError) []Kind {
+	desc := strings.ToLower(ae.Description)
+	var out []Kind
+	switch {
+	case ae.ErrorCode == 400:
+		out = append(out, ErrBadRequest)
+	case ae.ErrorCode == 401:
+		out = append(out, ErrUnauthorized)
+	case ae.ErrorCode == 403:
+		out = append(out, ErrForbidden)
+	case ae.ErrorCode == 404:
+		out = append(out, ErrNotFound)
+	case ae.ErrorCode == 409:
+		out = append(out, ErrConflict)
+	case ae.ErrorCode == 429:
+		out = append(out, ErrTooManyRequests)
+	case ae.ErrorCode >= 500:
+		out = append(out, ErrServer)
+	}
+	switch {
+	case strings.Contains(desc, string(ErrBotBlocked)):
+		out = append(out, ErrBotBlocked)
+	case strings.Contains(desc, string(ErrChatNotFound)):
+		out = append(out, ErrChatNotFound)
+	case strings.Contains(desc, string(ErrMessageNotModified)):
+		out = append(out, ErrMessageNotModified)
+	}
+	if ae.MigrateToChatId != 0 {
+		out = append(out, ErrChatMigrated)
+	}
+	if ae.RetryAfter != 0 && ae.ErrorCode != 429 {
+		out = append(out, ErrTooManyRequests)
+	}
+	return out
+}
+
+func (ae *APIError) Error() string {
+	return ae.Method + " failed. Error code : " + strconv.Itoa(ae.ErrorCode) + ", Description : " + ae.Description
+}
+
+// Is reports whether the error belongs to the given category.
+func (ae *APIError) Is(target error) bool {
+	k, ok := target.(Kind)
+	if !ok {
+		return false
+	}
+	for _, kind := range ae.kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkError is returned when the request could not be sent to the API server or the response could not be received.
+type NetworkError struct {
+	Method string
+	Err    error
+}
+
+func (ne *NetworkError) Error() string {
+	return "Unable to send " + ne.Method + ". " + ne.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (ne *NetworkError) Unwrap() error {
+	return ne.Err
+}
+
+// Is reports whether target is ErrNetwork.
+func (ne *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func TestAPIErrorKinds(t *testing.T) {
+	cases := []struct {
+		fr       *objs.FailureResult
+		kinds    []Kind
+		notKinds []Kind
+	}{
+		{&objs.FailureResult{ErrorCode: 403, Description: "Forbidden: bot was blocked by the user"}, []Kind{ErrForbidden, ErrBotBlocked}, []Kind{ErrBadRequest}},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: chat not found"}, []Kind{ErrBadRequest, ErrChatNotFound}, []Kind{ErrForbidden, ErrMessageNotModified}},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}, []Kind{ErrBadRequest, ErrMessageNotModified}, nil},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &objs.ResponseParameters{MigrateToChatId: -100123}}, []Kind{ErrBadRequest, ErrChatMigrated}, nil},
+		{&objs.FailureResult{ErrorCode: 429, Description: "Too Many Requests: retry after 5", Parameters: &objs.ResponseParameters{RetryAfter: 5}}, []Kind{ErrTooManyRequests}, []Kind{ErrBadRequest}},
+		{&objs.FailureResult{ErrorCode: 502, Description: "Bad Gateway"}, []Kind{ErrServer}, []Kind{ErrNetwork}},
+	}
+	for _, c := range cases {
+		err := fmt.Errorf("wrapped : %w", &MethodNotSentError{Method: "sendMessage", FailureResult: c.fr})
+		for _, k := range c.kinds {
+			if !errors.Is(err, k) {
+				t.Errorf("%q should be %q", c.fr.Description, k)
+			}
+		}
+		for _, k := range c.notKinds {
+			if errors.Is(err, k) {
+				t.Errorf("%q should not be %q", c.fr.Description, k)
+			}
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode != c.fr.ErrorCode || apiErr.Description != c.fr.Description {
+			t.Errorf("%q is not an APIError", c.fr.Description)
+		}
+	}
+
+	var apiErr *APIError
+	errors.As(&MethodNotSentError{Method: "sendMessage", FailureResult: cases[3].fr}, &apiErr)
+	if apiErr.MigrateToChatId != -100123 {
+		t.Error("MigrateToChatId is not set")
+	}
+	errors.As(&MethodNotSentError{Method: "sendMessage", FailureResult: cases[4].fr}, &apiErr)
+	if apiErr.RetryAfter != 5*time.Second {
+		t.Error("RetryAfter is not set")
+	}
+}
+
+func TestNetworkError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := &MethodNotSentError{Method: "getMe", Reason: cause.Error(), Err: &NetworkError{Method: "getMe", Err: cause}}
+	if !errors.Is(err, ErrNetwork) || !errors.Is(err, cause) || errors.Is(err, ErrServer) {
+		t.Fail()
+	}
+}
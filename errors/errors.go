@@ -7,10 +7,26 @@ import (
 	objs "github.com/hamidteimouri/telego/objects"
 )
 
-// MethodNotSentError is returned when API server responds with any code other than 200
+/*
+MethodNotSentError is returned when API server responds with any code other than 200.
+It wraps an *APIError if the API server has returned a failure result or a *NetworkError if the request could not be sent, so the cause can be checked using errors.Is and errors.As.
+*/
 type MethodNotSentError struct {
 	Method, Reason string
 	FailureResult  *objs.FailureResult
+	//Err is the cause of the error. If nil, it is derived from FailureResult.
+	Err error
+}
+
+// Unwrap returns the cause of the error.
+func (mnse *MethodNotSentError) Unwrap() error {
+	if mnse.Err != nil {
+		return mnse.Err
+	}
+	if mnse.FailureResult != nil {
+		return NewAPIError(mnse.Method, mnse.FailureResult)
+	}
+	return nil
 }
 
 func (mnse *MethodNotSentError) Error() string {
@@ -56,6 +72,8 @@ func (ram *RequiredArgumentError) Error() string {
 }
 
 // ChatIdProblem indicates a problem in the chat id.
+//
+// Deprecated: chat ids are passed as objects.ChatID which can not hold both a numeric id and a username. This error is not returned anymore.
 type ChatIdProblem struct {
 }
 
@@ -86,3 +104,40 @@ type MethodDeprecated struct {
 func (m *MethodDeprecated) Error() string {
 	return fmt.Sprintf("This method (%s) has been deprecated. Please use %s instead.", m.MethodName, m.Replacement)
 }
+
+// FileTooLargeError indicates that a downloaded file is larger than the maximum allowed size.
+type FileTooLargeError struct {
+	FilePath string
+	MaxSize  int64
+}
+
+func (ftl *FileTooLargeError) Error() string {
+	return "file \"" + ftl.FilePath + "\" is larger than the maximum allowed size of " + strconv.FormatInt(ftl.MaxSize, 10) + " bytes"
+}
+
+// ResultDecodeError indicates that the result returned by the API server could not be decoded into the expected type.
+type ResultDecodeError struct {
+	Method string
+	Err    error
+}
+
+// Unwrap returns the json error.
+func (rde *ResultDecodeError) Unwrap() error {
+	return rde.Err
+}
+
+func (rde *ResultDecodeError) Error() string {
+	return "unable to decode the result of " + rde.Method + ". " + rde.Err.Error()
+}
+
+// MessagesSkippedError indicates that the API server skipped some of the messages of a bulk request (like forwardMessages). The API server does not tell which messages were skipped.
+type MessagesSkippedError struct {
+	Method          string
+	Requested, Done int
+	// NewMessageIds contains the identifiers of the messages which were sent by the request, in the order of the source messages.
+	NewMessageIds []int64
+}
+
+func (mse *MessagesSkippedError) Error() string {
+	return mse.Method + " skipped " + strconv.Itoa(mse.Requested-mse.Done) + " of " + strconv.Itoa(mse.Requested) + " messages"
+}
@@ -0,0 +1,604 @@
+/*
+Apigen generates the objects, the method arguments and the raw method wrappers of the bot API from a machine-readable specification of the API (api/botapi.json).
+
+The specification has the same layout as the one published at https://github.com/PaulSonOfLars/telegram-bot-api-spec. Types and methods which are already written by hand in the objects and tba packages are skipped, so the generated code only fills the gaps and the ergonomic layer stays on top of it.
+
+Usage:
+
+	go run ./internal/apigen -spec api/botapi.json -objects objects -tba tba
+
+Pass -fetch to download the latest specification from telegram-bot-api-spec into the -spec file before generating.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const header = "// Code generated by apigen from %s. DO NOT EDIT.\n\n"
+
+/*The url of the latest specification published by telegram-bot-api-spec.*/
+const upstreamSpecURL = "https://raw.githubusercontent.com/PaulSonOfLars/telegram-bot-api-spec/main/api.json"
+
+/*Spec is the machine-readable specification of the bot API.*/
+type Spec struct {
+	Version string                 `json:"version"`
+	Types   map[string]*SpecType   `json:"types"`
+	Methods map[string]*SpecMethod `json:"methods"`
+}
+
+/*SpecField is a field of a type or a parameter of a method.*/
+type SpecField struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+/*
+SpecType is an object of the bot API. Types with subtypes (like ReactionType) are generated as one struct containing the fields of all the subtypes.
+The subtypes themselves are not generated unless another object or method refers to them.
+*/
+type SpecType struct {
+	Name        string      `json:"name"`
+	Description []string    `json:"description"`
+	Fields      []SpecField `json:"fields"`
+	Subtypes    []string    `json:"subtypes"`
+	SubtypeOf   []string    `json:"subtype_of"`
+}
+
+/*SpecMethod is a method of the bot API.*/
+type SpecMethod struct {
+	Name        string      `json:"name"`
+	Description []string    `json:"description"`
+	Fields      []SpecField `json:"fields"`
+	Returns     []string    `json:"returns"`
+}
+
+/*Declared contains the names which are already declared by hand in the target packages.*/
+type Declared struct {
+	//Types declared in the objects package.
+	Types map[string]bool
+	//Methods of BotAPIInterface declared in the tba package.
+	Methods map[string]bool
+}
+
+/*Output is the generated source code of the files.*/
+type Output struct {
+	Types   []byte
+	Args    []byte
+	Methods []byte
+}
+
+func main() {
+	specPath := flag.String("spec", "api/botapi.json", "path of the bot API specification")
+	objectsDir := flag.String("objects", "objects", "directory of the objects package")
+	tbaDir := flag.String("tba", "tba", "directory of the tba package")
+	fetch := flag.Bool("fetch", false, "download the latest specification into the spec file before generating")
+	flag.Parse()
+	if *fetch {
+		if err := fetchSpec(*specPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	spec, err := LoadSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	declared := &Declared{}
+	declared.Types, err = declaredNames(*objectsDir, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	declared.Methods, err = declaredNames(*tbaDir, "BotAPIInterface")
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := Generate(spec, declared, filepath.Base(*specPath))
+	if err != nil {
+		log.Fatal(err)
+	}
+	files := map[string][]byte{
+		filepath.Join(*objectsDir, "generated_types.go"): out.Types,
+		filepath.Join(*objectsDir, "generated_args.go"):  out.Args,
+		filepath.Join(*tbaDir, "generated_methods.go"):   out.Methods,
+	}
+	for path, src := range files {
+		if err := os.WriteFile(path, src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+/*Downloads the latest specification and saves it in the given file. The file is not changed if the download is not a valid specification.*/
+func fetchSpec(path string) error {
+	res, err := http.Get(upstreamSpecURL)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading the specification: %s", res.Status)
+	}
+	bt, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bt, &Spec{}); err != nil {
+		return fmt.Errorf("parsing the downloaded specification: %w", err)
+	}
+	return os.WriteFile(path, bt, 0644)
+}
+
+/*LoadSpec reads the specification from the given file.*/
+func LoadSpec(path string) (*Spec, error) {
+	bt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(bt, spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+/*
+Returns the names declared in the package in the given directory. The generated files are ignored.
+If receiver is empty the top level types are returned, otherwise the methods of the receiver are returned.
+*/
+func declaredNames(dir, receiver string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	filter := func(fi fs.FileInfo) bool {
+		return !strings.HasPrefix(fi.Name(), "generated_") && !strings.HasSuffix(fi.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.GenDecl:
+					if receiver != "" || d.Tok != token.TYPE {
+						continue
+					}
+					for _, s := range d.Specs {
+						names[s.(*ast.TypeSpec).Name.Name] = true
+					}
+				case *ast.FuncDecl:
+					if receiver == "" || d.Recv == nil || len(d.Recv.List) == 0 {
+						continue
+					}
+					typ := d.Recv.List[0].Type
+					if star, ok := typ.(*ast.StarExpr); ok {
+						typ = star.X
+					}
+					if id, ok := typ.(*ast.Ident); ok && id.Name == receiver {
+						names[d.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+/*Generate generates the source code of the types, the arguments and the methods in the specification which are not declared already.*/
+func Generate(spec *Spec, declared *Declared, source string) (*Output, error) {
+	g := &generator{spec: spec, declared: declared, referenced: referencedTypes(spec)}
+	out := &Output{}
+	var err error
+	if out.Types, err = g.file(source, "objects", g.types); err != nil {
+		return nil, fmt.Errorf("generating types: %w", err)
+	}
+	if out.Args, err = g.file(source, "objects", g.args); err != nil {
+		return nil, fmt.Errorf("generating arguments: %w", err)
+	}
+	if out.Methods, err = g.file(source, "tba", g.methods); err != nil {
+		return nil, fmt.Errorf("generating methods: %w", err)
+	}
+	return out, nil
+}
+
+type generator struct {
+	spec       *Spec
+	declared   *Declared
+	imports    map[string]string
+	referenced map[string]bool
+}
+
+/*Builds a formatted go file. The body function writes the declarations and adds the imports it needs.*/
+func (g *generator) file(source, pkg string, body func(buf *bytes.Buffer) error) ([]byte, error) {
+	g.imports = make(map[string]string)
+	decls := &bytes.Buffer{}
+	if err := body(decls); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, header, source)
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	if len(g.imports) != 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		buf.WriteString("import (\n")
+		for i, path := range paths {
+			//The standard library is separated from the packages of the module.
+			if i != 0 && strings.Contains(path, ".") && !strings.Contains(paths[i-1], ".") {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(buf, "%s %q\n", g.imports[path], path)
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.Write(decls.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+func (g *generator) types(buf *bytes.Buffer) error {
+	for _, name := range sortedKeys(g.spec.Types) {
+		if g.declared.Types[name] || g.mergedVariant(name) {
+			continue
+		}
+		typ := g.spec.Types[name]
+		fields := typ.Fields
+		description := strings.Join(typ.Description, "\n")
+		if len(typ.Subtypes) != 0 {
+			var err error
+			if fields, err = g.mergedFields(typ); err != nil {
+				return err
+			}
+			description += "\nThe fields of all of them are merged into this struct."
+		}
+		writeComment(buf, description)
+		fmt.Fprintf(buf, "type %s struct {\n", name)
+		for _, field := range fields {
+			goType, err := g.goType(field, "", true)
+			if err != nil {
+				return fmt.Errorf("field %s of %s: %w", field.Name, name, err)
+			}
+			g.writeField(buf, field, goType)
+		}
+		buf.WriteString("}\n\n")
+	}
+	return nil
+}
+
+/*Returns the fields of all the subtypes of the type. Fields which are not present in every subtype are optional.*/
+func (g *generator) mergedFields(typ *SpecType) ([]SpecField, error) {
+	var fields []SpecField
+	index := make(map[string]int)
+	count := make(map[string]int)
+	for _, subName := range typ.Subtypes {
+		sub, ok := g.spec.Types[subName]
+		if !ok {
+			return nil, fmt.Errorf("unknown subtype %s of %s", subName, typ.Name)
+		}
+		for _, field := range sub.Fields {
+			count[field.Name]++
+			if i, ok := index[field.Name]; ok {
+				fields[i].Required = fields[i].Required && field.Required
+				continue
+			}
+			index[field.Name] = len(fields)
+			//The descriptions of the discriminator fields name one subtype, like: Type of the reaction, always “emoji”
+			if i := strings.Index(field.Description, ", always “"); i != -1 {
+				field.Description = field.Description[:i]
+			}
+			fields = append(fields, field)
+		}
+	}
+	for i := range fields {
+		if count[fields[i].Name] != len(typ.Subtypes) {
+			fields[i].Required = false
+		}
+	}
+	return fields, nil
+}
+
+/*
+Returns true if the type is only a subtype of types which are generated as merged structs and nothing refers to it.
+Such subtypes are not generated, because their fields are already in the merged struct.
+*/
+func (g *generator) mergedVariant(name string) bool {
+	typ := g.spec.Types[name]
+	if len(typ.SubtypeOf) == 0 || g.referenced[name] {
+		return false
+	}
+	for _, parent := range typ.SubtypeOf {
+		//The subtypes of the hand-written types (like the kinds of ChatMember) are used by them.
+		if g.declared.Types[parent] {
+			return false
+		}
+	}
+	return true
+}
+
+/*Returns the names of the types which are the type of a field, a parameter or a result in the specification.*/
+func referencedTypes(spec *Spec) map[string]bool {
+	refs := make(map[string]bool)
+	add := func(types []string) {
+		for _, typ := range types {
+			for {
+				elem, ok := strings.CutPrefix(typ, "Array of ")
+				if !ok {
+					break
+				}
+				typ = elem
+			}
+			refs[typ] = true
+		}
+	}
+	for _, typ := range spec.Types {
+		for _, field := range typ.Fields {
+			add(field.Types)
+		}
+	}
+	for _, method := range spec.Methods {
+		add(method.Returns)
+		for _, field := range method.Fields {
+			add(field.Types)
+		}
+	}
+	return refs
+}
+
+func (g *generator) args(buf *bytes.Buffer) error {
+	for _, name := range sortedKeys(g.spec.Methods) {
+		method := g.spec.Methods[name]
+		argsName := argsTypeName(name)
+		if g.skipMethod(name) || len(method.Fields) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "/*%s contains the arguments of %q method.*/\n", argsName, name)
+		fmt.Fprintf(buf, "type %s struct {\n", argsName)
+		var chatIds []string
+		for _, field := range method.Fields {
+			goType, err := g.goType(field, "", false)
+			if err != nil {
+				return fmt.Errorf("parameter %s of %s: %w", field.Name, name, err)
+			}
+			if field.Name == "chat_id" && goType == "ChatID" {
+				chatIds = append(chatIds, goName(field.Name))
+			}
+			g.writeField(buf, field, goType)
+		}
+		buf.WriteString("}\n\n")
+		g.imports["encoding/json"] = ""
+		g.imports["mime/multipart"] = "mp"
+		fmt.Fprintf(buf, "// ToJson converts this strcut into json to be sent to the API server.\n")
+		fmt.Fprintf(buf, "func (args *%s) ToJson() []byte {\nbt, err := json.Marshal(args)\nif err != nil {\nreturn nil\n}\nreturn bt\n}\n\n", argsName)
+		fmt.Fprintf(buf, "// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.\n")
+		fmt.Fprintf(buf, "func (args *%s) ToMultiPart(wr *mp.Writer) {\njsonToMultiPart(args.ToJson(), wr)\n}\n\n", argsName)
+		for _, field := range chatIds {
+			fmt.Fprintf(buf, "// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.\n")
+			fmt.Fprintf(buf, "func (args *%s) SetChatId(chatId json.RawMessage) {\n_ = json.Unmarshal(chatId, &args.%s)\n}\n\n", argsName, field)
+			fmt.Fprintf(buf, "// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.\n")
+			fmt.Fprintf(buf, "func (args *%s) GetChatId() json.RawMessage {\nif args.%s.IsZero() {\nreturn nil\n}\nbt, _ := json.Marshal(args.%s)\nreturn bt\n}\n\n", argsName, field, field)
+		}
+	}
+	return nil
+}
+
+func (g *generator) methods(buf *bytes.Buffer) error {
+	for _, name := range sortedKeys(g.spec.Methods) {
+		if g.skipMethod(name) {
+			continue
+		}
+		method := g.spec.Methods[name]
+		ret, err := g.goType(SpecField{Name: "result", Types: method.Returns, Required: true}, "objs.", true)
+		if err != nil {
+			return fmt.Errorf("result of %s: %w", name, err)
+		}
+		g.imports["encoding/json"] = ""
+		g.imports["github.com/hamidteimouri/telego/objects"] = "objs"
+		writeComment(buf, strings.Join(method.Description, "\n"))
+		var params, argsVar string
+		if len(method.Fields) == 0 {
+			argsVar = "nil"
+		} else {
+			argsVar = "args"
+			params = "args *objs." + argsTypeName(name)
+		}
+		hasFiles := false
+		for _, field := range method.Fields {
+			if containsType(field.Types, "InputFile") {
+				hasFiles = true
+			}
+		}
+		if hasFiles {
+			params += ", files ...objs.NamedReader"
+		}
+		fmt.Fprintf(buf, "func (bai *BotAPIInterface) %s(%s) (*objs.Result[%s], error) {\n", goName(name), params, ret)
+		var chatIds []SpecField
+		for _, field := range method.Fields {
+			if goType, _ := g.goType(field, "", false); goType == "ChatID" {
+				chatIds = append(chatIds, field)
+			}
+		}
+		if argsVar == "args" {
+			g.imports["github.com/hamidteimouri/telego/errors"] = "errs"
+			if hasRequired(method.Fields) {
+				fmt.Fprintf(buf, "if args == nil {\nreturn nil, &errs.RequiredArgumentError{ArgName: \"args\", MethodName: %q}\n}\n", name)
+			} else {
+				fmt.Fprintf(buf, "if args == nil {\nargs = &objs.%s{}\n}\n", argsTypeName(name))
+			}
+			for _, field := range chatIds {
+				if field.Required {
+					fmt.Fprintf(buf, "if args.%s.IsZero() {\nreturn nil, &errs.RequiredArgumentError{ArgName: %q, MethodName: %q}\n}\n", goName(field.Name), field.Name, name)
+				}
+			}
+			if len(chatIds) != 0 {
+				//The arguments of the caller are not modified.
+				buf.WriteString("cp := *args\n")
+				for _, field := range chatIds {
+					fmt.Fprintf(buf, "cp.%[1]s = bai.migrateChatId(cp.%[1]s)\n", goName(field.Name))
+				}
+				buf.WriteString("args = &cp\n")
+			}
+		}
+		if hasFiles {
+			fmt.Fprintf(buf, "res, err := bai.SendCustom(%q, %s, len(files) != 0, files...)\n", name, argsVar)
+		} else {
+			fmt.Fprintf(buf, "res, err := bai.SendCustom(%q, %s, false)\n", name, argsVar)
+		}
+		fmt.Fprintf(buf, "if err != nil {\nreturn nil, err\n}\n")
+		fmt.Fprintf(buf, "msg := &objs.Result[%s]{}\nif err := json.Unmarshal(res, msg); err != nil {\nreturn nil, err\n}\nreturn msg, nil\n}\n\n", ret)
+	}
+	return nil
+}
+
+/*Methods are skipped when either the wrapper or the arguments type is written by hand.*/
+func (g *generator) skipMethod(name string) bool {
+	return g.declared.Methods[goName(name)] || g.declared.Types[argsTypeName(name)]
+}
+
+func (g *generator) writeField(buf *bytes.Buffer, field SpecField, goType string) {
+	if field.Description != "" {
+		writeComment(buf, field.Description)
+	}
+	tag := field.Name
+	if !field.Required {
+		tag += ",omitempty"
+	}
+	fmt.Fprintf(buf, "%s %s `json:\"%s\"`\n", goName(field.Name), goType, tag)
+}
+
+/*
+Returns the go type of the field. pkg is prepended to the names of the objects.
+Objects are pointers in the fields of the objects (ptr is true) so optional objects can be omitted.
+*/
+func (g *generator) goType(field SpecField, pkg string, ptr bool) (string, error) {
+	switch len(field.Types) {
+	case 0:
+		return "", fmt.Errorf("no type")
+	case 1:
+	default:
+		if sameTypes(field.Types, "Integer", "String") {
+			return pkg + "ChatID", nil
+		}
+		if containsType(field.Types, "InputFile") {
+			return "string", nil
+		}
+		g.imports["encoding/json"] = ""
+		return "json.RawMessage", nil
+	}
+	typ := field.Types[0]
+	if elem, ok := strings.CutPrefix(typ, "Array of "); ok {
+		inner, err := g.goType(SpecField{Name: strings.TrimSuffix(field.Name, "s"), Types: []string{elem}}, pkg, false)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + inner, nil
+	}
+	switch typ {
+	case "Integer":
+		if isIdentifier(field.Name) {
+			return "int64", nil
+		}
+		return "int", nil
+	case "Float", "Float number":
+		return "float64", nil
+	case "String":
+		return "string", nil
+	case "Boolean", "True":
+		return "bool", nil
+	case "InputFile":
+		return "string", nil
+	}
+	if _, ok := g.spec.Types[typ]; !ok && !g.declared.Types[typ] {
+		return "", fmt.Errorf("unknown type %s", typ)
+	}
+	if ptr {
+		return "*" + pkg + typ, nil
+	}
+	return pkg + typ, nil
+}
+
+/*Chat, user and message identifiers are int64 like the hand-written objects.*/
+func isIdentifier(name string) bool {
+	return name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids")
+}
+
+/*Converts snake_case and camelCase names into exported go names.*/
+func goName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func argsTypeName(method string) string {
+	return goName(method) + "Args"
+}
+
+func writeComment(buf *bytes.Buffer, text string) {
+	text = strings.ReplaceAll(text, "*/", "* /")
+	fmt.Fprintf(buf, "/*%s*/\n", text)
+}
+
+func hasRequired(fields []SpecField) bool {
+	for _, field := range fields {
+		if field.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []string, typ string) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func sameTypes(types []string, expected ...string) bool {
+	if len(types) != len(expected) {
+		return false
+	}
+	for _, t := range expected {
+		if !containsType(types, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	spec := &Spec{
+		Types: map[string]*SpecType{
+			"Chat": {Name: "Chat", Fields: []SpecField{{Name: "id", Types: []string{"Integer"}, Required: true}}},
+			"ReactionType": {
+				Name:     "ReactionType",
+				Subtypes: []string{"ReactionTypeEmoji", "ReactionTypeCustomEmoji"},
+			},
+			"ReactionTypeEmoji": {Name: "ReactionTypeEmoji", SubtypeOf: []string{"ReactionType"}, Fields: []SpecField{
+				{Name: "type", Types: []string{"String"}, Required: true, Description: "Type of the reaction, always “emoji”"},
+				{Name: "emoji", Types: []string{"String"}, Required: true},
+			}},
+			"ReactionTypeCustomEmoji": {Name: "ReactionTypeCustomEmoji", SubtypeOf: []string{"ReactionType"}, Fields: []SpecField{
+				{Name: "type", Types: []string{"String"}, Required: true},
+				{Name: "custom_emoji_id", Types: []string{"String"}, Required: true},
+			}},
+		},
+		Methods: map[string]*SpecMethod{
+			"setMessageReaction": {Name: "setMessageReaction", Returns: []string{"Boolean"}, Fields: []SpecField{
+				{Name: "chat_id", Types: []string{"Integer", "String"}, Required: true},
+				{Name: "message_id", Types: []string{"Integer"}, Required: true},
+				{Name: "reaction", Types: []string{"Array of ReactionType"}},
+			}},
+			"sendMessage": {Name: "sendMessage", Returns: []string{"Message"}},
+		},
+	}
+	declared := &Declared{
+		Types:   map[string]bool{"Chat": true, "Message": true},
+		Methods: map[string]bool{"SendMessage": true},
+	}
+	out, err := Generate(spec, declared, "test.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types, args, methods := compact(out.Types), compact(out.Args), compact(out.Methods)
+	if strings.Contains(types, "type Chat struct") || strings.Contains(methods, "SendMessage") || strings.Contains(args, "SendMessageArgs") {
+		t.Fatal("the hand-written declarations should be skipped")
+	}
+	if strings.Contains(types, "type ReactionTypeEmoji struct") || strings.Contains(types, "type ReactionTypeCustomEmoji struct") {
+		t.Errorf("the subtypes which are merged into ReactionType should not be generated:\n%s", types)
+	}
+	for _, expected := range []string{
+		"Type string `json:\"type\"`",
+		"/*Type of the reaction*/",
+		"Emoji string `json:\"emoji,omitempty\"`",
+		"CustomEmojiId string `json:\"custom_emoji_id,omitempty\"`",
+	} {
+		if !strings.Contains(types, expected) {
+			t.Errorf("merged ReactionType should contain %s:\n%s", expected, types)
+		}
+	}
+	for _, expected := range []string{
+		"ChatId ChatID `json:\"chat_id\"`",
+		"MessageId int64 `json:\"message_id\"`",
+		"Reaction []ReactionType `json:\"reaction,omitempty\"`",
+		"func (args *SetMessageReactionArgs) SetChatId(chatId json.RawMessage)",
+	} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("arguments should contain %s:\n%s", expected, args)
+		}
+	}
+	if !strings.Contains(methods, "func (bai *BotAPIInterface) SetMessageReaction(args *objs.SetMessageReactionArgs) (*objs.Result[bool], error)") ||
+		!strings.Contains(methods, "cp.ChatId = bai.migrateChatId(cp.ChatId)") {
+		t.Errorf("unexpected method:\n%s", methods)
+	}
+}
+
+/*Replaces the alignment of gofmt with single spaces.*/
+func compact(src []byte) string {
+	return strings.Join(strings.Fields(string(src)), " ")
+}
+
+func TestGenerateSubtypes(t *testing.T) {
+	spec := &Spec{Types: map[string]*SpecType{
+		"BackgroundType": {Name: "BackgroundType", Subtypes: []string{"BackgroundTypeFill", "BackgroundTypeWallpaper"}},
+		"BackgroundTypeFill": {Name: "BackgroundTypeFill", SubtypeOf: []string{"BackgroundType"}, Fields: []SpecField{
+			{Name: "type", Types: []string{"String"}, Required: true},
+		}},
+		"BackgroundTypeWallpaper": {Name: "BackgroundTypeWallpaper", SubtypeOf: []string{"BackgroundType"}, Fields: []SpecField{
+			{Name: "type", Types: []string{"String"}, Required: true},
+		}},
+		"ChatBackground": {Name: "ChatBackground", Fields: []SpecField{{Name: "fill", Types: []string{"BackgroundTypeFill"}}}},
+		"ChatMemberMember": {Name: "ChatMemberMember", SubtypeOf: []string{"ChatMember"}, Fields: []SpecField{
+			{Name: "status", Types: []string{"String"}, Required: true},
+		}},
+	}}
+	out, err := Generate(spec, &Declared{Types: map[string]bool{"ChatMember": true}}, "test.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := compact(out.Types)
+	if !strings.Contains(types, "type BackgroundTypeFill struct") {
+		t.Error("a subtype which is referenced by a field should be generated")
+	}
+	if strings.Contains(types, "type BackgroundTypeWallpaper struct") {
+		t.Error("a subtype which is not referenced should not be generated")
+	}
+	if !strings.Contains(types, "type ChatMemberMember struct") {
+		t.Error("the subtypes of a hand-written type should be generated")
+	}
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	spec := &Spec{Types: map[string]*SpecType{
+		"Story": {Name: "Story", Fields: []SpecField{{Name: "chat", Types: []string{"Chat"}, Required: true}}},
+	}}
+	_, err := Generate(spec, &Declared{}, "test.json")
+	if err == nil || !strings.Contains(err.Error(), "unknown type Chat") {
+		t.Fatalf("expected unknown type error, got %v", err)
+	}
+}
+
+/*The generated files should be regenerated with "go generate ./..." after changing the specification or the hand-written declarations.*/
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	spec, err := LoadSpec("../../api/botapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := &Declared{}
+	if declared.Types, err = declaredNames("../../objects", ""); err != nil {
+		t.Fatal(err)
+	}
+	if declared.Methods, err = declaredNames("../../tba", "BotAPIInterface"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := Generate(spec, declared, "botapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, src := range map[string][]byte{
+		"../../objects/generated_types.go": out.Types,
+		"../../objects/generated_args.go":  out.Args,
+		"../../tba/generated_methods.go":   out.Methods,
+	} {
+		current, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(current, src) {
+			t.Errorf("%s is out of date, run go generate ./...", path)
+		}
+	}
+}
@@ -5,8 +5,7 @@ import objs "github.com/hamidteimouri/telego/objects"
 // Invoice is an invoice that can be modified and sent to the user.
 type Invoice struct {
 	bot                                                                                                                                             *Bot
-	chatIdInt                                                                                                                                       int
-	chatIdString                                                                                                                                    string
+	chatId                                                                                                                                          objs.ChatID
 	replyMarkup                                                                                                                                     objs.InlineKeyboardMarkup
 	prices                                                                                                                                          []objs.LabeledPrice
 	suggestedTipAmounts                                                                                                                             []int
@@ -33,12 +32,12 @@ Official telegram doc :
 
 Use this method to send invoices. On success, the sent Message is returned.
 */
-func (is *Invoice) Send(replyTo, messageThreadId int, silent bool) (*objs.Result[*objs.Message], error) {
+func (is *Invoice) Send(replyTo, messageThreadId int64, silent bool) (*objs.Result[*objs.Message], error) {
 	return is.bot.apiInterface.SendInvoice(
-		is.chatIdInt, is.chatIdString, is.title, is.description, is.payload, is.providerToken,
+		is.chatId, is.title, is.description, is.payload, is.providerToken,
 		is.currency, is.prices, is.maxTipAmount, is.suggestedTipAmounts, is.startParameter, is.providerData,
 		is.photoURL, is.photoSize, is.photoWidth, is.photoHeight, is.needName, is.needPhoneNumber, is.needEmail, is.needShippingAddress,
-		is.sendPhoneNumberToProvider, is.sendEmailToProvider, is.isFlexible, silent, replyTo, messageThreadId, is.allowSendingWithoutReply, is.replyMarkup,
+		is.sendPhoneNumberToProvider, is.sendEmailToProvider, is.isFlexible, silent, replyTo, messageThreadId, is.allowSendingWithoutReply, false, is.replyMarkup,
 	)
 }
 
@@ -309,7 +309,7 @@ func (in *InlineKeyboard) AddPayButton(text string) {
 		WebApp:                       nil,
 	}
 	if len(in.keys) == 0 {
-		in.keys[0] = make([]*objs.InlineKeyboardButton, 0)
+		in.keys = append(in.keys, make([]*objs.InlineKeyboardButton, 0))
 	}
 
 	in.keys[0] = append([]*objs.InlineKeyboardButton{btn}, in.keys[0]...)
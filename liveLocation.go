@@ -10,10 +10,9 @@ import (
 // LiveLocation is a live location that can be sent to a user.
 type LiveLocation struct {
 	bot                                       *Bot
-	chatIdInt                                 int
-	chatIdString                              string
-	messageId                                 int
-	replyTo, messageThreadId                  int
+	chatId                                    objs.ChatID
+	messageId                                 int64
+	replyTo, messageThreadId                  int64
 	allowSendingWihoutReply                   bool
 	replyMarkUp                               objs.ReplyMarkup
 	latitude, longitude, horizontalAccuracy   float32
@@ -21,7 +20,7 @@ type LiveLocation struct {
 }
 
 /*
-Send sends this live location to all types of chats but channels. To send it to a channel use "SendToChannelMethod".
+Send sends this live location to the given chat or channel.
 
 If "silent" argument is true, the message will be sent without notification.
 
@@ -33,36 +32,10 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.
 */
-func (ll *LiveLocation) Send(chatId int, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	ll.chatIdInt = chatId
+func (ll *LiveLocation) Send(chatId objs.ChatID, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+	ll.chatId = chatId
 	res, err := ll.bot.apiInterface.SendLocation(
-		chatId, "", ll.latitude, ll.longitude, ll.horizontalAccuracy, ll.livePeriod,
-		ll.heading, ll.proximityAlertRadius, ll.replyTo, ll.messageThreadId, silent, ll.allowSendingWihoutReply, protectContent,
-		ll.replyMarkUp,
-	)
-	if err == nil {
-		ll.messageId = res.Result.MessageId
-	}
-	return res, err
-}
-
-/*
-SendToChannel sends this live location to a channel. Chat id should be the username of the channel.
-
-If "silent" argument is true, the message will be sent without notification.
-
-If "protectContent" argument is true, the message can't be forwarded or saved.
-
-------------------------
-
-Official telegram doc :
-
-Use this method to send point on the map. On success, the sent Message is returned.
-*/
-func (ll *LiveLocation) SendToChannel(chatId string, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	ll.chatIdString = chatId
-	res, err := ll.bot.apiInterface.SendLocation(
-		0, chatId, ll.latitude, ll.longitude, ll.horizontalAccuracy, ll.livePeriod,
+		chatId, ll.latitude, ll.longitude, ll.horizontalAccuracy, ll.livePeriod,
 		ll.heading, ll.proximityAlertRadius, ll.replyTo, ll.messageThreadId, silent, ll.allowSendingWihoutReply, protectContent,
 		ll.replyMarkUp,
 	)
@@ -90,7 +63,7 @@ func (ll *LiveLocation) Edit(latitude, langitude, horizontalAccuracy float32, he
 		ll.proximityAlertRadius = proximtyAlertRadius
 		ll.replyMarkUp = replyMarkUp
 		return ll.bot.apiInterface.EditMessageLiveLocation(
-			ll.chatIdInt, ll.chatIdString, "", ll.messageId, ll.latitude, ll.longitude,
+			ll.chatId, "", ll.messageId, ll.latitude, ll.longitude,
 			ll.horizontalAccuracy, ll.heading, ll.proximityAlertRadius, replyMarkUp,
 		)
 	}
@@ -109,7 +82,7 @@ Use this method to stop updating a live location message before live_period expi
 func (ll *LiveLocation) Stop(replyMarkrup objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
 	if ll.messageId != 0 {
 		return ll.bot.apiInterface.StopMessageLiveLocation(
-			ll.chatIdInt, ll.chatIdString, "", ll.messageId, &replyMarkrup,
+			ll.chatId, "", ll.messageId, &replyMarkrup,
 		)
 	} else {
 		return nil, &errs.LiveLocationNotStarted{}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Level is the severity of a log entry. The values are the same as log/slog levels.
+type Level int
+
+const (
+	LevelDebug Level = Level(slog.LevelDebug)
+	LevelInfo  Level = Level(slog.LevelInfo)
+	LevelWarn  Level = Level(slog.LevelWarn)
+	LevelError Level = Level(slog.LevelError)
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch {
+	case l < LevelInfo:
+		return "DEBUG"
+	case l < LevelWarn:
+		return "INFO"
+	case l < LevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+func (l Level) color() string {
+	switch {
+	case l < LevelInfo:
+		return OKBLUE
+	case l < LevelWarn:
+		return OKGREEN
+	case l < LevelError:
+		return WARNING
+	default:
+		return BOLD + FAIL
+	}
+}
+
+// ParseLevel converts the name of a level ("debug", "info", "warn" or "error") to Level. Unknown names are treated as "info".
+func ParseLevel(name string) Level {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug
+	case "warn", "warning":
+		return LevelWarn
+	case "error":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
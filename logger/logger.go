@@ -1,24 +1,40 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	cfg "github.com/hamidteimouri/telego/configs"
 )
 
+// Logger is the interface used by the bot for logging. Implement it to send the bot logs to your own logger.
+type Logger interface {
+	// Debug logs a message that is only useful for debugging.
+	Debug(msg string, fields ...Field)
+	// Info logs a message about a normal event, like a sent request or a received update.
+	Info(msg string, fields ...Field)
+	// Warn logs a message about an event that might need attention.
+	Warn(msg string, fields ...Field)
+	// Error logs a message about a failure.
+	Error(msg string, fields ...Field)
+}
+
+// BotLogger is the default logger of the bot. It writes the logs as (optionally colored) text lines.
 type BotLogger struct {
-	// logger is the default logger of the bot.
+	// logger is the internal logger of the bot.
 	logger    *log.Logger
+	mu        sync.RWMutex
+	level     Level
 	colorized bool
+	// closer is the log file opened by InitTheLogger.
+	closer io.Closer
 }
 
-// Logger is the default logger of the bot.
-// var Logger *log.Logger
-
-// colorized indicates if logs should be colored, default is true.
-var colorized = true
-
 const (
 	HEADER    string = "\033[95m"
 	OKBLUE    string = "\033[94m"
@@ -31,35 +47,95 @@ const (
 	UNDERLINE string = "\033[4m"
 )
 
-// InitiTheLogger initializes the default logger of the bot.
-func InitTheLogger(botCfg *cfg.BotConfigs) *BotLogger {
-	logger := &BotLogger{
-		colorized: true,
-	}
-	var file *os.File
-	if botCfg.LogFileAddress == "STDOUT" {
-		file = os.Stdout
-	} else {
-		var err error
-		file, err = os.OpenFile(botCfg.LogFileAddress, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+/*
+InitTheLogger initializes the default logger of the bot based on the bot configs.
+Logs are written in the file specified in "LogFileAddress" field of the configs ("STDOUT" for standard output), with the level specified in "LogLevel" field and in the format specified in "LogFormat" field.
+The file is rotated according to "LogRotation" field. An error is returned if the file can not be opened.
+*/
+func InitTheLogger(botCfg *cfg.BotConfigs) (Logger, error) {
+	var wr io.Writer = os.Stdout
+	var closer io.Closer
+	if botCfg.LogFileAddress != "" && botCfg.LogFileAddress != cfg.DefaultLogFile {
+		rf, err := NewRotatingFile(botCfg.LogFileAddress, botCfg.LogRotation)
 		if err != nil {
-			panic("Could not init the logger. Reason : " + err.Error())
+			return nil, err
 		}
+		wr, closer = rf, rf
 	}
-	logger.logger = log.New(file, botCfg.BotName+" | ", log.Ldate|log.Ltime)
-	return logger
+	level := ParseLevel(botCfg.LogLevel)
+	if botCfg.LogFormat == cfg.JSONLogFormat {
+		l := NewJSONLogger(wr, level).With(String("bot", botCfg.BotName))
+		l.closer = closer
+		return l, nil
+	}
+	l := NewTextLogger(wr, botCfg.BotName, level)
+	l.closer = closer
+	return l, nil
+}
+
+/*NewTextLogger returns a logger that writes the logs with the given level or higher as text lines into the given writer. Logs are colored by default.*/
+func NewTextLogger(wr io.Writer, botName string, level Level) *BotLogger {
+	return &BotLogger{
+		logger:    log.New(wr, botName+" | ", log.Ldate|log.Ltime),
+		level:     level,
+		colorized: true,
+	}
+}
+
+// Debug logs a message that is only useful for debugging.
+func (l *BotLogger) Debug(msg string, fields ...Field) {
+	l.log(LevelDebug, msg, fields)
 }
 
-// Log logs the given paramteres based on the defined format.
-func (l *BotLogger) Log(header, space, content, after, headerColor, contentColor, afterColor string) {
+// Info logs a message about a normal event.
+func (l *BotLogger) Info(msg string, fields ...Field) {
+	l.log(LevelInfo, msg, fields)
+}
+
+// Warn logs a message about an event that might need attention.
+func (l *BotLogger) Warn(msg string, fields ...Field) {
+	l.log(LevelWarn, msg, fields)
+}
+
+// Error logs a message about a failure.
+func (l *BotLogger) Error(msg string, fields ...Field) {
+	l.log(LevelError, msg, fields)
+}
+
+func (l *BotLogger) log(level Level, msg string, fields []Field) {
+	l.mu.RLock()
+	minLevel, colorized := l.level, l.colorized
+	l.mu.RUnlock()
+	if level < minLevel {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("| ")
 	if colorized {
-		text := "| " + headerColor + header + ENDC + space + contentColor + content + ENDC + " |" + afterColor + after + ENDC
-		l.logger.Println(text)
+		sb.WriteString(level.color() + fmt.Sprintf("%-5s", level.String()) + ENDC)
 	} else {
-		text := "| " + header + space + content + "|" + after
-		l.logger.Println(text)
+		sb.WriteString(fmt.Sprintf("%-5s", level.String()))
 	}
+	sb.WriteString(" | ")
+	sb.WriteString(msg)
+	for _, f := range fields {
+		sb.WriteString(" ")
+		if colorized {
+			sb.WriteString(OKCYAN + f.Key + ENDC)
+		} else {
+			sb.WriteString(f.Key)
+		}
+		sb.WriteString("=")
+		sb.WriteString(f.valueString())
+	}
+	l.logger.Println(sb.String())
+}
 
+// SetLevel changes the minimum level of the logs that are written.
+func (l *BotLogger) SetLevel(level Level) {
+	l.mu.Lock()
+	l.level = level
+	l.mu.Unlock()
 }
 
 // GetRaw returns the internal logger
@@ -69,10 +145,94 @@ func (l *BotLogger) GetRaw() *log.Logger {
 
 // Uncolor, clears the colors of the logs.
 func (l *BotLogger) Uncolor() {
-	colorized = false
+	l.mu.Lock()
+	l.colorized = false
+	l.mu.Unlock()
 }
 
 // Color adds color to the logs.
 func (l *BotLogger) Color() {
-	colorized = true
+	l.mu.Lock()
+	l.colorized = true
+	l.mu.Unlock()
+}
+
+// Close closes the log file opened by InitTheLogger. It does nothing if the logs are written into the standard output or the logger was created by NewTextLogger.
+func (l *BotLogger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Nop returns a logger that discards all the logs.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...Field) {}
+func (nopLogger) Info(string, ...Field)  {}
+func (nopLogger) Warn(string, ...Field)  {}
+func (nopLogger) Error(string, ...Field) {}
+
+// Field is a key/value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value any
+}
+
+func (f Field) valueString() string {
+	switch v := f.Value.(type) {
+	case string:
+		if strings.ContainsAny(v, " \t\"=") {
+			return fmt.Sprintf("%q", v)
+		}
+		return v
+	case error:
+		return fmt.Sprintf("%q", v.Error())
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Any returns a field with the given key and value.
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String returns a field with the given key and string value.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Method returns a field containing the name of a Bot API method.
+func Method(name string) Field {
+	return Field{Key: "method", Value: name}
+}
+
+// ChatId returns a field containing a chat id. Both int and string (username) chat ids are accepted.
+func ChatId(chatId any) Field {
+	return Field{Key: "chat_id", Value: chatId}
+}
+
+// UpdateId returns a field containing the id of an update.
+func UpdateId(updateId int) Field {
+	return Field{Key: "update_id", Value: updateId}
+}
+
+// UpdateType returns a field containing the type of an update.
+func UpdateType(updateType string) Field {
+	return Field{Key: "update_type", Value: updateType}
+}
+
+// Latency returns a field containing the latency of an operation.
+func Latency(d time.Duration) Field {
+	return Field{Key: "latency", Value: d}
+}
+
+// Err returns a field containing the given error.
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
 }
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{"debug", LevelDebug}, {"DEBUG", LevelDebug}, {"info", LevelInfo}, {"warn", LevelWarn},
+		{"Warning", LevelWarn}, {"error", LevelError}, {"", LevelInfo}, {"verbose", LevelInfo},
+	}
+	for _, tt := range tests {
+		if got := ParseLevel(tt.name); got != tt.want {
+			t.Errorf("ParseLevel(%q) : expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestTextLoggerLevels(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  []string
+	}{
+		{LevelDebug, []string{"DEBUG", "INFO", "WARN", "ERROR"}},
+		{LevelInfo, []string{"INFO", "WARN", "ERROR"}},
+		{LevelWarn, []string{"WARN", "ERROR"}},
+		{LevelError, []string{"ERROR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewTextLogger(&buf, "bot", tt.level)
+			l.Uncolor()
+			l.Debug("debug message")
+			l.Info("info message")
+			l.Warn("warn message")
+			l.Error("error message")
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("expected %d lines, got %q", len(tt.want), buf.String())
+			}
+			for i, level := range tt.want {
+				want := "| " + level + strings.Repeat(" ", 5-len(level)) + " | " + strings.ToLower(level) + " message"
+				if !strings.HasPrefix(lines[i], "bot | ") || !strings.HasSuffix(lines[i], want) {
+					t.Errorf("expected a line ending with %q, got %q", want, lines[i])
+				}
+			}
+		})
+	}
+	var buf bytes.Buffer
+	l := NewTextLogger(&buf, "bot", LevelError)
+	l.SetLevel(LevelInfo)
+	l.Info("shown")
+	if !strings.Contains(buf.String(), "shown") {
+		t.Error("the level should be changed by SetLevel")
+	}
+}
+
+func TestTextLoggerFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  string
+	}{
+		{"string", String("name", "value"), "name=value"},
+		{"quoted string", String("text", `say "hi" now`), `text="say \"hi\" now"`},
+		{"string with equal sign", String("query", "a=b"), `query="a=b"`},
+		{"int chat id", ChatId(int64(-100123)), "chat_id=-100123"},
+		{"username chat id", ChatId("@channel"), "chat_id=@channel"},
+		{"method", Method("sendMessage"), "method=sendMessage"},
+		{"update id", UpdateId(42), "update_id=42"},
+		{"update type", UpdateType("message"), "update_type=message"},
+		{"latency", Latency(1500 * time.Millisecond), "latency=1.5s"},
+		{"error", Err(errors.New("request failed")), `error="request failed"`},
+		{"any", Any("ids", []int{1, 2}), "ids=[1 2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewTextLogger(&buf, "bot", LevelDebug)
+			l.Uncolor()
+			l.Info("msg", tt.field)
+			if got := strings.TrimSuffix(buf.String(), "\n"); !strings.HasSuffix(got, "| msg "+tt.want) {
+				t.Errorf("expected the line to end with %q, got %q", "msg "+tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTextLoggerColors(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewTextLogger(&buf, "bot", LevelDebug)
+	l.Error("failed", String("key", "value"))
+	want := "| " + BOLD + FAIL + "ERROR" + ENDC + " | failed " + OKCYAN + "key" + ENDC + "=value\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("expected a colored line ending with %q, got %q", want, buf.String())
+	}
+	buf.Reset()
+	l.Uncolor()
+	l.Error("failed")
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("the line should not be colored, got %q", buf.String())
+	}
+	buf.Reset()
+	l.Color()
+	l.Info("ok")
+	if !strings.Contains(buf.String(), OKGREEN+"INFO ") {
+		t.Errorf("the line should be colored again, got %q", buf.String())
+	}
+}
+
+func TestNop(t *testing.T) {
+	l := Nop()
+	l.Debug("msg")
+	l.Info("msg", String("key", "value"))
+	l.Warn("msg")
+	l.Error("msg", Err(errors.New("failed")))
+}
@@ -0,0 +1,246 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+const backupTimeFormat = "20060102T150405.000"
+
+/*Renames the file when it is rotated. Replaced in the tests.*/
+var rename = os.Rename
+
+/*
+RotatingFile is an io.WriteCloser that writes into a file and rotates it based on its size and age.
+Rotated files are renamed to "<name>-<time><ext>" in the directory of the file, optionally compressed and removed when they exceed the retention limits.
+*/
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rc       cfg.LogRotationConfigs
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	//Guards the compression and clean up of the rotated files which are run in the background.
+	postMu sync.Mutex
+	wg     sync.WaitGroup
+}
+
+/*NewRotatingFile opens (or creates) the file in the given path for appending and rotates it according to the given configs. If rc is nil the file is never rotated.*/
+func NewRotatingFile(path string, rc *cfg.LogRotationConfigs) (*RotatingFile, error) {
+	rf := &RotatingFile{path: path}
+	if rc != nil {
+		rf.rc = *rc
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *RotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	st, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	rf.file = file
+	rf.size = st.Size()
+	rf.openedAt = time.Now()
+	return nil
+}
+
+/*
+Write writes p into the file. The file is rotated before writing if p does not fit in it or it is older than the rotation interval.
+If the rotation fails, p is still written into the current file and the error of the rotation is returned.
+*/
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.file == nil {
+		return 0, os.ErrClosed
+	}
+	var rotateErr error
+	if rf.shouldRotate(int64(len(p))) {
+		rotateErr = rf.rotate()
+		if rf.file == nil {
+			return 0, rotateErr
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+func (rf *RotatingFile) shouldRotate(next int64) bool {
+	if rf.rc.MaxSize > 0 && rf.size > 0 && rf.size+next > int64(rf.rc.MaxSize)*1024*1024 {
+		return true
+	}
+	return rf.rc.Interval > 0 && time.Since(rf.openedAt) >= rf.rc.Interval
+}
+
+// Rotate closes the current file, renames it and opens a new file in its place.
+func (rf *RotatingFile) Rotate() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.file == nil {
+		return os.ErrClosed
+	}
+	return rf.rotate()
+}
+
+/*Rotates the file. If the file can't be closed or renamed, it is opened again so the logs are still written into it.*/
+func (rf *RotatingFile) rotate() error {
+	err := rf.file.Close()
+	rf.file = nil
+	if err == nil {
+		backup := rf.backupName(time.Now())
+		if err = rename(rf.path, backup); err == nil {
+			rf.wg.Add(1)
+			go rf.postRotate(backup)
+			return rf.open()
+		}
+	}
+	if err2 := rf.open(); err2 != nil {
+		return errors.Join(err, err2)
+	}
+	return err
+}
+
+/*Returns the name of a backup file which does not exist yet.*/
+func (rf *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(rf.path)
+	prefix := strings.TrimSuffix(rf.path, ext) + "-" + t.Format(backupTimeFormat)
+	name := prefix + ext
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = prefix + "-" + strconv.Itoa(i) + ext
+	}
+	return name
+}
+
+/*Compresses the rotated file and removes the old ones.*/
+func (rf *RotatingFile) postRotate(backup string) {
+	defer rf.wg.Done()
+	rf.postMu.Lock()
+	defer rf.postMu.Unlock()
+	if rf.rc.Compress {
+		//If compression fails the uncompressed file is kept.
+		_ = compressFile(backup)
+	}
+	rf.removeOldBackups()
+}
+
+func (rf *RotatingFile) removeOldBackups() {
+	if rf.rc.MaxBackups <= 0 && rf.rc.MaxAge <= 0 {
+		return
+	}
+	backups := rf.backups()
+	cutoff := time.Now().Add(-rf.rc.MaxAge)
+	for i, b := range backups {
+		if (rf.rc.MaxBackups > 0 && i >= rf.rc.MaxBackups) || (rf.rc.MaxAge > 0 && b.t.Before(cutoff)) {
+			os.Remove(b.name)
+		}
+	}
+}
+
+type backupFile struct {
+	name string
+	t    time.Time
+}
+
+/*Returns the rotated files of this file, newest first.*/
+func (rf *RotatingFile) backups() []backupFile {
+	ext := filepath.Ext(rf.path)
+	dir := filepath.Dir(rf.path)
+	prefix := strings.TrimSuffix(filepath.Base(rf.path), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []backupFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix)
+		stamp = strings.TrimSuffix(stamp, ".gz")
+		stamp = strings.TrimSuffix(stamp, ext)
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp[:len(backupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+		out = append(out, backupFile{name: filepath.Join(dir, name), t: t})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].t.Equal(out[j].t) {
+			return out[i].name > out[j].name
+		}
+		return out[i].t.After(out[j].t)
+	})
+	return out
+}
+
+// Close closes the file and waits for the background compression and clean up to finish.
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	var err error
+	if rf.file != nil {
+		err = rf.file.Close()
+		rf.file = nil
+	}
+	rf.mu.Unlock()
+	rf.wg.Wait()
+	return err
+}
+
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err2 := gz.Close(); err == nil {
+		err = err2
+	}
+	if err2 := dst.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		os.Remove(name + ".gz")
+		return err
+	}
+	src.Close()
+	return os.Remove(name)
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
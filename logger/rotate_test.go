@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bot.log")
+	rf, err := NewRotatingFile(path, &cfg.LogRotationConfigs{MaxSize: 1, MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := []byte(strings.Repeat("a", 1023) + "\n")
+	//Each megabyte is 1024 lines, so 4 rotations happen.
+	for i := 0; i < 4*1024+10; i++ {
+		if _, err := rf.Write(line); err != nil {
+			t.Fatal(err)
+		}
+		if i%1024 == 1023 {
+			//Wait for the background clean up so the backups of each rotation have distinct names.
+			rf.wg.Wait()
+		}
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatal(err)
+	}
+	backups := rf.backups()
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b.name, ".log.gz") {
+			t.Fatalf("backup %s is not compressed", b.name)
+		}
+		f, err := os.Open(b.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(gz)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != 1024*1024 {
+			t.Fatalf("expected 1MB in %s, got %d bytes", b.name, len(data))
+		}
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Size() != 10*1024 {
+		t.Fatalf("expected 10KB in the current file, got %d bytes", st.Size())
+	}
+}
+
+func TestInitTheLoggerError(t *testing.T) {
+	bc := cfg.Default("key")
+	bc.LogFileAddress = filepath.Join(t.TempDir(), "missing", "bot.log")
+	l, err := InitTheLogger(bc)
+	if err == nil || l != nil {
+		t.Fatal("expected an error for a log file in a missing directory")
+	}
+}
+
+func TestRotatingFileRenameError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.log")
+	rf, err := NewRotatingFile(path, &cfg.LogRotationConfigs{MaxSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+	renameErr := errors.New("file is locked")
+	rename = func(string, string) error { return renameErr }
+	defer func() { rename = os.Rename }()
+	if err := rf.Rotate(); !errors.Is(err, renameErr) {
+		t.Fatalf("expected the rename error, got %v", err)
+	}
+	if _, err := rf.Write([]byte("after rotate\n")); err != nil {
+		t.Fatalf("the file should be opened again after a failed rotation, got %v", err)
+	}
+	big := []byte(strings.Repeat("a", 1024*1024) + "\n")
+	if n, err := rf.Write(big); !errors.Is(err, renameErr) || n != len(big) {
+		t.Fatalf("the line should be written and the rotation error returned, got %d and %v", n, err)
+	}
+	rename = os.Rename
+	if _, err := rf.Write([]byte("rotated\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "rotated\n" {
+		t.Errorf("expected a new file after the rotation succeeds, got %d bytes", len(data))
+	}
+	backups := rf.backups()
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %d", len(backups))
+	}
+	data, _ := os.ReadFile(backups[0].name)
+	if want := "after rotate\n" + string(big); string(data) != want {
+		t.Errorf("the lines written after the failed rotations should be kept, got %d bytes", len(data))
+	}
+}
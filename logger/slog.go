@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// SlogLogger is a Logger that writes the logs into a log/slog logger.
+type SlogLogger struct {
+	logger *slog.Logger
+	// closer is the log file opened by InitTheLogger.
+	closer io.Closer
+}
+
+// NewSlogLogger returns a Logger that writes the bot logs into the given slog logger, so the bot logs can join the logs of your service.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: l}
+}
+
+// NewJSONLogger returns a Logger that writes the logs with the given level or higher as JSON objects (one per line) into the given writer.
+func NewJSONLogger(wr io.Writer, level Level) *SlogLogger {
+	return NewSlogLogger(slog.New(slog.NewJSONHandler(wr, &slog.HandlerOptions{Level: slog.Level(level)})))
+}
+
+// With returns a logger that adds the given fields to all the logs.
+func (l *SlogLogger) With(fields ...Field) *SlogLogger {
+	return &SlogLogger{logger: l.logger.With(toAttrs(fields)...), closer: l.closer}
+}
+
+// Close closes the log file opened by InitTheLogger. It does nothing if the logs are written into the standard output or the logger was created by another function.
+func (l *SlogLogger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Debug logs a message that is only useful for debugging.
+func (l *SlogLogger) Debug(msg string, fields ...Field) {
+	l.log(LevelDebug, msg, fields)
+}
+
+// Info logs a message about a normal event.
+func (l *SlogLogger) Info(msg string, fields ...Field) {
+	l.log(LevelInfo, msg, fields)
+}
+
+// Warn logs a message about an event that might need attention.
+func (l *SlogLogger) Warn(msg string, fields ...Field) {
+	l.log(LevelWarn, msg, fields)
+}
+
+// Error logs a message about a failure.
+func (l *SlogLogger) Error(msg string, fields ...Field) {
+	l.log(LevelError, msg, fields)
+}
+
+func (l *SlogLogger) log(level Level, msg string, fields []Field) {
+	l.logger.Log(context.Background(), slog.Level(level), msg, toAttrs(fields)...)
+}
+
+func toAttrs(fields []Field) []any {
+	attrs := make([]any, 0, len(fields))
+	for _, f := range fields {
+		if err, ok := f.Value.(error); ok {
+			attrs = append(attrs, slog.String(f.Key, err.Error()))
+			continue
+		}
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	return attrs
+}
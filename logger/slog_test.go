@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+/*Decodes the JSON lines written into the buffer.*/
+func jsonLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid json line %q : %v", line, err)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestJSONLoggerLevels(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  []string
+	}{
+		{LevelDebug, []string{"DEBUG", "INFO", "WARN", "ERROR"}},
+		{LevelInfo, []string{"INFO", "WARN", "ERROR"}},
+		{LevelWarn, []string{"WARN", "ERROR"}},
+		{LevelError, []string{"ERROR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewJSONLogger(&buf, tt.level)
+			l.Debug("debug message")
+			l.Info("info message")
+			l.Warn("warn message")
+			l.Error("error message")
+			entries := jsonLines(t, &buf)
+			if len(entries) != len(tt.want) {
+				t.Fatalf("expected %d entries, got %q", len(tt.want), buf.String())
+			}
+			for i, level := range tt.want {
+				if entries[i]["level"] != level || entries[i]["msg"] != strings.ToLower(level)+" message" {
+					t.Errorf("unexpected entry %v", entries[i])
+				}
+				if _, ok := entries[i]["time"]; !ok {
+					t.Errorf("the entry should have a time, got %v", entries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJSONLoggerFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		key   string
+		want  any
+	}{
+		{"string", String("name", `say "hi"`), "name", `say "hi"`},
+		{"int chat id", ChatId(int64(-100123)), "chat_id", float64(-100123)},
+		{"username chat id", ChatId("@channel"), "chat_id", "@channel"},
+		{"method", Method("sendMessage"), "method", "sendMessage"},
+		{"update id", UpdateId(42), "update_id", float64(42)},
+		{"update type", UpdateType("message"), "update_type", "message"},
+		{"latency", Latency(1500 * time.Millisecond), "latency", float64(1500 * time.Millisecond)},
+		{"error", Err(errors.New("request failed")), "error", "request failed"},
+		{"bool", Any("ok", true), "ok", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			NewJSONLogger(&buf, LevelDebug).Info("msg", tt.field)
+			entries := jsonLines(t, &buf)
+			if len(entries) != 1 {
+				t.Fatalf("expected 1 entry, got %q", buf.String())
+			}
+			if got := entries[0][tt.key]; got != tt.want {
+				t.Errorf("expected %s=%v, got %v (%T)", tt.key, tt.want, got, got)
+			}
+		})
+	}
+}
+
+func TestJSONLoggerWith(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewJSONLogger(&buf, LevelInfo)
+	l := base.With(String("bot", "test-bot"))
+	l.Warn("with the field", Method("getMe"))
+	base.Warn("without the field")
+	entries := jsonLines(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %q", buf.String())
+	}
+	if entries[0]["bot"] != "test-bot" || entries[0]["method"] != "getMe" {
+		t.Errorf("the fields of With should be added, got %v", entries[0])
+	}
+	if _, ok := entries[1]["bot"]; ok {
+		t.Errorf("the original logger should not be changed, got %v", entries[1])
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	l := NewSlogLogger(slog.New(handler).With("service", "api"))
+	l.Info("filtered")
+	l.Error("failed", ChatId(int64(5)), Err(errors.New("boom")))
+	got := buf.String()
+	if strings.Contains(got, "filtered") {
+		t.Errorf("the level of the slog handler should be respected, got %q", got)
+	}
+	for _, want := range []string{"level=ERROR", "msg=failed", "service=api", "chat_id=5", "error=boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+}
+
+func TestInitTheLoggerJSON(t *testing.T) {
+	bc := cfg.Default("key")
+	bc.BotName = "test-bot"
+	bc.LogFormat = cfg.JSONLogFormat
+	bc.LogLevel = "warn"
+	bc.LogFileAddress = filepath.Join(t.TempDir(), "bot.log")
+	l, err := InitTheLogger(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("filtered")
+	l.Warn("written", Method("getMe"))
+	if _, ok := l.(*SlogLogger); !ok {
+		t.Fatalf("expected a json logger, got %T", l)
+	}
+	if err := l.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.(io.Closer).Close(); err != nil {
+		t.Fatalf("closing the file again should not fail, got %v", err)
+	}
+	data, err := os.ReadFile(bc.LogFileAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := jsonLines(t, bytes.NewBuffer(data))
+	if len(entries) != 1 || entries[0]["msg"] != "written" || entries[0]["bot"] != "test-bot" || entries[0]["level"] != "WARN" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
@@ -2,6 +2,7 @@ package telego
 
 import (
 	"errors"
+	"io"
 	"os"
 
 	errs "github.com/hamidteimouri/telego/errors"
@@ -11,11 +12,11 @@ import (
 // MediaGroup is a media group that can be sent
 type MediaGroup struct {
 	bot                      *Bot
-	replyTo, messageThreadId int
+	replyTo, messageThreadId int64
 	allowSendingWihoutReply  bool
 	replyMarkup              objs.ReplyMarkup
 	media                    []objs.InputMedia
-	files                    []*os.File
+	files                    []objs.NamedReader
 }
 
 // PhotoInserter is a tool for inserting photos into the MediaGroup.
@@ -37,17 +38,25 @@ func (pi *PhotoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (pi *PhotoInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
+	pi.addFile(file)
+	return nil
+}
+
+/*AddByReader adds the content of the reader as a file with the given name. The content is streamed to the API server when the media group is sent.*/
+func (pi *PhotoInserter) AddByReader(name string, reader io.Reader) {
+	pi.addFile(objs.NewNamedReader(name, reader))
+}
+
+func (pi *PhotoInserter) addFile(file objs.NamedReader) {
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", objs.AttachName(file), pi.caption, pi.parseMode, pi.captionEntities),
 		HasSpoiler:        pi.hasSpoiler,
 	}
 	pi.mg.media = append(pi.mg.media, im)
 	pi.mg.files = append(pi.mg.files, file)
-	return nil
 }
 
 // VideoInserter is a tool for inserting videos into the MediaGroup.
@@ -55,7 +64,7 @@ type VideoInserter struct {
 	mg                            *MediaGroup
 	caption, parseMode, thumb     string
 	captionEntities               []objs.MessageEntity
-	thumbFile                     *os.File
+	thumbFile                     objs.NamedReader
 	width, height, duration       int
 	supportsStreaming, hasSpoiler bool
 }
@@ -85,12 +94,21 @@ func (vi *VideoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (vi *VideoInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
+	vi.addFile(file)
+	return nil
+}
+
+/*AddByReader adds the content of the reader as a file with the given name. The content is streamed to the API server when the media group is sent.*/
+func (vi *VideoInserter) AddByReader(name string, reader io.Reader) {
+	vi.addFile(objs.NewNamedReader(name, reader))
+}
+
+func (vi *VideoInserter) addFile(file objs.NamedReader) {
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", objs.AttachName(file), vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 		HasSpoiler:        vi.hasSpoiler,
@@ -109,7 +127,6 @@ func (vi *VideoInserter) AddByFile(file *os.File) error {
 	if vi.thumbFile != nil {
 		vi.mg.files = append(vi.mg.files, vi.thumbFile)
 	}
-	return nil
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -119,21 +136,29 @@ func (vi *VideoInserter) SetThumbnail(fileIdOrURL string) {
 
 /*SetThumbnailFile sets the tumbnail of the file. It takes a file existing on the device*/
 func (vi *VideoInserter) SetThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	vi.thumbFile = file
-	vi.thumb = "attach://" + stat.Name()
+	vi.setThumbnailFile(file)
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file to the content of the reader with the given name.*/
+func (vi *VideoInserter) SetThumbnailReader(name string, reader io.Reader) {
+	vi.setThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (vi *VideoInserter) setThumbnailFile(file objs.NamedReader) {
+	vi.thumbFile = file
+	vi.thumb = objs.AttachName(file)
+}
+
 // AnimationInserter is a tool for inserting animations into the MediaGroup.
 type AnimationInserter struct {
 	mg                        *MediaGroup
 	caption, parseMode, thumb string
 	captionEntities           []objs.MessageEntity
-	thumbFile                 *os.File
+	thumbFile                 objs.NamedReader
 	width, height, duration   int
 	hasSpoiler                bool
 }
@@ -162,12 +187,21 @@ func (ai *AnimationInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (ai *AnimationInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
+	ai.addFile(file)
+	return nil
+}
+
+/*AddByReader adds the content of the reader as a file with the given name. The content is streamed to the API server when the media group is sent.*/
+func (ai *AnimationInserter) AddByReader(name string, reader io.Reader) {
+	ai.addFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AnimationInserter) addFile(file objs.NamedReader) {
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", objs.AttachName(file), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		HasSpoiler:        ai.hasSpoiler,
 	}
@@ -185,7 +219,6 @@ func (ai *AnimationInserter) AddByFile(file *os.File) error {
 	if ai.thumbFile != nil {
 		ai.mg.files = append(ai.mg.files, ai.thumbFile)
 	}
-	return nil
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -195,21 +228,29 @@ func (ai *AnimationInserter) SetThumbnail(fileIdOrURL string) {
 
 /*SetThumbnailFile sets the tumbnail of the file. It takes a file existing on the device*/
 func (ai *AnimationInserter) SetThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	ai.thumbFile = file
-	ai.thumb = "attach://" + stat.Name()
+	ai.setThumbnailFile(file)
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file to the content of the reader with the given name.*/
+func (ai *AnimationInserter) SetThumbnailReader(name string, reader io.Reader) {
+	ai.setThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AnimationInserter) setThumbnailFile(file objs.NamedReader) {
+	ai.thumbFile = file
+	ai.thumb = objs.AttachName(file)
+}
+
 // AudioInserter is a tool for inserting audios into the MediaGroup.
 type AudioInserter struct {
 	mg                                          *MediaGroup
 	caption, parseMode, thumb, performer, title string
 	captionEntities                             []objs.MessageEntity
-	thumbFile                                   *os.File
+	thumbFile                                   objs.NamedReader
 	duration                                    int
 }
 
@@ -232,12 +273,21 @@ func (ai *AudioInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (ai *AudioInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
+	ai.addFile(file)
+	return nil
+}
+
+/*AddByReader adds the content of the reader as a file with the given name. The content is streamed to the API server when the media group is sent.*/
+func (ai *AudioInserter) AddByReader(name string, reader io.Reader) {
+	ai.addFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AudioInserter) addFile(file objs.NamedReader) {
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", objs.AttachName(file), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -250,7 +300,6 @@ func (ai *AudioInserter) AddByFile(file *os.File) error {
 	if ai.thumbFile != nil {
 		ai.mg.files = append(ai.mg.files, ai.thumbFile)
 	}
-	return nil
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -260,21 +309,29 @@ func (ai *AudioInserter) SetThumbnail(fileIdOrURL string) {
 
 /*SetThumbnailFile sets the tumbnail of the file. It takes a file existing on the device*/
 func (ai *AudioInserter) SetThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	ai.thumbFile = file
-	ai.thumb = "attach://" + stat.Name()
+	ai.setThumbnailFile(file)
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file to the content of the reader with the given name.*/
+func (ai *AudioInserter) SetThumbnailReader(name string, reader io.Reader) {
+	ai.setThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AudioInserter) setThumbnailFile(file objs.NamedReader) {
+	ai.thumbFile = file
+	ai.thumb = objs.AttachName(file)
+}
+
 // DocumentInserter is a tool for inserting documents into the MediaGroup.
 type DocumentInserter struct {
 	mg                          *MediaGroup
 	caption, parseMode, thumb   string
 	captionEntities             []objs.MessageEntity
-	thumbFile                   *os.File
+	thumbFile                   objs.NamedReader
 	disableContentTypeDetection bool
 }
 
@@ -293,12 +350,21 @@ func (di *DocumentInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (di *DocumentInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
+	di.addFile(file)
+	return nil
+}
+
+/*AddByReader adds the content of the reader as a file with the given name. The content is streamed to the API server when the media group is sent.*/
+func (di *DocumentInserter) AddByReader(name string, reader io.Reader) {
+	di.addFile(objs.NewNamedReader(name, reader))
+}
+
+func (di *DocumentInserter) addFile(file objs.NamedReader) {
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", objs.AttachName(file), di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
@@ -307,7 +373,6 @@ func (di *DocumentInserter) AddByFile(file *os.File) error {
 	if di.thumbFile != nil {
 		di.mg.files = append(di.mg.files, di.thumbFile)
 	}
-	return nil
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -317,40 +382,25 @@ func (di *DocumentInserter) SetThumbnail(fileIdOrURL string) {
 
 /*SetThumbnailFile sets the tumbnail of the file. It takes a file existing on the device*/
 func (di *DocumentInserter) SetThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	di.thumbFile = file
-	di.thumb = "attach://" + stat.Name()
+	di.setThumbnailFile(file)
 	return nil
 }
 
-/*
-Send sends this album (to all types of chat but channels, to send to channels use "SendToChannel" method)
-
---------------------
-
-Official telegram doc :
-
-Use this method to send a group of photos, videos, documents or audios as an album. Documents and audio files can be only grouped in an album with messages of the same type. On success, an array of Messages that were sent is returned.
-
-If "silent" argument is true, the message will be sent without notification.
+/*SetThumbnailReader sets the tumbnail of the file to the content of the reader with the given name.*/
+func (di *DocumentInserter) SetThumbnailReader(name string, reader io.Reader) {
+	di.setThumbnailFile(objs.NewNamedReader(name, reader))
+}
 
-If "protectContent" argument is true, the message can't be forwarded or saved.
-*/
-func (mg *MediaGroup) Send(chatId int, silent, protectContent bool) (*objs.Result[[]objs.Message], error) {
-	if len(mg.media) < 2 {
-		return nil, errors.New("the number os medias should be greater than 1")
-	}
-	return mg.bot.apiInterface.SendMediaGroup(
-		chatId, "", mg.replyTo, mg.messageThreadId, mg.media, silent, mg.allowSendingWihoutReply, protectContent,
-		mg.replyMarkup, mg.files...,
-	)
+func (di *DocumentInserter) setThumbnailFile(file objs.NamedReader) {
+	di.thumbFile = file
+	di.thumb = objs.AttachName(file)
 }
 
 /*
-Send sends this album to a channel.
+Send sends this album to the given chat or channel.
 
 --------------------
 
@@ -362,12 +412,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (mg *MediaGroup) SendToChannel(chatId string, silent, protectContent bool) (*objs.Result[[]objs.Message], error) {
+func (mg *MediaGroup) Send(chatId objs.ChatID, silent, protectContent bool) (*objs.Result[[]objs.Message], error) {
 	if len(mg.media) < 2 {
 		return nil, errors.New("the number os medias should be greater than 1")
 	}
 	return mg.bot.apiInterface.SendMediaGroup(
-		0, chatId, mg.replyTo, mg.messageThreadId, mg.media, silent, mg.allowSendingWihoutReply, protectContent,
+		chatId, mg.replyTo, mg.messageThreadId, mg.media, silent, mg.allowSendingWihoutReply, protectContent,
 		mg.replyMarkup, mg.files...,
 	)
 }
@@ -2,6 +2,7 @@ package telego
 
 import (
 	"errors"
+	"io"
 	"os"
 
 	objs "github.com/hamidteimouri/telego/objects"
@@ -22,17 +23,17 @@ const (
 
 // MediaSender is a tool for sending media messages.
 type MediaSender struct {
-	bot                                                                 *Bot
-	chatIdInt                                                           int
-	mediaType                                                           MediaType
-	username, caption, parseMode, thumb, performer, title, stickerEmoji string
-	replyTo, messageThreadId                                            int
-	captionEntities                                                     []objs.MessageEntity
-	allowSendingWihoutReply, hasSpoiler                                 bool
-	replyMarkup                                                         objs.ReplyMarkup
-	duration, length, width, height                                     int
-	supportsStreaming, disableContentTypeDetection                      bool
-	thumbFile                                                           *os.File
+	bot                                                       *Bot
+	chatId                                                    objs.ChatID
+	mediaType                                                 MediaType
+	caption, parseMode, thumb, performer, title, stickerEmoji string
+	replyTo, messageThreadId                                  int64
+	captionEntities                                           []objs.MessageEntity
+	allowSendingWihoutReply, hasSpoiler                       bool
+	replyMarkup                                               objs.ReplyMarkup
+	duration, length, width, height                           int
+	supportsStreaming, disableContentTypeDetection            bool
+	thumbFile                                                 objs.NamedReader
 }
 
 /*SendByFileIdOrUrl sends a file that already exists on telegram servers (file id) or a url on the web.*/
@@ -40,47 +41,47 @@ func (ms *MediaSender) SendByFileIdOrUrl(fileIdOrUrl string, silent, protectCont
 	switch ms.mediaType {
 	case PHOTO:
 		return ms.bot.apiInterface.SendPhoto(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.replyMarkup, ms.captionEntities,
 		)
 	case VIDEO:
 		return ms.bot.apiInterface.SendVideo(
-			ms.chatIdInt, ms.username, fileIdOrUrl,
+			ms.chatId, fileIdOrUrl,
 			nil, ms.caption, ms.parseMode, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler,
 			ms.captionEntities, ms.duration, ms.supportsStreaming, ms.replyMarkup,
 		)
 	case AUDIO:
 		return ms.bot.apiInterface.SendAudio(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.captionEntities, ms.duration, ms.performer, ms.title, ms.replyMarkup,
 		)
 	case ANIMATION:
 		return ms.bot.apiInterface.SendAnimation(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.width, ms.height, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile,
 			silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.captionEntities, ms.replyMarkup,
 		)
 	case DOCUMENT:
 		return ms.bot.apiInterface.SendDocument(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities,
 			ms.disableContentTypeDetection, ms.replyMarkup,
 		)
 	case VIDEONOTE:
 		return ms.bot.apiInterface.SendVideoNote(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.length, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent,
 			ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case VOICE:
 		return ms.bot.apiInterface.SendVoice(
-			ms.chatIdInt, ms.username, fileIdOrUrl, nil, ms.caption, ms.parseMode,
+			ms.chatId, fileIdOrUrl, nil, ms.caption, ms.parseMode,
 			ms.duration, ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case STICKER:
 		return ms.bot.apiInterface.SendSticker(
-			ms.chatIdInt, ms.username, fileIdOrUrl, ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
+			ms.chatId, fileIdOrUrl, ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.replyTo, ms.messageThreadId, ms.replyMarkup, nil,
 		)
 	default:
@@ -91,54 +92,65 @@ func (ms *MediaSender) SendByFileIdOrUrl(fileIdOrUrl string, silent, protectCont
 
 /*SendByFile sends a file that is located in this device.*/
 func (ms *MediaSender) SendByFile(file *os.File, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return ms.sendFile(file, silent, protectContent)
+}
+
+/*
+SendByReader sends the content of the reader as a file with the given name.
+The content is streamed to the API server while it is being read, so it doesn't need to be stored in a temporary file or in memory.
+*/
+func (ms *MediaSender) SendByReader(name string, reader io.Reader, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+	return ms.sendFile(objs.NewNamedReader(name, reader), silent, protectContent)
+}
+
+func (ms *MediaSender) sendFile(file objs.NamedReader, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
 	switch ms.mediaType {
 	case PHOTO:
 		return ms.bot.apiInterface.SendPhoto(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.replyMarkup, ms.captionEntities,
 		)
 	case VIDEO:
 		return ms.bot.apiInterface.SendVideo(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(),
+			ms.chatId, objs.AttachName(file),
 			file, ms.caption, ms.parseMode, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler,
 			ms.captionEntities, ms.duration, ms.supportsStreaming, ms.replyMarkup,
 		)
 	case AUDIO:
 		return ms.bot.apiInterface.SendAudio(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.captionEntities, ms.duration, ms.performer, ms.title, ms.replyMarkup,
 		)
 	case ANIMATION:
 		return ms.bot.apiInterface.SendAnimation(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.width, ms.height, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile,
 			silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.captionEntities, ms.replyMarkup,
 		)
 	case DOCUMENT:
 		return ms.bot.apiInterface.SendDocument(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities,
 			ms.disableContentTypeDetection, ms.replyMarkup,
 		)
 	case VIDEONOTE:
 		return ms.bot.apiInterface.SendVideoNote(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.length, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent,
 			ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case VOICE:
 		return ms.bot.apiInterface.SendVoice(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatId, objs.AttachName(file), file, ms.caption, ms.parseMode,
 			ms.duration, ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case STICKER:
 		return ms.bot.apiInterface.SendSticker(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
+			ms.chatId, objs.AttachName(file), ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.replyTo, ms.messageThreadId, ms.replyMarkup, file,
 		)
 	default:
@@ -159,11 +171,19 @@ SetThumbnailFile sets the thumbnail of the file. It takes a file existing on the
 If this media does not support thumbnail, the thumbnail will be ignored.
 */
 func (ms *MediaSender) SetThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
 	ms.thumbFile = file
-	ms.thumb = "attach://" + stat.Name()
+	ms.thumb = objs.AttachName(file)
 	return nil
 }
+
+/*
+SetThumbnailReader sets the thumbnail of the file to the content of the reader with the given name.
+If this media does not support thumbnail, the thumbnail will be ignored.
+*/
+func (ms *MediaSender) SetThumbnailReader(name string, reader io.Reader) {
+	ms.thumbFile = objs.NewNamedReader(name, reader)
+	ms.thumb = objs.AttachName(ms.thumbFile)
+}
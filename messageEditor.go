@@ -2,6 +2,7 @@ package telego
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"time"
 
@@ -10,15 +11,14 @@ import (
 
 // MessageEditor is a tool for editing messsages.
 type MessageEditor struct {
-	bot          *Bot
-	chatIdInt    int
-	chatIdString string
+	bot    *Bot
+	chatId objs.ChatID
 }
 
 // PhotoEditor is a tool for editing photos.
 type PhotoEditor struct {
 	mg                                  *MessageEditor
-	messageId                           int
+	messageId                           int64
 	inlineMessageId, caption, parseMode string
 	captionEntities                     []objs.MessageEntity
 	replyMarkup                         *objs.InlineKeyboardMarkup
@@ -34,12 +34,20 @@ func (pi *PhotoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this photo with an existing file in the device*/
 func (pi *PhotoEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return pi.editFile(file)
+}
+
+/*EditByReader edits this media with the content of the reader as a file with the given name. The content is streamed to the API server.*/
+func (pi *PhotoEditor) EditByReader(name string, reader io.Reader) (*objs.Result[json.RawMessage], error) {
+	return pi.editFile(objs.NewNamedReader(name, reader))
+}
+
+func (pi *PhotoEditor) editFile(file objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", objs.AttachName(file), pi.caption, pi.parseMode, pi.captionEntities),
 	}
 	return pi.mg.editMedia(pi.messageId, pi.inlineMessageId, im, pi.replyMarkup, file)
 }
@@ -47,10 +55,10 @@ func (pi *PhotoEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage],
 // VideoEditor is a tool for editing videos.
 type VideoEditor struct {
 	mg                                         *MessageEditor
-	messageId                                  int
+	messageId                                  int64
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  objs.NamedReader
 	width, height, duration                    int
 	supportsStreaming                          bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
@@ -77,12 +85,20 @@ func (vi *VideoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this video by file in the device*/
 func (vi *VideoEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return vi.editFile(file)
+}
+
+/*EditByReader edits this media with the content of the reader as a file with the given name. The content is streamed to the API server.*/
+func (vi *VideoEditor) EditByReader(name string, reader io.Reader) (*objs.Result[json.RawMessage], error) {
+	return vi.editFile(objs.NewNamedReader(name, reader))
+}
+
+func (vi *VideoEditor) editFile(file objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", objs.AttachName(file), vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 	}
@@ -105,22 +121,30 @@ func (vi *VideoEditor) EditThumbnail(fileIdOrURL string) {
 
 /*EditThumbnailFile edits the thumbnail of the file. It takes a file existing on the device*/
 func (vi *VideoEditor) EditThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	vi.thumbFile = file
-	vi.thumb = "attach://" + stat.Name()
+	vi.editThumbnailFile(file)
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes the content of the reader with the given name.*/
+func (vi *VideoEditor) EditThumbnailReader(name string, reader io.Reader) {
+	vi.editThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (vi *VideoEditor) editThumbnailFile(file objs.NamedReader) {
+	vi.thumbFile = file
+	vi.thumb = objs.AttachName(file)
+}
+
 // AnimationEditor is a tool for editing animations.
 type AnimationEditor struct {
 	mg                                         *MessageEditor
-	messageId                                  int
+	messageId                                  int64
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  objs.NamedReader
 	width, height, duration                    int
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
@@ -145,12 +169,20 @@ func (ai *AnimationEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[j
 
 /*EditByFile edits this animation by file in the device*/
 func (ai *AnimationEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return ai.editFile(file)
+}
+
+/*EditByReader edits this media with the content of the reader as a file with the given name. The content is streamed to the API server.*/
+func (ai *AnimationEditor) EditByReader(name string, reader io.Reader) (*objs.Result[json.RawMessage], error) {
+	return ai.editFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AnimationEditor) editFile(file objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", objs.AttachName(file), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 	}
 	if ai.width != 0 {
@@ -172,22 +204,30 @@ func (ai *AnimationInserter) EditThumbnail(fileIdOrURL string) {
 
 /*EditThumbnailFile edits the thumbnail of the file. It takes a file existing on the device*/
 func (ai *AnimationInserter) EditThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	ai.thumbFile = file
-	ai.thumb = "attach://" + stat.Name()
+	ai.editThumbnailFile(file)
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes the content of the reader with the given name.*/
+func (ai *AnimationInserter) EditThumbnailReader(name string, reader io.Reader) {
+	ai.editThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AnimationInserter) editThumbnailFile(file objs.NamedReader) {
+	ai.thumbFile = file
+	ai.thumb = objs.AttachName(file)
+}
+
 // AudioEditor is a tool for editing audios.
 type AudioEditor struct {
 	mg                                                           *MessageEditor
-	messageId                                                    int
+	messageId                                                    int64
 	inlineMessageId, caption, parseMode, thumb, performer, title string
 	captionEntities                                              []objs.MessageEntity
-	thumbFile                                                    *os.File
+	thumbFile                                                    objs.NamedReader
 	duration                                                     int
 	replyMarkup                                                  *objs.InlineKeyboardMarkup
 }
@@ -208,12 +248,20 @@ func (ai *AudioEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this audio by file in the device*/
 func (ai *AudioEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return ai.editFile(file)
+}
+
+/*EditByReader edits this media with the content of the reader as a file with the given name. The content is streamed to the API server.*/
+func (ai *AudioEditor) EditByReader(name string, reader io.Reader) (*objs.Result[json.RawMessage], error) {
+	return ai.editFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AudioEditor) editFile(file objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", objs.AttachName(file), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -231,22 +279,30 @@ func (ai *AudioEditor) EditThumbnail(fileIdOrURL string) {
 
 /*EditThumbnailFile edits the thumbnail of the file. It takes a file existing on the device*/
 func (ai *AudioEditor) EditThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	ai.thumbFile = file
-	ai.thumb = "attach://" + stat.Name()
+	ai.editThumbnailFile(file)
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes the content of the reader with the given name.*/
+func (ai *AudioEditor) EditThumbnailReader(name string, reader io.Reader) {
+	ai.editThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (ai *AudioEditor) editThumbnailFile(file objs.NamedReader) {
+	ai.thumbFile = file
+	ai.thumb = objs.AttachName(file)
+}
+
 // DocumentEditor is a tool for editing documents.
 type DocumentEditor struct {
 	mg                                         *MessageEditor
-	messageId                                  int
+	messageId                                  int64
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  objs.NamedReader
 	disableContentTypeDetection                bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
@@ -263,12 +319,20 @@ func (di *DocumentEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[js
 
 /*EditByFile edits this document by file in the device*/
 func (di *DocumentEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return nil, err
 	}
+	return di.editFile(file)
+}
+
+/*EditByReader edits this media with the content of the reader as a file with the given name. The content is streamed to the API server.*/
+func (di *DocumentEditor) EditByReader(name string, reader io.Reader) (*objs.Result[json.RawMessage], error) {
+	return di.editFile(objs.NewNamedReader(name, reader))
+}
+
+func (di *DocumentEditor) editFile(file objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", objs.AttachName(file), di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
@@ -282,41 +346,49 @@ func (di *DocumentEditor) EditThumbnail(fileIdOrURL string) {
 
 /*EditThumbnailFile edits the thumbnail of the file. It takes a file existing on the device*/
 func (di *DocumentEditor) EditThumbnailFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
+	if _, err := file.Stat(); err != nil {
 		return err
 	}
-	di.thumbFile = file
-	di.thumb = "attach://" + stat.Name()
+	di.editThumbnailFile(file)
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes the content of the reader with the given name.*/
+func (di *DocumentEditor) EditThumbnailReader(name string, reader io.Reader) {
+	di.editThumbnailFile(objs.NewNamedReader(name, reader))
+}
+
+func (di *DocumentEditor) editThumbnailFile(file objs.NamedReader) {
+	di.thumbFile = file
+	di.thumb = objs.AttachName(file)
+}
+
 /*EditText can be used to edit text and game messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
-func (me *MessageEditor) EditText(messageId int, text, inlineMessageId, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
+func (me *MessageEditor) EditText(messageId int64, text, inlineMessageId, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
 	return me.bot.apiInterface.EditMessageText(
-		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, text,
+		me.chatId, messageId, inlineMessageId, text,
 		parseMode, entities, disableWebPagePreview, &replyMarkup,
 	)
 }
 
 /*EditCaption can be used to edit captions of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
-func (me *MessageEditor) EditCaption(messageId int, caption, inlineMessageId, parseMode string, captionEntities []objs.MessageEntity, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
+func (me *MessageEditor) EditCaption(messageId int64, caption, inlineMessageId, parseMode string, captionEntities []objs.MessageEntity, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
 	return me.bot.apiInterface.EditMessageCaption(
-		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, caption,
+		me.chatId, messageId, inlineMessageId, caption,
 		parseMode, captionEntities, &replyMarkup,
 	)
 }
 
 /*EditMediaPhoto returns a PhotoEditor to edit a photo*/
-func (mg *MessageEditor) EditMediaPhoto(messageId int, caption, parseMode string, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *PhotoEditor {
+func (mg *MessageEditor) EditMediaPhoto(messageId int64, caption, parseMode string, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *PhotoEditor {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
@@ -325,7 +397,7 @@ func (mg *MessageEditor) EditMediaPhoto(messageId int, caption, parseMode string
 }
 
 /*EditMediaVideo returns a VideoEditor to edit a video*/
-func (mg *MessageEditor) EditMediaVideo(messageId int, caption, parseMode string, width, height, duration int, supportsStreaming bool, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *VideoEditor {
+func (mg *MessageEditor) EditMediaVideo(messageId int64, caption, parseMode string, width, height, duration int, supportsStreaming bool, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *VideoEditor {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
@@ -334,7 +406,7 @@ func (mg *MessageEditor) EditMediaVideo(messageId int, caption, parseMode string
 }
 
 /*EditMediaAnimation returns an AnimationEditor to edit an animation*/
-func (mg *MessageEditor) EditMediaAnimation(messageId int, caption, parseMode string, width, height, duration int, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *AnimationEditor {
+func (mg *MessageEditor) EditMediaAnimation(messageId int64, caption, parseMode string, width, height, duration int, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *AnimationEditor {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
@@ -343,7 +415,7 @@ func (mg *MessageEditor) EditMediaAnimation(messageId int, caption, parseMode st
 }
 
 /*EditMediaAudio returns an AudioEditor to edit an audio*/
-func (mg *MessageEditor) EditMediaAudio(messageId int, caption, parseMode, performer, title string, duration int, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *AudioEditor {
+func (mg *MessageEditor) EditMediaAudio(messageId int64, caption, parseMode, performer, title string, duration int, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *AudioEditor {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
@@ -352,7 +424,7 @@ func (mg *MessageEditor) EditMediaAudio(messageId int, caption, parseMode, perfo
 }
 
 /*EditMediaDocument returns a DocumentEditor to edit a document*/
-func (mg *MessageEditor) EditMediaDocument(messageId int, caption, parseMode string, disableContentTypeDetection bool, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *DocumentEditor {
+func (mg *MessageEditor) EditMediaDocument(messageId int64, caption, parseMode string, disableContentTypeDetection bool, captionEntitie []objs.MessageEntity, keyboard *InlineKeyboard) *DocumentEditor {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
@@ -361,13 +433,13 @@ func (mg *MessageEditor) EditMediaDocument(messageId int, caption, parseMode str
 }
 
 /*EditReplyMarkup can be used to edit only the reply markup of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
-func (me *MessageEditor) EditReplyMarkup(messageId int, inlineMessageId string, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
+func (me *MessageEditor) EditReplyMarkup(messageId int64, inlineMessageId string, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
 	return me.bot.apiInterface.EditMessagereplyMarkup(
-		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, &replyMarkup,
+		me.chatId, messageId, inlineMessageId, &replyMarkup,
 	)
 }
 
@@ -392,15 +464,15 @@ DeleteMessage can be used to delete a message, including service messages, with
 
 Returns True on success.
 */
-func (me *MessageEditor) DeleteMessage(messageId int) (*objs.Result[bool], error) {
-	return me.bot.apiInterface.DeleteMessage(me.chatIdInt, me.chatIdString, messageId)
+func (me *MessageEditor) DeleteMessage(messageId int64) (*objs.Result[bool], error) {
+	return me.bot.apiInterface.DeleteMessage(me.chatId, messageId)
 }
 
 /*
 DeletIn deletes the message with a delay. Delay should be in time.Duration format. Once this method is called it cannot be canceled.
-All the rules of DeleteMessage method apply to this method too.
+All the rules of DeleteMessage method apply to this method too. The deletion is lost if the program stops before the delay, use Scheduler.ScheduleDelete for a deletion which survives restarts and can be canceled.
 */
-func (me *MessageEditor) DeletIn(messageId int, delay time.Duration) {
+func (me *MessageEditor) DeletIn(messageId int64, delay time.Duration) {
 	go func() {
 		timer := time.NewTimer(delay)
 		<-timer.C
@@ -408,9 +480,9 @@ func (me *MessageEditor) DeletIn(messageId int, delay time.Duration) {
 	}()
 }
 
-func (me *MessageEditor) editMedia(messageId int, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.Result[json.RawMessage], error) {
+func (me *MessageEditor) editMedia(messageId int64, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	return me.bot.apiInterface.EditMessageMedia(
-		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, media,
+		me.chatId, messageId, inlineMessageId, media,
 		replyMarkup, file...,
 	)
 }
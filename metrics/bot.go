@@ -0,0 +1,57 @@
+package metrics
+
+// The outcomes of an API call.
+const (
+	// OutcomeOk means the API server returned "ok" : true.
+	OutcomeOk = "ok"
+	// OutcomeAPIError means the API server returned "ok" : false.
+	OutcomeAPIError = "api_error"
+	// OutcomeNetworkError means the request could not be sent or the response could not be read.
+	OutcomeNetworkError = "network_error"
+)
+
+// The reasons of dropping an update.
+const (
+	// DropReasonMiddleware means a middleware did not call the next function.
+	DropReasonMiddleware = "middleware"
+	// DropReasonBlockedUser means the update was sent by a blocked user.
+	DropReasonBlockedUser = "blocked_user"
+)
+
+// The queues of updates waiting to be received by the bot.
+const (
+	// QueueUpdates is the update channel of the bot.
+	QueueUpdates = "updates"
+	// QueueChatUpdates is the chat update channel of the bot.
+	QueueChatUpdates = "chat_updates"
+)
+
+var (
+	// APIRequests counts the calls to the Bot API by method and outcome.
+	APIRequests = NewCounterVec("telego_api_requests_total", "Number of Bot API calls by method and outcome.", "method", "outcome")
+	// APIRequestDuration is the latency of the calls to the Bot API by method.
+	APIRequestDuration = NewHistogramVec("telego_api_request_duration_seconds", "Latency of Bot API calls in seconds.", nil, "method")
+	// FloodWaits counts the calls rejected by the flood control of the API server (error code 429) by method.
+	FloodWaits = NewCounterVec("telego_flood_waits_total", "Number of Bot API calls rejected by the flood control.", "method")
+	// FloodWaitSeconds is the total number of seconds the API server has asked the bot to wait by method.
+	FloodWaitSeconds = NewCounterVec("telego_flood_wait_seconds_total", "Total retry_after seconds returned by the flood control.", "method")
+	// UpdatesReceived counts the received updates by update type.
+	UpdatesReceived = NewCounterVec("telego_updates_received_total", "Number of received updates by type.", "type")
+	// UpdatesDropped counts the updates which have not reached the bot by update type and reason.
+	UpdatesDropped = NewCounterVec("telego_updates_dropped_total", "Number of dropped updates by type and reason.", "type", "reason")
+	// HandlerDuration is the execution time of the handlers by handler kind (text, callback, user_shared and chat_shared).
+	HandlerDuration = NewHistogramVec("telego_handler_duration_seconds", "Execution time of update handlers in seconds.", nil, "handler")
+	// QueueSize is the number of updates waiting to be received from the channels of the bot.
+	QueueSize = NewGaugeVec("telego_update_queue_size", "Number of updates waiting to be received from the update channels.", "queue")
+)
+
+func init() {
+	Default.Register(APIRequests)
+	Default.Register(APIRequestDuration)
+	Default.Register(FloodWaits)
+	Default.Register(FloodWaitSeconds)
+	Default.Register(UpdatesReceived)
+	Default.Register(UpdatesDropped)
+	Default.Register(HandlerDuration)
+	Default.Register(QueueSize)
+}
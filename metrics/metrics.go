@@ -0,0 +1,392 @@
+/*
+Package metrics contains the metrics of the bot and exposes them in Prometheus text exposition format.
+All the metrics of the bot are registered in the Default registry. Mount Handler() on your HTTP server (or set "MetricsPath" field of the webhook configs) to expose them.
+*/
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collector is a metric (or a group of metrics) which can be written in Prometheus text format.
+type Collector interface {
+	// WriteText writes the HELP and TYPE lines and the samples of the metric.
+	WriteText(w io.Writer) error
+}
+
+// Registry holds a list of collectors.
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// NewRegistry returns a new empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default is the registry which contains all the metrics of the bot.
+var Default = NewRegistry()
+
+// Register adds the given collector to the registry.
+func (r *Registry) Register(c Collector) {
+	r.mu.Lock()
+	r.collectors = append(r.collectors, c)
+	r.mu.Unlock()
+}
+
+// WriteText writes all the metrics of the registry in Prometheus text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	collectors := append([]Collector{}, r.collectors...)
+	r.mu.RUnlock()
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		if err := c.WriteText(bw); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Handler returns an http handler which serves the metrics of the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		wr.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(wr)
+	})
+}
+
+// Handler returns an http handler which serves the metrics of the Default registry.
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+/*The common part of all metric vectors. Each child is identified by its label values.*/
+type vec[T any] struct {
+	name, help, typ string
+	labels          []string
+	mu              sync.RWMutex
+	children        map[string]*child[T]
+	newValue        func() *T
+}
+
+type child[T any] struct {
+	labelValues []string
+	value       *T
+}
+
+func newVec[T any](name, help, typ string, labels []string, newValue func() *T) *vec[T] {
+	return &vec[T]{name: name, help: help, typ: typ, labels: labels, children: make(map[string]*child[T]), newValue: newValue}
+}
+
+func (v *vec[T]) with(labelValues []string) *T {
+	if len(labelValues) != len(v.labels) {
+		panic("metrics: " + v.name + " has " + strconv.Itoa(len(v.labels)) + " labels but " + strconv.Itoa(len(labelValues)) + " values were given")
+	}
+	key := strings.Join(labelValues, "\xff")
+	v.mu.RLock()
+	c, ok := v.children[key]
+	v.mu.RUnlock()
+	if ok {
+		return c.value
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if c, ok = v.children[key]; !ok {
+		c = &child[T]{labelValues: append([]string{}, labelValues...), value: v.newValue()}
+		v.children[key] = c
+	}
+	return c.value
+}
+
+/*Returns the children sorted by their label values.*/
+func (v *vec[T]) sortedChildren() []*child[T] {
+	v.mu.RLock()
+	out := make([]*child[T], 0, len(v.children))
+	for _, c := range v.children {
+		out = append(out, c)
+	}
+	v.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		return strings.Join(out[i].labelValues, "\xff") < strings.Join(out[j].labelValues, "\xff")
+	})
+	return out
+}
+
+func (v *vec[T]) writeHeader(w io.Writer) error {
+	_, err := io.WriteString(w, "# HELP "+v.name+" "+escapeHelp(v.help)+"\n# TYPE "+v.name+" "+v.typ+"\n")
+	return err
+}
+
+// Counter is a metric which only goes up.
+type Counter struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Inc increases the counter by one.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increases the counter by the given value. Negative values are ignored.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	c.mu.Lock()
+	c.value += v
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
+
+// CounterVec is a group of counters with the same name which are distinguished by their labels.
+type CounterVec struct {
+	*vec[Counter]
+}
+
+// NewCounterVec creates a new counter vector with the given name, help text and label names.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{newVec(name, help, "counter", labels, func() *Counter { return &Counter{} })}
+}
+
+// With returns the counter with the given label values. Label values should be passed in the same order as label names.
+func (cv *CounterVec) With(labelValues ...string) *Counter {
+	return cv.with(labelValues)
+}
+
+// WriteText writes the counters in Prometheus text format.
+func (cv *CounterVec) WriteText(w io.Writer) error {
+	if err := cv.writeHeader(w); err != nil {
+		return err
+	}
+	for _, c := range cv.sortedChildren() {
+		if err := writeSample(w, cv.name, cv.labels, c.labelValues, "", "", c.value.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Gauge is a metric which can go up and down.
+type Gauge struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Set sets the value of the gauge.
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	g.value = v
+	g.mu.Unlock()
+}
+
+// Add adds the given value (which can be negative) to the gauge.
+func (g *Gauge) Add(v float64) {
+	g.mu.Lock()
+	g.value += v
+	g.mu.Unlock()
+}
+
+// Inc increases the gauge by one.
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec decreases the gauge by one.
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+// GaugeVec is a group of gauges with the same name which are distinguished by their labels.
+type GaugeVec struct {
+	*vec[Gauge]
+}
+
+// NewGaugeVec creates a new gauge vector with the given name, help text and label names.
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{newVec(name, help, "gauge", labels, func() *Gauge { return &Gauge{} })}
+}
+
+// With returns the gauge with the given label values. Label values should be passed in the same order as label names.
+func (gv *GaugeVec) With(labelValues ...string) *Gauge {
+	return gv.with(labelValues)
+}
+
+// WriteText writes the gauges in Prometheus text format.
+func (gv *GaugeVec) WriteText(w io.Writer) error {
+	if err := gv.writeHeader(w); err != nil {
+		return err
+	}
+	for _, c := range gv.sortedChildren() {
+		if err := writeSample(w, gv.name, gv.labels, c.labelValues, "", "", c.value.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultBuckets are the default upper bounds (in seconds) of histogram buckets. They are suitable for the latency of API calls and handlers.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Histogram counts the observed values in buckets.
+type Histogram struct {
+	mu     sync.Mutex
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Observe adds a value to the histogram.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.bounds, v)
+	h.mu.Lock()
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+	h.mu.Unlock()
+}
+
+// ObserveDuration adds the given duration in seconds to the histogram.
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// Count returns the number of observed values.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// HistogramVec is a group of histograms with the same name and buckets which are distinguished by their labels.
+type HistogramVec struct {
+	*vec[Histogram]
+	buckets []float64
+}
+
+// NewHistogramVec creates a new histogram vector with the given name, help text, bucket upper bounds and label names. If buckets is nil, DefaultBuckets is used.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	bounds := append([]float64{}, buckets...)
+	sort.Float64s(bounds)
+	return &HistogramVec{
+		vec: newVec(name, help, "histogram", labels, func() *Histogram {
+			return &Histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+		}),
+		buckets: bounds,
+	}
+}
+
+// With returns the histogram with the given label values. Label values should be passed in the same order as label names.
+func (hv *HistogramVec) With(labelValues ...string) *Histogram {
+	return hv.with(labelValues)
+}
+
+// WriteText writes the histograms in Prometheus text format.
+func (hv *HistogramVec) WriteText(w io.Writer) error {
+	if err := hv.writeHeader(w); err != nil {
+		return err
+	}
+	for _, c := range hv.sortedChildren() {
+		h := c.value
+		h.mu.Lock()
+		counts := append([]uint64{}, h.counts...)
+		sum, count := h.sum, h.count
+		h.mu.Unlock()
+		var cumulative uint64
+		for i, b := range hv.buckets {
+			cumulative += counts[i]
+			if err := writeSample(w, hv.name+"_bucket", hv.labels, c.labelValues, "le", formatFloat(b), float64(cumulative)); err != nil {
+				return err
+			}
+		}
+		if err := writeSample(w, hv.name+"_bucket", hv.labels, c.labelValues, "le", "+Inf", float64(count)); err != nil {
+			return err
+		}
+		if err := writeSample(w, hv.name+"_sum", hv.labels, c.labelValues, "", "", sum); err != nil {
+			return err
+		}
+		if err := writeSample(w, hv.name+"_count", hv.labels, c.labelValues, "", "", float64(count)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSample(w io.Writer, name string, labels, labelValues []string, extraLabel, extraValue string, value float64) error {
+	var sb strings.Builder
+	sb.WriteString(name)
+	if len(labels) > 0 || extraLabel != "" {
+		sb.WriteString("{")
+		for i, l := range labels {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(l + "=\"" + escapeLabelValue(labelValues[i]) + "\"")
+		}
+		if extraLabel != "" {
+			if len(labels) > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(extraLabel + "=\"" + extraValue + "\"")
+		}
+		sb.WriteString("}")
+	}
+	sb.WriteString(" " + formatFloat(value) + "\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpReplacer       = strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+	labelValueReplacer = strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\"", "\\\"")
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
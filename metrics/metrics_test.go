@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	cv := NewCounterVec("test_requests_total", "Number of requests.", "method", "outcome")
+	hv := NewHistogramVec("test_duration_seconds", "Duration.", []float64{1, 0.1}, "method")
+	gv := NewGaugeVec("test_queue_size", "Queue size.", "queue")
+	r.Register(cv)
+	r.Register(hv)
+	r.Register(gv)
+
+	cv.With("sendMessage", "ok").Inc()
+	cv.With("sendMessage", "ok").Add(2)
+	cv.With("getMe", "api_error").Inc()
+	hv.With("sendMessage").Observe(0.05)
+	hv.With("sendMessage").Observe(0.5)
+	hv.With("sendMessage").Observe(5)
+	gv.With("a\"b").Inc()
+
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# HELP test_requests_total Number of requests.
+# TYPE test_requests_total counter
+test_requests_total{method="getMe",outcome="api_error"} 1
+test_requests_total{method="sendMessage",outcome="ok"} 3
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="sendMessage",le="0.1"} 1
+test_duration_seconds_bucket{method="sendMessage",le="1"} 2
+test_duration_seconds_bucket{method="sendMessage",le="+Inf"} 3
+test_duration_seconds_sum{method="sendMessage"} 5.55
+test_duration_seconds_count{method="sendMessage"} 3
+# HELP test_queue_size Queue size.
+# TYPE test_queue_size gauge
+test_queue_size{queue="a\"b"} 1
+`
+	if sb.String() != expected {
+		t.Fatalf("unexpected output :\n%s", sb.String())
+	}
+}
@@ -0,0 +1,81 @@
+package telego
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func waitMigration(t *testing.T, migrated chan [2]int64, want [2]int64) {
+	t.Helper()
+	select {
+	case got := <-migrated:
+		if got != want {
+			t.Errorf("expected migration %v, got %v", want, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the migration handler was not called")
+	}
+}
+
+func TestChatMigratedByRequest(t *testing.T) {
+	var chatIds []string
+	bot := newTestBot(t, func(method string, r *http.Request) string {
+		chatId := requestParams(r)["chat_id"]
+		chatIds = append(chatIds, chatId)
+		if chatId == "-7" {
+			return errResult(400, "Bad Request: group chat was upgraded to a supergroup chat", map[string]any{"migrate_to_chat_id": -1007})
+		}
+		return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": -1007, "type": "supergroup"}})
+	})
+	migrated := make(chan [2]int64, 1)
+	bot.AddChatMigrationHandler(func(oldChatId, newChatId int64) { migrated <- [2]int64{oldChatId, newChatId} })
+	ch, err := bot.AdvancedMode().RegisterChannel("-7", "message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bot.SendMessage(objs.IntChatID(-7), "hi", "", 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chatIds) != 2 || chatIds[1] != "-1007" {
+		t.Fatalf("the request should be sent again to the supergroup, requests: %v", chatIds)
+	}
+	waitMigration(t, migrated, [2]int64{-7, -1007})
+	if bot.getChannel("-1007", "message") != ch {
+		t.Error("the channel should be moved to the supergroup")
+	}
+	if bot.getChannel("-7", "message") != nil {
+		t.Error("the channel of the group should be removed")
+	}
+}
+
+func TestChatMigratedByUpdate(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string { return okResult(true) })
+	migrated := make(chan [2]int64, 1)
+	bot.AddChatMigrationHandler(func(oldChatId, newChatId int64) { migrated <- [2]int64{oldChatId, newChatId} })
+	ch, err := bot.AdvancedMode().RegisterChannel("-9", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	up := &objs.ChatUpdate{ChatId: "-9", Update: &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: -9, Type: "group"}, MigrateToChatId: -1009}}}
+	if key := bot.checkChatMigration(up); key != "-1009" {
+		t.Errorf("the update should be sent to the channels of the supergroup, got %q", key)
+	}
+	waitMigration(t, migrated, [2]int64{-9, -1009})
+	if bot.getChannel("-1009", "all") != ch || bot.getChannel("-9", "all") != nil {
+		t.Error("the channel should be moved to the supergroup")
+	}
+	//The updates of the group which are received later are sent to the supergroup too.
+	later := &objs.ChatUpdate{ChatId: "-9", Update: &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: -9, Type: "group"}}}}
+	if key := bot.checkChatMigration(later); key != "-1009" {
+		t.Errorf("expected the key of the supergroup, got %q", key)
+	}
+	select {
+	case m := <-migrated:
+		t.Errorf("the migration should be handled once, got %v again", m)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,120 @@
+package objects
+
+import (
+	"encoding/json"
+	"io"
+	mp "mime/multipart"
+	"sort"
+	"strings"
+)
+
+/*
+Args are the arguments of a method as a map from the names of the parameters to their values. It can be used for calling the methods which don't have a dedicated arguments type.
+
+Values are encoded as json. NamedReader values (like *os.File) are uploaded as files and the parameter is set to "attach://<file name>".
+*/
+type Args map[string]any
+
+// ToJson converts the arguments into json format.
+func (args Args) ToJson() []byte {
+	bt, err := json.Marshal(args.encoded())
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts the arguments into HTTP multipart form to be sent to the API server. Strings are written as they are and the other values are json encoded.
+func (args Args) ToMultiPart(wr *mp.Writer) {
+	enc := args.encoded()
+	keys := make([]string, 0, len(enc))
+	for key := range enc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value string
+		switch v := enc[key].(type) {
+		case string:
+			value = v
+		case json.RawMessage:
+			//Json strings (like the username of a channel) are written without the quotes.
+			if err := json.Unmarshal(v, &value); err != nil {
+				value = string(v)
+			}
+		default:
+			bt, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			value = string(bt)
+		}
+		fw, _ := wr.CreateFormField(key)
+		_, _ = io.Copy(fw, strings.NewReader(value))
+	}
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args Args) SetChatId(chatId json.RawMessage) {
+	args["chat_id"] = chatId
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args Args) GetChatId() json.RawMessage {
+	switch v := args["chat_id"].(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		return v
+	default:
+		bt, err := json.Marshal(v)
+		if err != nil || string(bt) == "null" {
+			return nil
+		}
+		return bt
+	}
+}
+
+/*Files returns the files which should be uploaded with the arguments.*/
+func (args Args) Files() []NamedReader {
+	var files []NamedReader
+	for _, v := range args {
+		if file, ok := v.(NamedReader); ok && !IsNilFile(file) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+/*Returns a copy of the arguments in which the files are replaced by their attach names and the nil values are removed.*/
+func (args Args) encoded() map[string]any {
+	out := make(map[string]any, len(args))
+	for key, v := range args {
+		if v == nil {
+			continue
+		}
+		if file, ok := v.(NamedReader); ok {
+			if IsNilFile(file) {
+				continue
+			}
+			v = AttachName(file)
+		}
+		out[key] = v
+	}
+	return out
+}
+
+/*Writes a json object into the multipart form. It is used by the generated arguments.*/
+func jsonToMultiPart(bt []byte, wr *mp.Writer) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bt, &fields); err != nil {
+		return
+	}
+	args := make(Args, len(fields))
+	for key, value := range fields {
+		if string(value) != "null" {
+			args[key] = value
+		}
+	}
+	args.ToMultiPart(wr)
+}
@@ -75,7 +75,7 @@ func (bc *BotCommandScopeChatAdministrators) FixTheType() {
 type BotCommandScopeChatMember struct {
 	BotCommandScopeChat
 	/*Unique identifier of the target user*/
-	UserId int `json:"user_id"`
+	UserId int64 `json:"user_id"`
 }
 
 func (bc *BotCommandScopeChatMember) FixTheType() {
@@ -0,0 +1,97 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+/*
+ChatID is the identifier of a target chat. It is either the numeric id of the chat or the username of a supergroup or channel (in the format @channelusername).
+Use IntChatID or UsernameChatID to create one. The zero value is an empty identifier.
+
+ChatID is encoded as a json number or a json string, like the "chat_id" arguments of the bot API.
+*/
+type ChatID struct {
+	id       int64
+	username string
+}
+
+/*IntChatID returns a ChatID of the chat with the given numeric id.*/
+func IntChatID(id int64) ChatID {
+	return ChatID{id: id}
+}
+
+/*UsernameChatID returns a ChatID of the supergroup or channel with the given username. The "@" prefix is added if it is missing.*/
+func UsernameChatID(username string) ChatID {
+	if username == "" {
+		return ChatID{}
+	}
+	if !strings.HasPrefix(username, "@") {
+		username = "@" + username
+	}
+	return ChatID{username: username}
+}
+
+/*Int returns the numeric id of the chat. It is zero if the chat is identified by its username.*/
+func (c ChatID) Int() int64 {
+	return c.id
+}
+
+/*Username returns the username of the chat in the format @username. It is empty if the chat is identified by its numeric id.*/
+func (c ChatID) Username() string {
+	return c.username
+}
+
+/*IsUsername returns true if the chat is identified by its username.*/
+func (c ChatID) IsUsername() bool {
+	return c.username != ""
+}
+
+/*IsZero returns true if the ChatID is empty.*/
+func (c ChatID) IsZero() bool {
+	return c.id == 0 && c.username == ""
+}
+
+/*String returns the numeric id in decimal form or the username of the chat.*/
+func (c ChatID) String() string {
+	if c.username != "" {
+		return c.username
+	}
+	return strconv.FormatInt(c.id, 10)
+}
+
+/*MarshalJSON encodes the ChatID as a json number or a json string. The zero value is encoded as null.*/
+func (c ChatID) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.username != "":
+		return json.Marshal(c.username)
+	case c.id != 0:
+		return []byte(strconv.FormatInt(c.id, 10)), nil
+	default:
+		return []byte("null"), nil
+	}
+}
+
+/*UnmarshalJSON decodes a json number or a json string. Strings containing a number are decoded as numeric ids.*/
+func (c *ChatID) UnmarshalJSON(data []byte) error {
+	*c = ChatID{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] != '"' {
+		return json.Unmarshal(data, &c.id)
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+		c.id = id
+		return nil
+	}
+	*c = UsernameChatID(s)
+	return nil
+}
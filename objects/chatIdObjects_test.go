@@ -0,0 +1,53 @@
+package objects
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatIDMarshalJSON(t *testing.T) {
+	tests := []struct {
+		chatId ChatID
+		want   string
+	}{
+		{IntChatID(-1001234), `-1001234`},
+		{UsernameChatID("channel"), `"@channel"`},
+		{UsernameChatID("@channel"), `"@channel"`},
+		{UsernameChatID(""), `null`},
+		{ChatID{}, `null`},
+	}
+	for _, tt := range tests {
+		out, err := json.Marshal(tt.chatId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("got %s, want %s", out, tt.want)
+		}
+	}
+}
+
+func TestChatIDUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want ChatID
+	}{
+		{`12`, IntChatID(12)},
+		{`"-1001234"`, IntChatID(-1001234)},
+		{`"@channel"`, UsernameChatID("channel")},
+		{`null`, ChatID{}},
+	}
+	for _, tt := range tests {
+		var got ChatID
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %#v, want %#v", tt.data, got, tt.want)
+		}
+	}
+	var got ChatID
+	if err := json.Unmarshal([]byte(`true`), &got); err == nil {
+		t.Error("expected an error for a boolean chat id")
+	}
+}
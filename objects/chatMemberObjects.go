@@ -1,7 +1,79 @@
 package objects
 
+import (
+	"encoding/json"
+	"errors"
+)
+
+/*
+ChatMember contains information about one member of a chat. The concrete type depends on the status of the member :
+ChatMemberOwner ("creator"), ChatMemberAdministrator ("administrator"), ChatMemberMember ("member"), ChatMemberRestricted ("restricted"), ChatMemberLeft ("left") and ChatMemberBanned ("kicked").
+Use a type switch to access the fields of each type.
+*/
 type ChatMember interface {
-	blah()
+	/*GetStatus returns the status of the member in the chat.*/
+	GetStatus() string
+	/*GetUser returns the information of the user.*/
+	GetUser() *User
+	/*IsAdmin returns true if the member is the owner or an administrator of the chat.*/
+	IsAdmin() bool
+	/*InChat returns true if the user is a member of the chat at the moment.*/
+	InChat() bool
+	/*CanRestrict returns true if the member can restrict, ban or unban chat members.*/
+	CanRestrict() bool
+}
+
+const (
+	ChatMemberStatusOwner         = "creator"
+	ChatMemberStatusAdministrator = "administrator"
+	ChatMemberStatusMember        = "member"
+	ChatMemberStatusRestricted    = "restricted"
+	ChatMemberStatusLeft          = "left"
+	ChatMemberStatusBanned        = "kicked"
+)
+
+/*UnmarshalChatMember decodes a ChatMember. The concrete type is chosen based on the "status" field. Unknown statuses are decoded as ChatMemberMember.*/
+func UnmarshalChatMember(data []byte) (ChatMember, error) {
+	var st struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, err
+	}
+	var cm ChatMember
+	switch st.Status {
+	case ChatMemberStatusOwner:
+		cm = &ChatMemberOwner{}
+	case ChatMemberStatusAdministrator:
+		cm = &ChatMemberAdministrator{}
+	case ChatMemberStatusRestricted:
+		cm = &ChatMemberRestricted{}
+	case ChatMemberStatusLeft:
+		cm = &ChatMemberLeft{}
+	case ChatMemberStatusBanned:
+		cm = &ChatMemberBanned{}
+	case "":
+		return nil, errors.New("chat member has no status")
+	default:
+		cm = &ChatMemberMember{}
+	}
+	if err := json.Unmarshal(data, cm); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+/*UnmarshalChatMembers decodes a list of ChatMember objects.*/
+func UnmarshalChatMembers(data []json.RawMessage) ([]ChatMember, error) {
+	out := make([]ChatMember, 0, len(data))
+	for _, raw := range data {
+		cm, err := UnmarshalChatMember(raw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cm)
+	}
+	return out, nil
 }
 
 /*Represents a chat member that has no additional privileges or restrictions*/
@@ -12,8 +84,17 @@ type ChatMemberMember struct {
 	User User `json:"user"`
 }
 
-func (*ChatMemberMember) blah() {}
+func (cm *ChatMemberMember) GetStatus() string { return cm.Status }
+
+func (cm *ChatMemberMember) GetUser() *User { return &cm.User }
 
+func (*ChatMemberMember) IsAdmin() bool { return false }
+
+func (*ChatMemberMember) InChat() bool { return true }
+
+func (*ChatMemberMember) CanRestrict() bool { return false }
+
+/*Represents a chat member that owns the chat and has all administrator privileges.*/
 type ChatMemberOwner struct {
 	ChatMemberMember
 	/*True, if the user's presence in the chat is hidden*/
@@ -61,8 +142,15 @@ type ChatMemberAdministrator struct {
 	CustomTitle string `json:"custom_title,omitempty"`
 }
 
-func (*ChatMemberOwner) blah() {}
+func (*ChatMemberOwner) IsAdmin() bool { return true }
+
+func (*ChatMemberOwner) CanRestrict() bool { return true }
+
+func (*ChatMemberAdministrator) IsAdmin() bool { return true }
+
+func (cm *ChatMemberAdministrator) CanRestrict() bool { return cm.CanRestrictMembers }
 
+/*Represents a chat member that is under certain restrictions in the chat. Supergroups only.*/
 type ChatMemberRestricted struct {
 	ChatMemberMember
 	/*True, if the user is a member of the chat at the moment of the request*/
@@ -102,14 +190,14 @@ type ChatMemberRestricted struct {
 	UntilDate int `json:"until_date"`
 }
 
-func (*ChatMemberRestricted) blah() {}
+func (cm *ChatMemberRestricted) InChat() bool { return cm.IsMember }
 
 /*Represents a chat member that isn't currently a member of the chat, but may join it themselves.*/
 type ChatMemberLeft struct {
 	ChatMemberMember
 }
 
-func (*ChatMemberLeft) blah() {}
+func (*ChatMemberLeft) InChat() bool { return false }
 
 /*Represents a chat member that was banned in the chat and can't return to the chat or view chat messages.*/
 type ChatMemberBanned struct {
@@ -118,4 +206,4 @@ type ChatMemberBanned struct {
 	UntilDate int `json:"until_date"`
 }
 
-func (*ChatMemberBanned) blah() {}
+func (*ChatMemberBanned) InChat() bool { return false }
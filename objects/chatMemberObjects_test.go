@@ -0,0 +1,68 @@
+package objects
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalChatMember(t *testing.T) {
+	tests := []struct {
+		data        string
+		status      string
+		admin       bool
+		inChat      bool
+		canRestrict bool
+	}{
+		{`{"status":"creator","user":{"id":1},"is_anonymous":false}`, ChatMemberStatusOwner, true, true, true},
+		{`{"status":"administrator","user":{"id":1},"can_restrict_members":true}`, ChatMemberStatusAdministrator, true, true, true},
+		{`{"status":"administrator","user":{"id":1},"can_restrict_members":false}`, ChatMemberStatusAdministrator, true, true, false},
+		{`{"status":"member","user":{"id":1}}`, ChatMemberStatusMember, false, true, false},
+		{`{"status":"restricted","user":{"id":1},"is_member":false,"until_date":10}`, ChatMemberStatusRestricted, false, false, false},
+		{`{"status":"left","user":{"id":1}}`, ChatMemberStatusLeft, false, false, false},
+		{`{"status":"kicked","user":{"id":1},"until_date":0}`, ChatMemberStatusBanned, false, false, false},
+	}
+	for _, tt := range tests {
+		cm, err := UnmarshalChatMember([]byte(tt.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cm.GetStatus() != tt.status || cm.GetUser().Id != 1 {
+			t.Errorf("%s: got status %q and user %d", tt.data, cm.GetStatus(), cm.GetUser().Id)
+		}
+		if cm.IsAdmin() != tt.admin || cm.InChat() != tt.inChat || cm.CanRestrict() != tt.canRestrict {
+			t.Errorf("%s: IsAdmin %v, InChat %v, CanRestrict %v", tt.data, cm.IsAdmin(), cm.InChat(), cm.CanRestrict())
+		}
+	}
+	if _, err := UnmarshalChatMember([]byte(`{"user":{"id":1}}`)); err == nil {
+		t.Error("expected an error for a member without status")
+	}
+}
+
+func TestChatMemberUpdatedUnmarshal(t *testing.T) {
+	data := `{"chat":{"id":5,"type":"private"},"date":1,"old_chat_member":{"status":"member","user":{"id":1}},"new_chat_member":{"status":"kicked","user":{"id":1},"until_date":7}}`
+	cmu := &ChatMemberUpdated{}
+	if err := json.Unmarshal([]byte(data), cmu); err != nil {
+		t.Fatal(err)
+	}
+	if cmu.Chat == nil || cmu.Chat.Id != 5 {
+		t.Fatal("the other fields should be decoded too")
+	}
+	if _, ok := cmu.OldChatMember.(*ChatMemberMember); !ok {
+		t.Errorf("old member has type %T", cmu.OldChatMember)
+	}
+	banned, ok := cmu.NewChatMember.(*ChatMemberBanned)
+	if !ok || banned.UntilDate != 7 {
+		t.Errorf("new member is %#v", cmu.NewChatMember)
+	}
+	out, err := json.Marshal(cmu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again := &ChatMemberUpdated{}
+	if err := json.Unmarshal(out, again); err != nil {
+		t.Fatal(err)
+	}
+	if again.NewChatMember.GetStatus() != ChatMemberStatusBanned {
+		t.Errorf("round trip lost the status : %s", out)
+	}
+}
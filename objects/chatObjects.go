@@ -1,10 +1,13 @@
 package objects
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 type Chat struct {
 	/*Unique identifier for this chat. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.*/
-	Id int `json:"id"`
+	Id int64 `json:"id"`
 	/*Type of chat, can be either “private”, “group”, “supergroup” or “channel”*/
 	Type string `json:"type"`
 	/*Optional. Title, for supergroups, channels and group chats*/
@@ -58,9 +61,11 @@ type Chat struct {
 	/*Optional. True, if the bot can change the group sticker set.*/
 	CanSetStickerSet bool `json:"can_set_sticker_set,omitempty"`
 	/*Optional. Unique identifier for the linked chat, i.e. the discussion group identifier for a channel and vice versa; for supergroups and channel chats. This identifier may be greater than 32 bits and some programming languages may have difficulty/silent defects in interpreting it. But it is smaller than 52 bits, so a signed 64 bit integer or double-precision float type are safe for storing this identifier.*/
-	LinkedChatId int `json:"linked_chat_id,omitempty"`
+	LinkedChatId int64 `json:"linked_chat_id,omitempty"`
 	/*Optional. For supergroups, the location to which the supergroup is connected. */
 	Location *ChatLocation `json:"location,omitempty"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
 }
 
 type ChatPhoto struct {
@@ -104,13 +109,41 @@ type ChatMemberUpdated struct {
 	/*Date the change was done in Unix time*/
 	Date int `json:"date"`
 	/*Previous information about the chat member*/
-	OldChatMember json.RawMessage `json:"old_chat_member"`
+	OldChatMember ChatMember `json:"old_chat_member"`
 	/*New information about the chat member*/
-	NewChatMember json.RawMessage `json:"new_chat_member"`
+	NewChatMember ChatMember `json:"new_chat_member"`
 	/*Optional. Chat invite link, which was used by the user to join the chat; for joining by invite link events only.*/
 	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
 	//True, if the user joined the chat via a chat folder invite link
 	ViaChatFolderInviteLink bool `json:"via_chat_folder_invite_link"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
+}
+
+/*UnmarshalJSON decodes the old and the new chat member based on their status.*/
+func (cmu *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
+	type chatMemberUpdated ChatMemberUpdated
+	aux := struct {
+		*chatMemberUpdated
+		OldChatMember json.RawMessage `json:"old_chat_member"`
+		NewChatMember json.RawMessage `json:"new_chat_member"`
+	}{chatMemberUpdated: (*chatMemberUpdated)(cmu)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if len(aux.OldChatMember) != 0 && string(aux.OldChatMember) != "null" {
+		if cmu.OldChatMember, err = UnmarshalChatMember(aux.OldChatMember); err != nil {
+			return err
+		}
+	}
+	if len(aux.NewChatMember) != 0 && string(aux.NewChatMember) != "null" {
+		if cmu.NewChatMember, err = UnmarshalChatMember(aux.NewChatMember); err != nil {
+			return err
+		}
+	}
+	cmu.Extra = extraFields(data, reflect.TypeOf(chatMemberUpdated{}))
+	return nil
 }
 
 /*Represents a join request sent to a chat.*/
@@ -0,0 +1,9 @@
+package objects
+
+/*DownloadOptions contains the options of downloading a file from the API server.*/
+type DownloadOptions struct {
+	/*Maximum size of the file in bytes. The download fails with errors.FileTooLargeError if the file is larger. Zero means no limit.*/
+	MaxSize int64
+	/*Called while the file is being downloaded with the number of the downloaded bytes and the total size of the file. Total is -1 if the size is unknown.*/
+	OnProgress func(downloaded, total int64)
+}
@@ -0,0 +1,326 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+ExtraFields contains the fields of a received object which are not known by this version of the library, as raw json.
+They are kept so the middlewares can read the new fields of the bot API before the library supports them, and they are written back when the object is marshaled, so recording and replaying the updates doesn't lose any data.
+*/
+type ExtraFields map[string]json.RawMessage
+
+/*Get decodes the extra field with the given name into v. It returns false if the field is not present or can not be decoded into v.*/
+func (ef ExtraFields) Get(name string, v any) bool {
+	raw, ok := ef[name]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+/*The lower case json names of the fields of each type. Unknown fields are found case insensitively like encoding/json.*/
+var knownFields sync.Map
+
+func fieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	addFieldNames(t, names)
+	knownFields.Store(t, names)
+	return names
+}
+
+func addFieldNames(t reflect.Type, names map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addFieldNames(ft, names)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+}
+
+/*
+Returns the fields of the json object which are not fields of the given struct type. Returns nil if there is no unknown field.
+The object has already been decoded into the struct, so only its keys are read here and the nested values are skipped without being decoded.
+Only the values of the unknown fields are copied.
+*/
+func extraFields(data []byte, t reflect.Type) ExtraFields {
+	names := fieldNames(t)
+	var out ExtraFields
+	eachObjectField(data, func(key, value []byte) {
+		if isKnownField(names, key) {
+			return
+		}
+		if out == nil {
+			out = make(ExtraFields)
+		}
+		name := string(key)
+		if bytes.IndexByte(key, '\\') != -1 {
+			_ = json.Unmarshal(append(append([]byte{'"'}, key...), '"'), &name)
+		}
+		out[name] = append(json.RawMessage(nil), value...)
+	})
+	return out
+}
+
+func isKnownField(names map[string]bool, key []byte) bool {
+	for _, c := range key {
+		if c >= 'A' && c <= 'Z' || c == '\\' || c >= 0x80 {
+			var name string
+			_ = json.Unmarshal(append(append([]byte{'"'}, key...), '"'), &name)
+			return names[strings.ToLower(name)]
+		}
+	}
+	return names[string(key)]
+}
+
+/*Calls fn with the raw key (without the quotes) and the raw value of each field of the json object. The data should be a valid json object.*/
+func eachObjectField(data []byte, fn func(key, value []byte)) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return
+	}
+	i++
+	for {
+		i = skipSpace(data, i)
+		if i >= len(data) || data[i] != '"' {
+			return
+		}
+		keyEnd := skipString(data, i)
+		key := data[i+1 : keyEnd-1]
+		i = skipSpace(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return
+		}
+		i = skipSpace(data, i+1)
+		valueEnd := skipValue(data, i)
+		fn(key, data[i:valueEnd])
+		i = skipSpace(data, valueEnd)
+		if i >= len(data) || data[i] != ',' {
+			return
+		}
+		i++
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+/*Returns the index after the closing quote of the string which starts at i.*/
+func skipString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+/*Returns the index after the end of the value which starts at i.*/
+func skipValue(data []byte, i int) int {
+	depth := 0
+	for i < len(data) {
+		switch data[i] {
+		case '"':
+			i = skipString(data, i)
+			if depth == 0 {
+				return i
+			}
+			continue
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ',', ' ', '\t', '\n', '\r':
+			if depth == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return i
+}
+
+/*Adds the extra fields to the marshaled json object. The fields which are already in the object are not added.*/
+func appendExtraFields(data []byte, extra ExtraFields, t reflect.Type) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+	names := fieldNames(t)
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		if !names[strings.ToLower(key)] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+	buf := bytes.NewBuffer(bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}")))
+	empty := buf.Len() == 1
+	for _, key := range keys {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+/*UnmarshalJSON decodes the update and keeps its unknown fields in Extra.*/
+func (u *Update) UnmarshalJSON(data []byte) error {
+	type update Update
+	if err := json.Unmarshal(data, (*update)(u)); err != nil {
+		return err
+	}
+	u.Extra = extraFields(data, reflect.TypeOf(update{}))
+	return nil
+}
+
+/*MarshalJSON encodes the update including its unknown fields.*/
+func (u Update) MarshalJSON() ([]byte, error) {
+	type update Update
+	bt, err := json.Marshal(update(u))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, u.Extra, reflect.TypeOf(update{}))
+}
+
+/*UnmarshalJSON decodes the message and keeps its unknown fields in Extra.*/
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message
+	if err := json.Unmarshal(data, (*message)(m)); err != nil {
+		return err
+	}
+	m.Extra = extraFields(data, reflect.TypeOf(message{}))
+	return nil
+}
+
+/*MarshalJSON encodes the message including its unknown fields.*/
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message
+	bt, err := json.Marshal(message(m))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, m.Extra, reflect.TypeOf(message{}))
+}
+
+/*UnmarshalJSON decodes the user and keeps its unknown fields in Extra.*/
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	if err := json.Unmarshal(data, (*user)(u)); err != nil {
+		return err
+	}
+	u.Extra = extraFields(data, reflect.TypeOf(user{}))
+	return nil
+}
+
+/*MarshalJSON encodes the user including its unknown fields.*/
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	bt, err := json.Marshal(user(u))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, u.Extra, reflect.TypeOf(user{}))
+}
+
+/*UnmarshalJSON decodes the chat and keeps its unknown fields in Extra.*/
+func (c *Chat) UnmarshalJSON(data []byte) error {
+	type chat Chat
+	if err := json.Unmarshal(data, (*chat)(c)); err != nil {
+		return err
+	}
+	c.Extra = extraFields(data, reflect.TypeOf(chat{}))
+	return nil
+}
+
+/*MarshalJSON encodes the chat including its unknown fields.*/
+func (c Chat) MarshalJSON() ([]byte, error) {
+	type chat Chat
+	bt, err := json.Marshal(chat(c))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, c.Extra, reflect.TypeOf(chat{}))
+}
+
+/*UnmarshalJSON decodes the callback query and keeps its unknown fields in Extra.*/
+func (cq *CallbackQuery) UnmarshalJSON(data []byte) error {
+	type callbackQuery CallbackQuery
+	if err := json.Unmarshal(data, (*callbackQuery)(cq)); err != nil {
+		return err
+	}
+	cq.Extra = extraFields(data, reflect.TypeOf(callbackQuery{}))
+	return nil
+}
+
+/*MarshalJSON encodes the callback query including its unknown fields.*/
+func (cq CallbackQuery) MarshalJSON() ([]byte, error) {
+	type callbackQuery CallbackQuery
+	bt, err := json.Marshal(callbackQuery(cq))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, cq.Extra, reflect.TypeOf(callbackQuery{}))
+}
+
+/*MarshalJSON encodes the chat member update including its unknown fields.*/
+func (cmu ChatMemberUpdated) MarshalJSON() ([]byte, error) {
+	type chatMemberUpdated ChatMemberUpdated
+	bt, err := json.Marshal(chatMemberUpdated(cmu))
+	if err != nil {
+		return nil, err
+	}
+	return appendExtraFields(bt, cmu.Extra, reflect.TypeOf(chatMemberUpdated{}))
+}
@@ -0,0 +1,110 @@
+package objects
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const updateWithNewFields = `{
+	"update_id": 10,
+	"new_update_kind": {"a": 1},
+	"message": {
+		"message_id": 5,
+		"date": 1700000000,
+		"chat": {"id": -100, "type": "supergroup", "title": "group", "is_forum": true, "new_chat_field": "x"},
+		"from": {"id": 7, "is_bot": false, "first_name": "A", "is_premium": true, "new_user_field": true},
+		"text": "hi",
+		"story": {"chat": {"id": -200, "type": "channel"}, "id": 3},
+		"link_preview_options": {"is_disabled": true},
+		"quote": {"text": "q", "position": 0}
+	}
+}`
+
+func TestExtraFields(t *testing.T) {
+	update := &Update{}
+	if err := json.Unmarshal([]byte(updateWithNewFields), update); err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Extra) != 1 || string(update.Extra["new_update_kind"]) != `{"a": 1}` {
+		t.Fatalf("unexpected extra fields of the update %v", update.Extra)
+	}
+	msg := update.Message
+	var preview struct {
+		IsDisabled bool `json:"is_disabled"`
+	}
+	if !msg.Extra.Get("link_preview_options", &preview) || !preview.IsDisabled {
+		t.Fatalf("unexpected extra fields of the message %v", msg.Extra)
+	}
+	if _, ok := msg.Extra["text"]; ok || len(msg.Extra) != 2 {
+		t.Fatalf("known fields should not be extra fields %v", msg.Extra)
+	}
+	if string(msg.Chat.Extra["new_chat_field"]) != `"x"` || msg.From.Extra["new_user_field"] == nil {
+		t.Fatal("extra fields of the nested objects were not kept")
+	}
+	if msg.Story == nil || msg.Story.Chat.Id != -200 || msg.Story.Id != 3 {
+		t.Fatalf("unexpected story %#v", msg.Story)
+	}
+}
+
+func TestExtraFieldsRoundTrip(t *testing.T) {
+	update := &Update{}
+	if err := json.Unmarshal([]byte(updateWithNewFields), update); err != nil {
+		t.Fatal(err)
+	}
+	bt, err := json.Marshal(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var in, out map[string]any
+	_ = json.Unmarshal([]byte(updateWithNewFields), &in)
+	if err := json.Unmarshal(bt, &out); err != nil {
+		t.Fatalf("invalid json %s : %v", bt, err)
+	}
+	//Known fields without omitempty are written with their zero values, so only the received fields are compared.
+	assertContains(t, "update", in, out)
+}
+
+func assertContains(t *testing.T, path string, expected, got map[string]any) {
+	t.Helper()
+	for key, value := range expected {
+		if sub, ok := value.(map[string]any); ok {
+			gotSub, ok := got[key].(map[string]any)
+			if !ok {
+				t.Errorf("%s.%s is missing", path, key)
+				continue
+			}
+			assertContains(t, path+"."+key, sub, gotSub)
+			continue
+		}
+		if !reflect.DeepEqual(got[key], value) {
+			t.Errorf("%s.%s is %v, expected %v", path, key, got[key], value)
+		}
+	}
+}
+
+func TestAppendExtraFields(t *testing.T) {
+	bt, err := appendExtraFields([]byte(`{}`), ExtraFields{"b": json.RawMessage(`2`), "a": json.RawMessage(`"x"`), "Id": json.RawMessage(`1`)}, reflect.TypeOf(User{}))
+	if err != nil || string(bt) != `{"a":"x","b":2}` {
+		t.Fatalf("got %s, %v", bt, err)
+	}
+}
+
+func TestExtraFieldsScan(t *testing.T) {
+	data := []byte(` { "i\u0064" : 7, "First_Name":"a", "new":{"s":"}]\"{", "list":[1,{"a":[]}]} ,"name" : "x",
+		"flag":true, "empty":{}, "last":null } `)
+	extra := extraFields(data, reflect.TypeOf(User{}))
+	want := ExtraFields{
+		"new":   json.RawMessage(`{"s":"}]\"{", "list":[1,{"a":[]}]}`),
+		"name":  json.RawMessage(`"x"`),
+		"flag":  json.RawMessage(`true`),
+		"empty": json.RawMessage(`{}`),
+		"last":  json.RawMessage(`null`),
+	}
+	if !reflect.DeepEqual(extra, want) {
+		t.Errorf("unexpected extra fields %q", extra)
+	}
+	if extra := extraFields([]byte(`{"id":1,"is_bot":false}`), reflect.TypeOf(User{})); extra != nil {
+		t.Errorf("expected no extra fields, got %v", extra)
+	}
+}
@@ -3,7 +3,7 @@ package objects
 /*This object represents a forum topic.*/
 type ForumTopic struct {
 	//Unique identifier of the forum topic
-	MessageThreadId int `json:"message_thread_id"`
+	MessageThreadId int64 `json:"message_thread_id"`
 	//Name of the topic
 	Name string `json:"name"`
 	//Color of the topic icon in RGB format
@@ -0,0 +1,210 @@
+// Code generated by apigen from botapi.json. DO NOT EDIT.
+
+package objects
+
+import (
+	"encoding/json"
+	mp "mime/multipart"
+)
+
+/*CopyMessagesArgs contains the arguments of "copyMessages" method.*/
+type CopyMessagesArgs struct {
+	/*Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
+	ChatId ChatID `json:"chat_id"`
+	/*Unique identifier for the target message thread (topic) of the forum; for forum supergroups only*/
+	MessageThreadId int64 `json:"message_thread_id,omitempty"`
+	/*Unique identifier for the chat where the original messages were sent (or channel username in the format @channelusername)*/
+	FromChatId ChatID `json:"from_chat_id"`
+	/*Identifiers of 1-100 messages in the chat from_chat_id to copy. The identifiers must be specified in a strictly increasing order.*/
+	MessageIds []int64 `json:"message_ids"`
+	/*Sends the messages silently. Users will receive a notification with no sound.*/
+	DisableNotification bool `json:"disable_notification,omitempty"`
+	/*Protects the contents of the sent messages from forwarding and saving*/
+	ProtectContent bool `json:"protect_content,omitempty"`
+	/*Pass True to copy the messages without their captions*/
+	RemoveCaption bool `json:"remove_caption,omitempty"`
+}
+
+// ToJson converts this strcut into json to be sent to the API server.
+func (args *CopyMessagesArgs) ToJson() []byte {
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
+func (args *CopyMessagesArgs) ToMultiPart(wr *mp.Writer) {
+	jsonToMultiPart(args.ToJson(), wr)
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *CopyMessagesArgs) SetChatId(chatId json.RawMessage) {
+	_ = json.Unmarshal(chatId, &args.ChatId)
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *CopyMessagesArgs) GetChatId() json.RawMessage {
+	if args.ChatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(args.ChatId)
+	return bt
+}
+
+/*DeleteMessagesArgs contains the arguments of "deleteMessages" method.*/
+type DeleteMessagesArgs struct {
+	/*Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
+	ChatId ChatID `json:"chat_id"`
+	/*Identifiers of 1-100 messages to delete. See deleteMessage for limitations on which messages can be deleted*/
+	MessageIds []int64 `json:"message_ids"`
+}
+
+// ToJson converts this strcut into json to be sent to the API server.
+func (args *DeleteMessagesArgs) ToJson() []byte {
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
+func (args *DeleteMessagesArgs) ToMultiPart(wr *mp.Writer) {
+	jsonToMultiPart(args.ToJson(), wr)
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *DeleteMessagesArgs) SetChatId(chatId json.RawMessage) {
+	_ = json.Unmarshal(chatId, &args.ChatId)
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *DeleteMessagesArgs) GetChatId() json.RawMessage {
+	if args.ChatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(args.ChatId)
+	return bt
+}
+
+/*ForwardMessagesArgs contains the arguments of "forwardMessages" method.*/
+type ForwardMessagesArgs struct {
+	/*Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
+	ChatId ChatID `json:"chat_id"`
+	/*Unique identifier for the target message thread (topic) of the forum; for forum supergroups only*/
+	MessageThreadId int64 `json:"message_thread_id,omitempty"`
+	/*Unique identifier for the chat where the original messages were sent (or channel username in the format @channelusername)*/
+	FromChatId ChatID `json:"from_chat_id"`
+	/*Identifiers of 1-100 messages in the chat from_chat_id to forward. The identifiers must be specified in a strictly increasing order.*/
+	MessageIds []int64 `json:"message_ids"`
+	/*Sends the messages silently. Users will receive a notification with no sound.*/
+	DisableNotification bool `json:"disable_notification,omitempty"`
+	/*Protects the contents of the forwarded messages from forwarding and saving*/
+	ProtectContent bool `json:"protect_content,omitempty"`
+}
+
+// ToJson converts this strcut into json to be sent to the API server.
+func (args *ForwardMessagesArgs) ToJson() []byte {
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
+func (args *ForwardMessagesArgs) ToMultiPart(wr *mp.Writer) {
+	jsonToMultiPart(args.ToJson(), wr)
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *ForwardMessagesArgs) SetChatId(chatId json.RawMessage) {
+	_ = json.Unmarshal(chatId, &args.ChatId)
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *ForwardMessagesArgs) GetChatId() json.RawMessage {
+	if args.ChatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(args.ChatId)
+	return bt
+}
+
+/*GetUserChatBoostsArgs contains the arguments of "getUserChatBoosts" method.*/
+type GetUserChatBoostsArgs struct {
+	/*Unique identifier for the chat or username of the channel (in the format @channelusername)*/
+	ChatId ChatID `json:"chat_id"`
+	/*Unique identifier of the target user*/
+	UserId int64 `json:"user_id"`
+}
+
+// ToJson converts this strcut into json to be sent to the API server.
+func (args *GetUserChatBoostsArgs) ToJson() []byte {
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
+func (args *GetUserChatBoostsArgs) ToMultiPart(wr *mp.Writer) {
+	jsonToMultiPart(args.ToJson(), wr)
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *GetUserChatBoostsArgs) SetChatId(chatId json.RawMessage) {
+	_ = json.Unmarshal(chatId, &args.ChatId)
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *GetUserChatBoostsArgs) GetChatId() json.RawMessage {
+	if args.ChatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(args.ChatId)
+	return bt
+}
+
+/*SetMessageReactionArgs contains the arguments of "setMessageReaction" method.*/
+type SetMessageReactionArgs struct {
+	/*Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
+	ChatId ChatID `json:"chat_id"`
+	/*Identifier of the target message. If the message belongs to a media group, the reaction is set to the first non-deleted message in the group instead.*/
+	MessageId int64 `json:"message_id"`
+	/*New list of reaction types to set on the message. Currently, as non-premium users, bots can set up to one reaction per message. A custom emoji reaction can be used if it is either already present on the message or explicitly allowed by chat administrators.*/
+	Reaction []ReactionType `json:"reaction,omitempty"`
+	/*Pass True to set the reaction with a big animation*/
+	IsBig bool `json:"is_big,omitempty"`
+}
+
+// ToJson converts this strcut into json to be sent to the API server.
+func (args *SetMessageReactionArgs) ToJson() []byte {
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	return bt
+}
+
+// ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
+func (args *SetMessageReactionArgs) ToMultiPart(wr *mp.Writer) {
+	jsonToMultiPart(args.ToJson(), wr)
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *SetMessageReactionArgs) SetChatId(chatId json.RawMessage) {
+	_ = json.Unmarshal(chatId, &args.ChatId)
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetMessageReactionArgs) GetChatId() json.RawMessage {
+	if args.ChatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(args.ChatId)
+	return bt
+}
@@ -0,0 +1,121 @@
+// Code generated by apigen from botapi.json. DO NOT EDIT.
+
+package objects
+
+/*This object contains information about a chat boost.*/
+type ChatBoost struct {
+	/*Unique identifier of the boost*/
+	BoostId string `json:"boost_id"`
+	/*Point in time (Unix timestamp) when the chat was boosted*/
+	AddDate int `json:"add_date"`
+	/*Point in time (Unix timestamp) when the boost will automatically expire, unless the booster's Telegram Premium subscription is prolonged*/
+	ExpirationDate int `json:"expiration_date"`
+	/*Source of the added boost*/
+	Source *ChatBoostSource `json:"source"`
+}
+
+/*This object represents a boost removed from a chat.*/
+type ChatBoostRemoved struct {
+	/*Chat which was boosted*/
+	Chat *Chat `json:"chat"`
+	/*Unique identifier of the boost*/
+	BoostId string `json:"boost_id"`
+	/*Point in time (Unix timestamp) when the boost was removed*/
+	RemoveDate int `json:"remove_date"`
+	/*Source of the removed boost*/
+	Source *ChatBoostSource `json:"source"`
+}
+
+/*
+This object describes the source of a chat boost. It can be one of ChatBoostSourcePremium, ChatBoostSourceGiftCode or ChatBoostSourceGiveaway.
+The fields of all of them are merged into this struct.
+*/
+type ChatBoostSource struct {
+	/*Source of the boost*/
+	Source string `json:"source"`
+	/*User that boosted the chat*/
+	User *User `json:"user,omitempty"`
+	/*Identifier of a message in the chat with the giveaway; the message could have been deleted already. May be 0 if the message isn't sent yet.*/
+	GiveawayMessageId int64 `json:"giveaway_message_id,omitempty"`
+	/*Optional. True, if the giveaway was completed, but there was no user to win the prize*/
+	IsUnclaimed bool `json:"is_unclaimed,omitempty"`
+}
+
+/*This object represents a boost added to a chat or changed.*/
+type ChatBoostUpdated struct {
+	/*Chat which was boosted*/
+	Chat *Chat `json:"chat"`
+	/*Information about the chat boost*/
+	Boost *ChatBoost `json:"boost"`
+}
+
+/*This object represents a unique message identifier.*/
+type MessageId struct {
+	/*Unique message identifier*/
+	MessageId int64 `json:"message_id"`
+}
+
+/*This object represents reaction changes on a message with anonymous reactions.*/
+type MessageReactionCountUpdated struct {
+	/*The chat containing the message*/
+	Chat *Chat `json:"chat"`
+	/*Unique message identifier inside the chat*/
+	MessageId int64 `json:"message_id"`
+	/*Date of the change in Unix time*/
+	Date int `json:"date"`
+	/*List of reactions that are present on the message*/
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+/*This object represents a change of a reaction on a message performed by a user.*/
+type MessageReactionUpdated struct {
+	/*The chat containing the message the user reacted to*/
+	Chat *Chat `json:"chat"`
+	/*Unique identifier of the message inside the chat*/
+	MessageId int64 `json:"message_id"`
+	/*Optional. The user that changed the reaction, if the user isn't anonymous*/
+	User *User `json:"user,omitempty"`
+	/*Optional. The chat on behalf of which the reaction was changed, if the user is anonymous*/
+	ActorChat *Chat `json:"actor_chat,omitempty"`
+	/*Date of the change in Unix time*/
+	Date int `json:"date"`
+	/*Previous list of reaction types that were set by the user*/
+	OldReaction []ReactionType `json:"old_reaction"`
+	/*New list of reaction types that have been set by the user*/
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+/*Represents a reaction added to a message along with the number of times it was added.*/
+type ReactionCount struct {
+	/*Type of the reaction*/
+	Type *ReactionType `json:"type"`
+	/*Number of times the reaction was added*/
+	TotalCount int `json:"total_count"`
+}
+
+/*
+This object describes the type of a reaction. Currently, it can be one of ReactionTypeEmoji or ReactionTypeCustomEmoji.
+The fields of all of them are merged into this struct.
+*/
+type ReactionType struct {
+	/*Type of the reaction*/
+	Type string `json:"type"`
+	/*Reaction emoji.*/
+	Emoji string `json:"emoji,omitempty"`
+	/*Custom emoji identifier*/
+	CustomEmojiId string `json:"custom_emoji_id,omitempty"`
+}
+
+/*This object represents a story.*/
+type Story struct {
+	/*Chat that posted the story*/
+	Chat *Chat `json:"chat"`
+	/*Unique identifier for the story in the chat*/
+	Id int64 `json:"id"`
+}
+
+/*This object represents a list of boosts added to a chat by a user.*/
+type UserChatBoosts struct {
+	/*The list of boosts added to the chat by the user*/
+	Boosts []ChatBoost `json:"boosts"`
+}
@@ -0,0 +1,58 @@
+package objects
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+/*
+NamedReader is a file which is uploaded to the API server. The content is streamed from the reader and the name is used as the file name in the multipart form.
+*os.File implements this interface. Use NewNamedReader to upload the content of any other io.Reader.
+*/
+type NamedReader interface {
+	io.Reader
+	Name() string
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (nr *namedReader) Name() string {
+	return nr.name
+}
+
+/*Seek seeks the underlying reader if it is an io.Seeker. This allows the request to be sent again (for example when the target group has been migrated to a supergroup).*/
+func (nr *namedReader) Seek(offset int64, whence int) (int64, error) {
+	if s, ok := nr.Reader.(io.Seeker); ok {
+		return s.Seek(offset, whence)
+	}
+	return 0, errors.New("reader of \"" + nr.name + "\" is not seekable")
+}
+
+/*NewNamedReader returns a NamedReader which uploads the content of the reader with the given file name.*/
+func NewNamedReader(name string, reader io.Reader) NamedReader {
+	return &namedReader{Reader: reader, name: name}
+}
+
+/*FileName returns the name which is used for the file in the multipart form. Only the base name of the file is used.*/
+func FileName(file NamedReader) string {
+	return filepath.Base(file.Name())
+}
+
+/*AttachName returns the "attach://<file_name>" string which should be passed to the API server to refer to the uploaded file.*/
+func AttachName(file NamedReader) string {
+	return "attach://" + FileName(file)
+}
+
+/*IsNilFile returns true if the file is nil or a nil *os.File.*/
+func IsNilFile(file NamedReader) bool {
+	if file == nil {
+		return true
+	}
+	f, ok := file.(*os.File)
+	return ok && f == nil
+}
@@ -155,7 +155,7 @@ type CallbackQuery struct {
 	/*Sender*/
 	From User `json:"from"`
 	/*Optional. Message with the callback button that originated the query. Note that message content and message date will not be available if the message is too old*/
-	Message Message `json:"message,omitempty"`
+	Message *Message `json:"message,omitempty"`
 	/*Optional. Identifier of the message sent via the bot in inline mode, that originated the query.*/
 	InlineMessageId string `json:"inline_message_id,omitempty"`
 	/*Global identifier, uniquely corresponding to the chat to which the message with the callback button was sent. Useful for high scores in games.*/
@@ -164,6 +164,8 @@ type CallbackQuery struct {
 	Data string `json:"data,omitempty"`
 	/*Optional. Short name of a Game to be returned, serves as the unique identifier for the game*/
 	GameShortName string `json:"game_short_name,omitempty"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
 }
 
 // This object represents an inline button that switches the current user to inline mode in a chosen chat, with an optional default inline query.
@@ -1,5 +1,7 @@
 package objects
 
+import "context"
+
 /*
 This object represents an incoming update.
 At most one of the optional parameters can be present in any given update.
@@ -35,6 +37,31 @@ type Update struct {
 	ChatMember *ChatMemberUpdated `json:"chat_member,omitempty"`
 	/*Optional. A request to join the chat has been sent. The bot must have the can_invite_users administrator right in the chat to receive these updates.*/
 	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request,omitempty"`
+	/*Optional. A reaction to a message was changed by a user. The bot must be an administrator in the chat and must explicitly specify "message_reaction" in the list of allowed_updates to receive these updates. The update isn't received for reactions set by bots.*/
+	MessageReaction *MessageReactionUpdated `json:"message_reaction,omitempty"`
+	/*Optional. Reactions to a message with anonymous reactions were changed. The bot must be an administrator in the chat and must explicitly specify "message_reaction_count" in the list of allowed_updates to receive these updates. The updates are grouped and can be sent with delay up to a few minutes.*/
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+	/*Optional. A chat boost was added or changed. The bot must be an administrator in the chat to receive these updates.*/
+	ChatBoost *ChatBoostUpdated `json:"chat_boost,omitempty"`
+	/*Optional. A boost was removed from a chat. The bot must be an administrator in the chat to receive these updates.*/
+	RemovedChatBoost *ChatBoostRemoved `json:"removed_chat_boost,omitempty"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
+	//The context of this update. It contains the tracing span of the update.
+	ctx context.Context
+}
+
+/*Context returns the context of this update which contains the tracing span of the update. Pass it to bot.WithContext so the API calls made for this update are traced as its children. It is never nil.*/
+func (u *Update) Context() context.Context {
+	if u.ctx == nil {
+		return context.Background()
+	}
+	return u.ctx
+}
+
+/*SetContext sets the context of this update. Middlewares can use it to pass values or a new tracing span to the next middlewares and the handlers.*/
+func (u *Update) SetContext(ctx context.Context) {
+	u.ctx = ctx
 }
 
 /*Returnes the populated field of this update*/
@@ -81,15 +108,44 @@ func (u *Update) GetType() string {
 	if u.ChatJoinRequest != nil {
 		return "chat_join_request"
 	}
+	if u.MessageReaction != nil {
+		return "message_reaction"
+	}
+	if u.MessageReactionCount != nil {
+		return "message_reaction_count"
+	}
+	if u.ChatBoost != nil {
+		return "chat_boost"
+	}
+	if u.RemovedChatBoost != nil {
+		return "removed_chat_boost"
+	}
 	return ""
 }
 
+/*UpdateTypes contains all the types of the updates which GetType method of Update can return.*/
+var UpdateTypes = []string{
+	"message", "edited_message", "channel_post", "edited_channel_post", "inline_query", "chosen_inline_result",
+	"callback_query", "shipping_query", "pre_checkout_query", "poll", "poll_answer", "my_chat_member", "chat_member",
+	"chat_join_request", "message_reaction", "message_reaction_count", "chat_boost", "removed_chat_boost",
+}
+
+/*IsUpdateType returns true if the given string is one of the types of the updates.*/
+func IsUpdateType(updateType string) bool {
+	for _, t := range UpdateTypes {
+		if t == updateType {
+			return true
+		}
+	}
+	return false
+}
+
 /*This object represents a message.*/
 type Message struct {
 	/*Unique message identifier inside this chat*/
-	MessageId int `json:"message_id"`
+	MessageId int64 `json:"message_id"`
 	/*Optional. Unique identifier of a message thread to which the message belongs; for supergroups only*/
-	MessageThreadId int `json:"message_thread_id"`
+	MessageThreadId int64 `json:"message_thread_id"`
 	/*Optional. Sender, empty for messages sent to channels*/
 	From *User `json:"from,omitempty"`
 	/*Optional. Sender of the message, sent on behalf of a chat. The channel itself for channel messages. The supergroup itself for messages from anonymous group administrators. The linked channel for messages automatically forwarded to the discussion group*/
@@ -103,7 +159,7 @@ type Message struct {
 	/*Optional. For messages forwarded from channels or from anonymous administrators, information about the original sender chat*/
 	ForwardFromChat *Chat `json:"forward_from_chat,omitempty"`
 	/*Optional. For messages forwarded from channels, identifier of the original message in the channel*/
-	ForwardFromMessageId int `json:"forward_from_message_id,omitempty"`
+	ForwardFromMessageId int64 `json:"forward_from_message_id,omitempty"`
 	/*Optional. For messages forwarded from channels, signature of the post author if present*/
 	ForwardSignature string `json:"forward_signature,omitempty"`
 	/*Optional. Sender's name for messages forwarded from users who disallow adding a link to their account in forwarded messages*/
@@ -117,7 +173,7 @@ type Message struct {
 	/*Optional. For replies, the original message. Note that the Message object in this field will not contain further reply_to_message fields even if it itself is a reply.*/
 	ReplyToMessage *Message `json:"reply_to_message,omitempty"`
 	/*Optional. Bot through which the message was sent*/
-	ViaBot User `json:"via_bot,omitempty"`
+	ViaBot *User `json:"via_bot,omitempty"`
 	/*Optional. Date the message was last edited in Unix time*/
 	EditDate int `json:"edit_date,omitempty"`
 	/*Optional. True, if the message can't be forwarded*/
@@ -185,9 +241,9 @@ type Message struct {
 	/*Optional. Service message: auto-delete timer settings changed in the chat*/
 	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed,omitempty"`
 	/*Optional. The group has been migrated to a supergroup with the specified identifier. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.*/
-	MigrateToChatId int `json:"migrate_to_chat_id,omitempty"`
+	MigrateToChatId int64 `json:"migrate_to_chat_id,omitempty"`
 	/*Optional. The supergroup has been migrated from a group with the specified identifier. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.*/
-	MigrateFromChatId int `json:"migrate_from_chat_id,omitempty"`
+	MigrateFromChatId int64 `json:"migrate_from_chat_id,omitempty"`
 	/*Optional. Specified message was pinned. Note that the Message object in this field will not contain further reply_to_message fields even if it is itself a reply.*/
 	PinnedMessage *Message `json:"pinned_message,omitempty"`
 	/*Optional. Message is an invoice for a payment, information about the invoice*/
@@ -230,11 +286,13 @@ type Message struct {
 	WebAppData *WebAppData `json:"web_app_data,omitempty"`
 	/*Optional. Inline keyboard attached to the message. login_url buttons are represented as ordinary url buttons.*/
 	ReplyMakrup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
 }
 
 type User struct {
 	/*Unique identifier for this user or bot. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a 64-bit integer or double-precision float type are safe for storing this identifier.*/
-	Id int `json:"id"`
+	Id int64 `json:"id"`
 	/*True, if this user is a bot*/
 	IsBot bool `json:"is_bot"`
 	/*User's or bot's first name*/
@@ -255,6 +313,8 @@ type User struct {
 	CanReadAllGroupMessages bool `json:"can_read_all_group_messages,omitempty"`
 	/*Optional. True, if the bot supports inline queries.*/
 	SuportsInlineQueries bool `json:"supports_inline_queries,omitempty"`
+	/*Extra contains the fields which are not known by this version of the library.*/
+	Extra ExtraFields `json:"-"`
 }
 
 /*Upon receiving a message with this object, Telegram clients will display a reply interface to the user (act as if the user has selected the bot's message and tapped 'Reply'). This can be extremely useful if you want to create user-friendly step-by-step interfaces without having to sacrifice privacy mode.*/
@@ -272,7 +332,7 @@ func (rm *ForceReply) blah() {}
 /*Contains information about why a request was unsuccessful.*/
 type ResponseParameters struct {
 	/*Optional. The group has been migrated to a supergroup with the specified identifier. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.*/
-	MigrateToChatId int `json:"migrate_to_chat_id"`
+	MigrateToChatId int64 `json:"migrate_to_chat_id"`
 	/*ptional. In case of exceeding flood control, the number of seconds left to wait before the request can be repeated*/
 	RetryAfter int `json:"retry_after"`
 }
@@ -299,9 +359,6 @@ type WebhookInfo struct {
 	AllowedUpdates []string `json:"allowed_updates,omitempty"`
 }
 
-// This object represents a message about a forwarded story in the chat. Currently holds no information.
-type Story struct{}
-
 /*Not related to telegram bot api*/
 type ChatUpdate struct {
 	ChatId string
@@ -42,8 +42,10 @@ func (args *SetWebhookArgs) ToJson() []byte {
 func (args *SetWebhookArgs) ToMultiPart(wr *mp.Writer) {
 	fr, _ := wr.CreateFormField("url")
 	_, _ = io.Copy(fr, strings.NewReader(args.URL))
-	fr, _ = wr.CreateFormField("certificate")
-	_, _ = io.Copy(fr, strings.NewReader(args.Certificate))
+	if args.Certificate != "" {
+		fr, _ = wr.CreateFormField("certificate")
+		_, _ = io.Copy(fr, strings.NewReader(args.Certificate))
+	}
 	if args.IPAddress != "" {
 		fr, _ = wr.CreateFormField("ip_address")
 		_, _ = io.Copy(fr, strings.NewReader(args.IPAddress))
@@ -111,7 +113,7 @@ type DefaultSendMethodsArguments struct {
 	/*Sends the message silently. Users will receive a notification with no sound.*/
 	DisableNotification bool `json:"disable_notification"`
 	/*If the message is a reply, ID of the original message*/
-	ReplyToMessageId int `json:"reply_to_message_id,omitempty"`
+	ReplyToMessageId int64 `json:"reply_to_message_id,omitempty"`
 	/*Pass True, if the message should be sent even if the specified replied-to message is not found*/
 	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
 	/*Protects the contents of sent messages from forwarding and saving*/
@@ -119,7 +121,17 @@ type DefaultSendMethodsArguments struct {
 	/*Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.*/
 	ReplyMarkup ReplyMarkup `json:"reply_markup,omitempty"`
 	/*Unique identifier for the target message thread (topic) of the forum; for forum supergroups only*/
-	MessageThreadId int `json:"message_thread_id,omitempty"`
+	MessageThreadId int64 `json:"message_thread_id,omitempty"`
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (df *DefaultSendMethodsArguments) SetChatId(chatId json.RawMessage) {
+	df.ChatId = chatId
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (df *DefaultSendMethodsArguments) GetChatId() json.RawMessage {
+	return df.ChatId
 }
 
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
@@ -130,10 +142,16 @@ func (df *DefaultSendMethodsArguments) toMultiPart(wr *mp.Writer) {
 	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(df.DisableNotification)))
 	if df.ReplyToMessageId != 0 {
 		fw, _ = wr.CreateFormField("reply_to_message_id")
-		_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(df.ReplyToMessageId)))
+		_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(df.ReplyToMessageId, 10)))
 	}
 	fw, _ = wr.CreateFormField("allow_sending_without_reply")
 	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(df.AllowSendingWithoutReply)))
+	fw, _ = wr.CreateFormField("protect_content")
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(df.ProtectContent)))
+	if df.MessageThreadId != 0 {
+		fw, _ = wr.CreateFormField("message_thread_id")
+		_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(df.MessageThreadId, 10)))
+	}
 	if df.ReplyMarkup != nil {
 		fw, _ = wr.CreateFormField("reply_markup")
 		bt, _ := json.Marshal(df.ReplyMarkup)
@@ -177,9 +195,19 @@ type ForwardMessageArgs struct {
 	/*Protects the contents of sent messages from forwarding and saving*/
 	ProtectContent bool `json:"protect_content"`
 	/*Message identifier in the chat specified in from_chat_id*/
-	MessageId int `json:"message_id"`
+	MessageId int64 `json:"message_id"`
 	/*Unique identifier for the target message thread (topic) of the forum; for forum supergroups only*/
-	MessageThreadId int `json:"message_thread_id,omitempty"`
+	MessageThreadId int64 `json:"message_thread_id,omitempty"`
+}
+
+// SetChatId sets the target chat of the arguments. It is used for sending the request again when the chat has been migrated.
+func (args *ForwardMessageArgs) SetChatId(chatId json.RawMessage) {
+	args.ChatId = chatId
+}
+
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *ForwardMessageArgs) GetChatId() json.RawMessage {
+	return args.ChatId
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -205,7 +233,7 @@ type CopyMessageArgs struct {
 	/*A JSON-serialized list of special entities that appear in the new caption, which can be specified instead of parse_mode*/
 	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
 	/*If the message is a reply, ID of the original message*/
-	ReplyToMessageId int `json:"reply_to_message_id,omitempty"`
+	ReplyToMessageId int64 `json:"reply_to_message_id,omitempty"`
 	/*Pass True, if the message should be sent even if the specified replied-to message is not found*/
 	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	/*Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.*/
@@ -254,6 +282,8 @@ func (args *SendPhotoArgs) ToMultiPart(wr *mp.Writer) {
 	args.toMultiPart(wr)
 	fw, _ := wr.CreateFormField("photo")
 	_, _ = io.Copy(fw, strings.NewReader(args.Photo))
+	fw, _ = wr.CreateFormField("has_spoiler")
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(args.HasSpoiler)))
 	if args.Caption != "" {
 		fw, _ = wr.CreateFormField("caption")
 		_, _ = io.Copy(fw, strings.NewReader(args.Caption))
@@ -287,6 +317,10 @@ func (args *SendStickerArgs) ToMultiPart(wr *mp.Writer) {
 	args.toMultiPart(wr)
 	fw, _ := wr.CreateFormField("sticker")
 	_, _ = io.Copy(fw, strings.NewReader(args.Sticker))
+	if args.Emoji != "" {
+		fw, _ = wr.CreateFormField("emoji")
+		_, _ = io.Copy(fw, strings.NewReader(args.Emoji))
+	}
 }
 
 type GetStickerSetArgs struct {
@@ -308,7 +342,7 @@ func (args *GetStickerSetArgs) ToMultiPart(wr *mp.Writer) {
 }
 
 type UploadStickerFileArgs struct {
-	UserId        int           `json:"user_id"`
+	UserId        int64         `json:"user_id"`
 	Sticker       *InputSticker `json:"sticker"`
 	StickerFormat string        `json:"sticker_format"`
 }
@@ -322,7 +356,7 @@ func (args *UploadStickerFileArgs) ToJson() []byte {
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
 func (args *UploadStickerFileArgs) ToMultiPart(wr *mp.Writer) {
 	fw, _ := wr.CreateFormField("user_id")
-	io.Copy(fw, strings.NewReader(strconv.Itoa(args.UserId)))
+	io.Copy(fw, strings.NewReader(strconv.FormatInt(args.UserId, 10)))
 	fw, _ = wr.CreateFormField("sticker")
 	jsn, _ := json.Marshal(args.Sticker)
 	io.Copy(fw, bytes.NewReader(jsn))
@@ -331,7 +365,7 @@ func (args *UploadStickerFileArgs) ToMultiPart(wr *mp.Writer) {
 }
 
 type CreateNewStickerSetArgs struct {
-	UserId          int             `json:"user_id"`
+	UserId          int64           `json:"user_id"`
 	Name            string          `json:"name"`
 	Title           string          `json:"title"`
 	Stickers        []*InputSticker `json:"stickers"`
@@ -349,7 +383,7 @@ func (args *CreateNewStickerSetArgs) ToJson() []byte {
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
 func (args *CreateNewStickerSetArgs) ToMultiPart(wr *mp.Writer) {
 	fw, _ := wr.CreateFormField("user_id")
-	_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.UserId)))
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(args.UserId, 10)))
 	fw, _ = wr.CreateFormField("name")
 	_, _ = io.Copy(fw, strings.NewReader(args.Name))
 	fw, _ = wr.CreateFormField("title")
@@ -366,7 +400,7 @@ func (args *CreateNewStickerSetArgs) ToMultiPart(wr *mp.Writer) {
 }
 
 type AddStickerSetArgs struct {
-	UserId  int           `json:"user_id"`
+	UserId  int64         `json:"user_id"`
 	Name    string        `json:"name"`
 	Sticker *InputSticker `json:"sticker"`
 }
@@ -380,7 +414,7 @@ func (args *AddStickerSetArgs) ToJson() []byte {
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
 func (args *AddStickerSetArgs) ToMultiPart(wr *mp.Writer) {
 	fw, _ := wr.CreateFormField("user_id")
-	_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.UserId)))
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(args.UserId, 10)))
 	fw, _ = wr.CreateFormField("name")
 	_, _ = io.Copy(fw, strings.NewReader(args.Name))
 	fw, _ = wr.CreateFormField("sticker")
@@ -521,7 +555,7 @@ func (args *DeleteStickerSetArgs) ToMultiPart(wr *mp.Writer) {
 
 type SetStickerSetThumbnailArgs struct {
 	Name   string `json:"name"`
-	UserId int    `json:"user_id"`
+	UserId int64  `json:"user_id"`
 	Thumb  string `json:"thumb"`
 }
 
@@ -534,7 +568,7 @@ func (args *SetStickerSetThumbnailArgs) ToJson() []byte {
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
 func (args *SetStickerSetThumbnailArgs) ToMultiPart(wr *mp.Writer) {
 	fw, _ := wr.CreateFormField("user_id")
-	_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.UserId)))
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(args.UserId, 10)))
 	fw, _ = wr.CreateFormField("name")
 	_, _ = io.Copy(fw, strings.NewReader(args.Name))
 	fw, _ = wr.CreateFormField("thumbnail")
@@ -679,6 +713,8 @@ func (args *SendVideoArgs) ToMultiPart(wr *mp.Writer) {
 	args.toMultiPart(wr)
 	fw, _ := wr.CreateFormField("video")
 	_, _ = io.Copy(fw, strings.NewReader(args.Video))
+	fw, _ = wr.CreateFormField("has_spoiler")
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(args.HasSpoiler)))
 	fw, _ = wr.CreateFormField("supports_streaming")
 	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(args.SupportsStreaming)))
 	if args.Duration != 0 {
@@ -734,6 +770,8 @@ func (args *SendAnimationArgs) ToMultiPart(wr *mp.Writer) {
 	args.toMultiPart(wr)
 	fw, _ := wr.CreateFormField("animation")
 	_, _ = io.Copy(fw, strings.NewReader(args.Animation))
+	fw, _ = wr.CreateFormField("has_spoiler")
+	_, _ = io.Copy(fw, strings.NewReader(strconv.FormatBool(args.HasSpoiler)))
 	if args.Duration != 0 {
 		fw, _ = wr.CreateFormField("duration")
 		_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.Duration)))
@@ -857,7 +895,7 @@ func (args *SendVideoNoteArgs) ToMultiPart(wr *mp.Writer) {
 		_, _ = io.Copy(fw, bytes.NewReader(bt))
 	}
 	if args.Length != 0 {
-		fw, _ = wr.CreateFormField("lengt")
+		fw, _ = wr.CreateFormField("length")
 		_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.Length)))
 	}
 }
@@ -920,7 +958,7 @@ type EditMessageLiveLocationArgs struct {
 	/*Required if inline_message_id is not specified. Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
 	ChatId json.RawMessage `json:"chat_id,omitempty"`
 	/*Required if inline_message_id is not specified. Identifier of the message to edit*/
-	MessageId int `json:"message_id,omitempty"`
+	MessageId int64 `json:"message_id,omitempty"`
 	/*Required if chat_id and message_id are not specified. Identifier of the inline message*/
 	InlineMessageId string `json:"inline_message_id,omitempty"`
 	/*Latitude of the location*/
@@ -951,11 +989,16 @@ func (args *EditMessageLiveLocationArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *EditMessageLiveLocationArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type StopMessageLiveLocationArgs struct {
 	/*Required if inline_message_id is not specified. Unique identifier for the target chat or username of the target channel (in the format @channelusername)*/
 	ChatId json.RawMessage `json:"chat_id,omitempty"`
 	/*Required if inline_message_id is not specified. Identifier of the message to edit*/
-	MessageId int `json:"message_id,omitempty"`
+	MessageId int64 `json:"message_id,omitempty"`
 	/*Required if chat_id and message_id are not specified. Identifier of the inline message*/
 	InlineMessageId string `json:"inline_message_id,omitempty"`
 	/*Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.*/
@@ -976,6 +1019,11 @@ func (args *StopMessageLiveLocationArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *StopMessageLiveLocationArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SendVenueArgs struct {
 	DefaultSendMethodsArguments
 	/*Latitude of the location*/
@@ -1095,7 +1143,7 @@ type SendChatActionArgs struct {
 	/*Type of action to broadcast. Choose one, depending on what the user is about to receive: typing for text messages, upload_photo for photos, record_video or upload_video for videos, record_voice or upload_voice for voice notes, upload_document for general files, choose_sticker for stickers, find_location for location data, record_video_note or upload_video_note for video notes.*/
 	Action string `json:"action"`
 	/*Unique identifier for the target message thread; supergroups only*/
-	MessageThreaddId int `json:"message_thread_id"`
+	MessageThreaddId int64 `json:"message_thread_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1112,9 +1160,14 @@ func (args *SendChatActionArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SendChatActionArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type GetUserProfilePhototsArgs struct {
 	/*Unique identifier of the target user*/
-	UserId int `json:"user_id"`
+	UserId int64 `json:"user_id"`
 	/*Sequential number of the first photo to be returned. By default, all photos are returned.*/
 	Offset int `json:"offset,omitempty"`
 	/*Limits the number of photos to be retrieved. Values between 1-100 are accepted. Defaults to 100.*/
@@ -1171,9 +1224,14 @@ func (args *DefaultChatArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *DefaultChatArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type BanChatMemberArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
-	UserId int             `json:"user_id"`
+	UserId int64           `json:"user_id"`
 	/*Date when the user will be unbanned, unix time. If user is banned for more than 366 days or less than 30 seconds from the current time they are considered to be banned forever. Applied for supergroups and channels only.*/
 	UntilDate int `json:"until_date,omitempty"`
 	/*Pass True to delete all messages from the chat for the user that is being removed. If False, the user will be able to see messages in the group that were sent before the user was removed. Always True for supergroups and channels.*/
@@ -1194,9 +1252,14 @@ func (args *BanChatMemberArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *BanChatMemberArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type UnbanChatMemberArgsArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
-	UserId int             `json:"user_id"`
+	UserId int64           `json:"user_id"`
 	/*Do nothing if the user is not banned*/
 	OnlyIfBanned bool `json:"only_if_banned,omitempty"`
 }
@@ -1215,9 +1278,14 @@ func (args *UnbanChatMemberArgsArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *UnbanChatMemberArgsArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type RestrictChatMemberArgs struct {
 	ChatId                        json.RawMessage `json:"chat_id"`
-	UserId                        int             `json:"user_id"`
+	UserId                        int64           `json:"user_id"`
 	Permission                    ChatPermissions `json:"permissions"`
 	UseIndependentChatPermissions bool            `json:"use_independent_chat_permissions"`
 	UntilDate                     int             `json:"until_date,omitempty"`
@@ -1237,9 +1305,14 @@ func (args *RestrictChatMemberArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *RestrictChatMemberArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type PromoteChatMemberArgs struct {
 	ChatId              json.RawMessage `json:"chat_id"`
-	UserId              int             `json:"user_id"`
+	UserId              int64           `json:"user_id"`
 	IsAnonymous         bool            `json:"is_anonymous"`
 	CanManageChat       bool            `json:"can_manage_chat"`
 	CanPostMessages     bool            `json:"can_post_messages"`
@@ -1271,9 +1344,14 @@ func (args *PromoteChatMemberArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *PromoteChatMemberArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatAdministratorCustomTitleArgs struct {
 	ChatId      json.RawMessage `json:"chat_id"`
-	UserId      int             `json:"user_id"`
+	UserId      int64           `json:"user_id"`
 	CustomTitle string          `json:"custom_title"`
 }
 
@@ -1291,9 +1369,14 @@ func (args *SetChatAdministratorCustomTitleArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatAdministratorCustomTitleArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type BanChatSenderChatArgs struct {
 	ChatId       json.RawMessage `json:"chat_id"`
-	SenderChatId int             `json:"sender_chat_id"`
+	SenderChatId int64           `json:"sender_chat_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1310,9 +1393,14 @@ func (args *BanChatSenderChatArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *BanChatSenderChatArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type UnbanChatSenderChatArgs struct {
 	ChatId       json.RawMessage `json:"chat_id"`
-	SenderChatId int             `json:"sender_chat_id"`
+	SenderChatId int64           `json:"sender_chat_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1329,6 +1417,11 @@ func (args *UnbanChatSenderChatArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *UnbanChatSenderChatArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatPermissionsArgs struct {
 	ChatId                        json.RawMessage `json:"chat_id"`
 	Permissions                   ChatPermissions `json:"permissions"`
@@ -1349,6 +1442,11 @@ func (args *SetChatPermissionsArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatPermissionsArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type CreateChatInviteLinkArgs struct {
 	ChatId             json.RawMessage `json:"chat_id"`
 	Name               string          `json:"name,omitempty"`
@@ -1371,6 +1469,11 @@ func (args *CreateChatInviteLinkArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *CreateChatInviteLinkArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type EditChatInviteLinkArgs struct {
 	ChatId             json.RawMessage `json:"chat_id"`
 	InviteLink         string          `json:"invite_link"`
@@ -1394,6 +1497,11 @@ func (args *EditChatInviteLinkArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *EditChatInviteLinkArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type RevokeChatInviteLinkArgs struct {
 	ChatId     json.RawMessage `json:"chat_id"`
 	InviteLink string          `json:"invite_link"`
@@ -1413,9 +1521,14 @@ func (args *RevokeChatInviteLinkArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *RevokeChatInviteLinkArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type ApproveChatJoinRequestArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
-	UserId int             `json:"user_id"`
+	UserId int64           `json:"user_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1432,9 +1545,14 @@ func (args *ApproveChatJoinRequestArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *ApproveChatJoinRequestArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type DeclineChatJoinRequestArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
-	UserId int             `json:"user_id"`
+	UserId int64           `json:"user_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1451,6 +1569,11 @@ func (args *DeclineChatJoinRequestArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *DeclineChatJoinRequestArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatPhotoArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
 	Photo  string          `json:"photo"`
@@ -1470,6 +1593,11 @@ func (args *SetChatPhotoArgs) ToMultiPart(wr *mp.Writer) {
 	_, _ = io.Copy(fw, strings.NewReader(args.Photo))
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatPhotoArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatTitleArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
 	Title  string          `json:"title"`
@@ -1489,6 +1617,11 @@ func (args *SetChatTitleArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatTitleArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatDescriptionArgs struct {
 	ChatId      json.RawMessage `json:"chat_id"`
 	Description string          `json:"description"`
@@ -1508,9 +1641,14 @@ func (args *SetChatDescriptionArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatDescriptionArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type PinChatMessageArgs struct {
 	ChatId              json.RawMessage `json:"chat_id"`
-	MessageId           int             `json:"message_id"`
+	MessageId           int64           `json:"message_id"`
 	DisableNotification bool            `json:"disable_notification"`
 }
 
@@ -1528,9 +1666,14 @@ func (args *PinChatMessageArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *PinChatMessageArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type UnpinChatMessageArgs struct {
 	ChatId    json.RawMessage `json:"chat_id"`
-	MessageId int             `json:"message_id"`
+	MessageId int64           `json:"message_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1547,9 +1690,14 @@ func (args *UnpinChatMessageArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *UnpinChatMessageArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type GetChatMemberArgs struct {
 	ChatId json.RawMessage `json:"chat_id"`
-	UserId int             `json:"user_id"`
+	UserId int64           `json:"user_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1566,6 +1714,11 @@ func (args *GetChatMemberArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *GetChatMemberArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type SetChatStcikerSet struct {
 	ChatId         json.RawMessage `json:"chat_id"`
 	StickerSetName string          `json:"sticker_set_name"`
@@ -1585,6 +1738,11 @@ func (args *SetChatStcikerSet) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetChatStcikerSet) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type AnswerCallbackQueryArgs struct {
 	CallbackQueyId string `json:"callback_query_id"`
 	Text           string `json:"text,omitempty"`
@@ -1647,11 +1805,16 @@ func (args *SetMyCommandsArgs) ToMultiPart(wr *mp.Writer) {
 
 type EditMessageDefaultArgs struct {
 	ChatId          json.RawMessage       `json:"chat_id,omitempty"`
-	MessageId       int                   `json:"message_id,omitempty"`
+	MessageId       int64                 `json:"message_id,omitempty"`
 	InlineMessageId string                `json:"inline_message_id,omitempty"`
 	ReplyMarkup     *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *EditMessageDefaultArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type EditMessageTextArgs struct {
 	EditMessageDefaultArgs
 	Text                  string          `json:"text"`
@@ -1715,7 +1878,7 @@ func (args *EditMessageMediaArgs) ToMultiPart(wr *mp.Writer) {
 	}
 	if args.MessageId != 0 {
 		fw, _ := wr.CreateFormField("message_id")
-		_, _ = io.Copy(fw, strings.NewReader(strconv.Itoa(args.MessageId)))
+		_, _ = io.Copy(fw, strings.NewReader(strconv.FormatInt(args.MessageId, 10)))
 	}
 	if args.InlineMessageId != "" {
 		fw, _ := wr.CreateFormField("inline_message_id")
@@ -1751,7 +1914,7 @@ func (args *EditMessageReplyMakrupArgs) ToMultiPart(wr *mp.Writer) {
 
 type DeleteMessageArgs struct {
 	ChatId    json.RawMessage `json:"chat_id"`
-	MessageId int             `json:"message_id"`
+	MessageId int64           `json:"message_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -1768,9 +1931,14 @@ func (args *DeleteMessageArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *DeleteMessageArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type StopPollArgs struct {
 	ChatId      json.RawMessage       `json:"chat_id"`
-	MessageId   int                   `json:"message_id"`
+	MessageId   int64                 `json:"message_id"`
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 }
 
@@ -1788,6 +1956,11 @@ func (args *StopPollArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *StopPollArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type AnswerInlineQueryArgs struct {
 	InlineQueryId string                    `json:"inline_query_id"`
 	Results       []InlineQueryResult       `json:"results"`
@@ -1892,7 +2065,7 @@ func (args *AnswerPreCheckoutQueryArgs) ToMultiPart(wr *mp.Writer) {
 }
 
 type SetPassportDataErrorsArgs struct {
-	UserId int                    `json:"user_id"`
+	UserId int64                  `json:"user_id"`
 	Errors []PassportElementError `json:"errors"`
 }
 
@@ -1930,12 +2103,12 @@ func (args *SendGameArgs) ToMultiPart(wr *mp.Writer) {
 }
 
 type SetGameScoreArgs struct {
-	UserId             int    `json:"user_id"`
+	UserId             int64  `json:"user_id"`
 	Score              int    `json:"score"`
 	Force              bool   `json:"force"`
 	DisableEditMessage bool   `json:"disable_edit_message"`
-	ChatId             int    `json:"chat_id,omitempty"`
-	MessageId          int    `json:"message_id,omitempty"`
+	ChatId             int64  `json:"chat_id,omitempty"`
+	MessageId          int64  `json:"message_id,omitempty"`
 	InlineMessageId    string `json:"inline_message_id,omitempty"`
 }
 
@@ -1953,10 +2126,18 @@ func (args *SetGameScoreArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *SetGameScoreArgs) GetChatId() json.RawMessage {
+	if args.ChatId == 0 {
+		return nil
+	}
+	return json.RawMessage(strconv.FormatInt(args.ChatId, 10))
+}
+
 type GetGameHighScoresArgs struct {
-	UserId          int    `json:"user_id"`
-	ChatId          int    `json:"chat_id,omitempty"`
-	MessageId       int    `json:"message_id,omitempty"`
+	UserId          int64  `json:"user_id"`
+	ChatId          int64  `json:"chat_id,omitempty"`
+	MessageId       int64  `json:"message_id,omitempty"`
 	InlineMessageId string `json:"inline_message_id,omitempty"`
 }
 
@@ -1974,6 +2155,14 @@ func (args *GetGameHighScoresArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *GetGameHighScoresArgs) GetChatId() json.RawMessage {
+	if args.ChatId == 0 {
+		return nil
+	}
+	return json.RawMessage(strconv.FormatInt(args.ChatId, 10))
+}
+
 type AnswerWebAppQueryArgs struct {
 	WebAppQueryId string            `json:"web_app_query_id"`
 	Result        InlineQueryResult `json:"result,omitempty"`
@@ -2012,6 +2201,14 @@ func (args *ChatMenuButtonArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *ChatMenuButtonArgs) GetChatId() json.RawMessage {
+	if args.ChatId == 0 {
+		return nil
+	}
+	return json.RawMessage(strconv.FormatInt(args.ChatId, 10))
+}
+
 type MyDefaultAdministratorRightsArgs struct {
 	/*Pass True to change the default administrator rights of the bot in channels. Otherwise, the default administrator rights of the bot for groups and supergroups will be changed.*/
 	ForChannels bool `json:"for_channels"`
@@ -2072,9 +2269,14 @@ func (args *CreateForumTopicArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *CreateForumTopicArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type EditForumTopicArgs struct {
 	ChatId            json.RawMessage `json:"chat_id"`
-	MessageThreadId   int             `json:"message_thread_id"`
+	MessageThreadId   int64           `json:"message_thread_id"`
 	Name              string          `json:"name"`
 	IconCustomEmojiId string          `json:"icon_custom_emoji_id,omitempty"`
 }
@@ -2093,9 +2295,14 @@ func (args *EditForumTopicArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *EditForumTopicArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type CloseForumTopicArgs struct {
 	ChatId          json.RawMessage `json:"chat_id"`
-	MessageThreadId int             `json:"message_thread_id"`
+	MessageThreadId int64           `json:"message_thread_id"`
 }
 
 // ToJson converts this strcut into json to be sent to the API server.
@@ -2112,6 +2319,11 @@ func (args *CloseForumTopicArgs) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *CloseForumTopicArgs) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type ReopenForumTopicArgs struct {
 	*CloseForumTopicArgs
 }
@@ -2143,6 +2355,11 @@ func (args *EditGeneralForumTopic) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *EditGeneralForumTopic) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type CloseGeneralForumTopic struct {
 	ChatId json.RawMessage `json:"chat_id"`
 }
@@ -2161,6 +2378,11 @@ func (args *CloseGeneralForumTopic) ToMultiPart(wr *mp.Writer) {
 	//This method arguments are never passed as multipart
 }
 
+// GetChatId returns the target chat of the arguments. It is empty if the chat is not set.
+func (args *CloseGeneralForumTopic) GetChatId() json.RawMessage {
+	return args.ChatId
+}
+
 type ReopenGeneralForumTopic struct {
 	*CloseGeneralForumTopic
 }
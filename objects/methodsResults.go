@@ -11,6 +11,8 @@ type FailureResult struct {
 	Ok          bool   `json:"ok"`
 	ErrorCode   int    `json:"error_code"`
 	Description string `json:"description"`
+	//Optional. Contains information about why the request was unsuccessful.
+	Parameters *ResponseParameters `json:"parameters,omitempty"`
 }
 
 // Result is generic struct conataining results on success
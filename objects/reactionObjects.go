@@ -0,0 +1,11 @@
+package objects
+
+/*EmojiReaction returns a reaction which is based on the given emoji (like "👍").*/
+func EmojiReaction(emoji string) ReactionType {
+	return ReactionType{Type: "emoji", Emoji: emoji}
+}
+
+/*CustomEmojiReaction returns a reaction which is based on the custom emoji with the given identifier.*/
+func CustomEmojiReaction(customEmojiId string) ReactionType {
+	return ReactionType{Type: "custom_emoji", CustomEmojiId: customEmojiId}
+}
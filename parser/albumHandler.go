@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*The maximum number of the items of an album. The album is delivered as soon as it has this many messages.*/
+const maxAlbumSize = 10
+
+/*Collects the messages of the albums (messages with the same media group id) and passes each album to the handler once.*/
+type albumHandler struct {
+	mu       sync.Mutex
+	timeout  time.Duration
+	function func([]*objs.Update)
+	albums   map[string]*pendingAlbum
+	logger   logger.Logger
+}
+
+type pendingAlbum struct {
+	updates []*objs.Update
+	timer   *time.Timer
+}
+
+/*
+SetAlbumHandler sets the handler of the albums. The messages and channel posts which have a media group id are not passed to the other handlers and channels,
+instead they are buffered until no other message of the album is received for "timeout" and then the whole album is passed to the handler, sorted by the message ids.
+If timeout is not positive, one second is used. Passing a nil handler removes the album handler.
+The albums which are still being collected when the handler is replaced or removed are passed to the previous handler.
+*/
+func (up *UpdateParser) SetAlbumHandler(handler func(album []*objs.Update), timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	up.albumMu.Lock()
+	old := up.album
+	up.album = nil
+	if handler != nil {
+		up.album = &albumHandler{timeout: timeout, function: handler, albums: make(map[string]*pendingAlbum), logger: up.logger}
+	}
+	up.albumMu.Unlock()
+	if old != nil {
+		old.flushAll()
+	}
+}
+
+/*FlushAlbums passes the albums which are still being collected to the album handler without waiting for their timeout. It is called when the bot stops.*/
+func (up *UpdateParser) FlushAlbums() {
+	up.albumMu.RLock()
+	ah := up.album
+	up.albumMu.RUnlock()
+	if ah != nil {
+		ah.flushAll()
+	}
+}
+
+func (up *UpdateParser) checkAlbumHandler(update *objs.Update) bool {
+	up.albumMu.RLock()
+	ah := up.album
+	up.albumMu.RUnlock()
+	if ah == nil {
+		return false
+	}
+	msg := update.Message
+	if msg == nil {
+		msg = update.ChannelPost
+	}
+	if msg == nil || msg.MediaGroupId == "" {
+		return false
+	}
+	key := msg.MediaGroupId
+	if msg.Chat != nil {
+		key = strconv.FormatInt(msg.Chat.Id, 10) + ":" + key
+	}
+	ah.add(key, update)
+	return true
+}
+
+func (ah *albumHandler) add(key string, update *objs.Update) {
+	ah.mu.Lock()
+	album := ah.albums[key]
+	if album == nil {
+		album = &pendingAlbum{}
+		ah.albums[key] = album
+		album.timer = time.AfterFunc(ah.timeout, func() { ah.flush(key, album) })
+	} else {
+		album.timer.Reset(ah.timeout)
+	}
+	album.updates = append(album.updates, update)
+	full := len(album.updates) >= maxAlbumSize
+	ah.mu.Unlock()
+	if full {
+		album.timer.Stop()
+		ah.flush(key, album)
+	}
+}
+
+/*Passes all the pending albums to the handler.*/
+func (ah *albumHandler) flushAll() {
+	ah.mu.Lock()
+	pending := make(map[string]*pendingAlbum, len(ah.albums))
+	for key, album := range ah.albums {
+		album.timer.Stop()
+		pending[key] = album
+	}
+	ah.mu.Unlock()
+	if len(pending) != 0 {
+		ah.logger.Info("Passing the pending albums to the album handler", logger.Any("albums", len(pending)))
+	}
+	for key, album := range pending {
+		ah.flush(key, album)
+	}
+}
+
+/*
+Passes the album to the handler if it has not been passed yet.
+The album is passed after the spans of its updates have ended, so the handler gets a new span which is not a child of them.
+*/
+func (ah *albumHandler) flush(key string, album *pendingAlbum) {
+	ah.mu.Lock()
+	if ah.albums[key] != album {
+		ah.mu.Unlock()
+		return
+	}
+	delete(ah.albums, key)
+	updates := album.updates
+	ah.mu.Unlock()
+	sort.SliceStable(updates, func(i, j int) bool {
+		return albumMessageId(updates[i]) < albumMessageId(updates[j])
+	})
+	startHandler(context.Background(), "album", func() { ah.function(updates) }, updates...)
+}
+
+func albumMessageId(update *objs.Update) int64 {
+	if update.Message != nil {
+		return update.Message.MessageId
+	}
+	return update.ChannelPost.MessageId
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	objs "github.com/hamidteimouri/telego/objects"
+	"github.com/hamidteimouri/telego/tracing"
+)
+
+func albumUpdate(chatId, messageId int64, groupId string) *objs.Update {
+	return &objs.Update{Message: &objs.Message{MessageId: messageId, MediaGroupId: groupId, Chat: &objs.Chat{Id: chatId}}}
+}
+
+func TestAlbumHandler(t *testing.T) {
+	up, _, _ := newTestParser()
+	albums := make(chan []*objs.Update, 2)
+	up.SetAlbumHandler(func(album []*objs.Update) { albums <- album }, 50*time.Millisecond)
+
+	for _, u := range []*objs.Update{albumUpdate(1, 12, "g"), albumUpdate(1, 10, "g"), albumUpdate(2, 5, "g"), albumUpdate(1, 11, "g")} {
+		if !up.checkHandlers(u) {
+			t.Fatal("the messages of the albums should be handled by the album handler")
+		}
+	}
+	if up.checkAlbumHandler(&objs.Update{Message: &objs.Message{MessageId: 1, Chat: &objs.Chat{Id: 1}}}) {
+		t.Fatal("messages without a media group should not be handled")
+	}
+	got := map[int64][]int64{}
+	for i := 0; i < 2; i++ {
+		select {
+		case album := <-albums:
+			for _, u := range album {
+				got[u.Message.Chat.Id] = append(got[u.Message.Chat.Id], u.Message.MessageId)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("the albums were not delivered")
+		}
+	}
+	if len(got[1]) != 3 || got[1][0] != 10 || got[1][2] != 12 || len(got[2]) != 1 {
+		t.Fatalf("unexpected albums %v", got)
+	}
+}
+
+func TestAlbumHandlerFullAlbum(t *testing.T) {
+	up, _, _ := newTestParser()
+	albums := make(chan []*objs.Update, 1)
+	up.SetAlbumHandler(func(album []*objs.Update) { albums <- album }, time.Hour)
+	for i := int64(1); i <= maxAlbumSize; i++ {
+		up.checkAlbumHandler(albumUpdate(1, i, "full"))
+	}
+	select {
+	case album := <-albums:
+		if len(album) != maxAlbumSize {
+			t.Fatalf("the album has %d messages", len(album))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a full album should be delivered without waiting for the timeout")
+	}
+	up.SetAlbumHandler(nil, 0)
+	if up.checkAlbumHandler(albumUpdate(1, 20, "full")) {
+		t.Fatal("the album handler should be removed")
+	}
+}
+
+func TestAlbumHandlerPendingAlbums(t *testing.T) {
+	up, _, _ := newTestParser()
+	albums := make(chan []*objs.Update, 2)
+	up.SetAlbumHandler(func(album []*objs.Update) { albums <- album }, time.Hour)
+	up.checkAlbumHandler(albumUpdate(1, 2, "stop"))
+	up.checkAlbumHandler(albumUpdate(1, 1, "stop"))
+	up.FlushAlbums()
+	select {
+	case album := <-albums:
+		if len(album) != 2 || album[0].Message.MessageId != 1 {
+			t.Fatalf("unexpected album %v", album)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the pending albums should be passed to the handler when the bot stops")
+	}
+
+	up.checkAlbumHandler(albumUpdate(1, 3, "removed"))
+	replaced := make(chan []*objs.Update, 1)
+	up.SetAlbumHandler(func(album []*objs.Update) { replaced <- album }, time.Hour)
+	select {
+	case album := <-albums:
+		if len(album) != 1 || album[0].Message.MessageId != 3 {
+			t.Fatalf("unexpected album %v", album)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the pending albums should be passed to the previous handler when it is replaced")
+	}
+	up.SetAlbumHandler(nil, 0)
+	up.FlushAlbums()
+	select {
+	case album := <-replaced:
+		t.Fatalf("no album is pending, got %v", album)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAlbumHandlerSpan(t *testing.T) {
+	rec := tracing.NewRecorder()
+	tracing.SetTracer(rec)
+	defer tracing.SetTracer(nil)
+	up, _, _ := newTestParser()
+	albums := make(chan []*objs.Update, 1)
+	up.SetAlbumHandler(func(album []*objs.Update) { albums <- album }, 20*time.Millisecond)
+	for i := int64(1); i <= 2; i++ {
+		u := albumUpdate(1, i, "span")
+		//The update span has ended when the album is delivered.
+		ctx, span := tracing.Start(u.Context(), "telego.update")
+		u.SetContext(ctx)
+		up.checkAlbumHandler(u)
+		span.End()
+	}
+	var album []*objs.Update
+	select {
+	case album = <-albums:
+	case <-time.After(time.Second):
+		t.Fatal("the album was not delivered")
+	}
+	handler, ok := tracing.SpanFromContext(album[0].Context()).(*tracing.RecordedSpan)
+	if !ok || handler.Name != "telego.handler" || handler.Attributes[tracing.AttrHandlerKind] != "album" {
+		t.Fatalf("the updates should have the context of the album handler span, got %#v", tracing.SpanFromContext(album[0].Context()))
+	}
+	if handler.ParentId != 0 {
+		t.Error("the album handler span should not be a child of the ended update spans")
+	}
+	if tracing.SpanFromContext(album[1].Context()) != handler {
+		t.Error("all the updates of the album should have the same context")
+	}
+}
@@ -1,10 +1,14 @@
 package parser
 
 import (
+	"context"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hamidteimouri/telego/metrics"
 	objs "github.com/hamidteimouri/telego/objects"
+	"github.com/hamidteimouri/telego/tracing"
 )
 
 // var handlers = handlerTree{}
@@ -23,6 +27,12 @@ type callbackHandler struct {
 	function     *func(*objs.Update)
 }
 
+/*Handles all the updates of one type (like "message_reaction").*/
+type typeHandler struct {
+	updateType string
+	function   *func(*objs.Update)
+}
+
 func (up *UpdateParser) AddHandler(patern string, handlerFunc func(*objs.Update), chatType ...string) error {
 	hl := handler{chatType: strings.Join(chatType, ","), function: &handlerFunc}
 	rgxp, err := regexp.Compile(patern)
@@ -59,8 +69,21 @@ func (up *UpdateParser) AddChatSharedHandler(requestId int, handler func(*objs.U
 	)
 }
 
+/*AddUpdateTypeHandler adds a handler which is called for all the updates of the given type. The previous handler of the type is replaced.*/
+func (up *UpdateParser) AddUpdateTypeHandler(updateType string, handler func(*objs.Update)) {
+	up.typeHandlers.Add(updateType, &typeHandler{updateType: updateType, function: &handler})
+}
+
 func (up *UpdateParser) checkHandlers(update *objs.Update) bool {
-	if update == nil || update.Message == nil {
+	if update == nil {
+		return false
+	}
+
+	if up.checkTypeHandlers(update) || up.checkAlbumHandler(update) {
+		return true
+	}
+
+	if update.Message == nil {
 		return false
 	}
 
@@ -79,10 +102,20 @@ func (up *UpdateParser) checkHandlers(update *objs.Update) bool {
 	return up.checkTextMsgHandlers(update)
 }
 
+func (up *UpdateParser) checkTypeHandlers(update *objs.Update) bool {
+	updateType := update.GetType()
+	hdl, ok := up.typeHandlers.Load(updateType)
+	if ok && hdl != nil && hdl.function != nil {
+		runHandler(updateType, *hdl.function, update)
+		return true
+	}
+	return false
+}
+
 func (up *UpdateParser) checkCallbackHanlders(update *objs.Update) bool {
 	hdl, ok := up.callbackHandlers.Load(update.CallbackQuery.Data)
 	if ok && hdl != nil {
-		go (*hdl.function)(update)
+		runHandler("callback", *hdl.function, update)
 		return true
 	}
 	return false
@@ -91,7 +124,7 @@ func (up *UpdateParser) checkCallbackHanlders(update *objs.Update) bool {
 func (up *UpdateParser) checkUserSharedHandlers(update *objs.Update) bool {
 	hdl, ok := up.userSharedHandlers.LoadAndDelete(update.Message.UserShared.RequestId)
 	if ok && hdl != nil && hdl.function != nil {
-		go (*hdl.function)(update)
+		runHandler("user_shared", *hdl.function, update)
 		return true
 	}
 	return false
@@ -100,7 +133,7 @@ func (up *UpdateParser) checkUserSharedHandlers(update *objs.Update) bool {
 func (up *UpdateParser) checkChatSharedHandlers(update *objs.Update) bool {
 	hdl, ok := up.chatSharedHandlers.Load(update.Message.ChatShared.RequestId)
 	if ok && hdl != nil && hdl.function != nil {
-		go (*hdl.function)(update)
+		runHandler("chat_shared", *hdl.function, update)
 		return true
 	}
 	return false
@@ -110,9 +143,30 @@ func (up *UpdateParser) checkTextMsgHandlers(update *objs.Update) bool {
 	if update.Message != nil && (update.Message.Text != "" || update.Message.Caption != "") {
 		hndl := up.handlers.GetHandler(update.Message)
 		if hndl != nil {
-			go (*hndl.function)(update)
+			runHandler("text", *hndl.function, update)
 			return true
 		}
 	}
 	return false
 }
+
+/*Starts the span of the handler and executes the handler in a new goroutine while recording its execution time.*/
+func runHandler(kind string, function func(*objs.Update), update *objs.Update) {
+	startHandler(update.Context(), kind, func() { function(update) }, update)
+}
+
+/*Starts the span of the handler as a child of the span in ctx, sets it as the context of the updates and executes the handler in a new goroutine while recording its execution time.*/
+func startHandler(ctx context.Context, kind string, function func(), updates ...*objs.Update) {
+	ctx, span := tracing.Start(ctx, "telego.handler", tracing.String(tracing.AttrHandlerKind, kind))
+	for _, update := range updates {
+		update.SetContext(ctx)
+	}
+	go func() {
+		start := time.Now()
+		defer func() {
+			metrics.HandlerDuration.With(kind).ObserveDuration(time.Since(start))
+			span.End()
+		}()
+		function()
+	}()
+}
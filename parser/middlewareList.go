@@ -2,6 +2,7 @@ package parser
 
 import (
 	"sync"
+	"sync/atomic"
 
 	objs "github.com/hamidteimouri/telego/objects"
 )
@@ -13,12 +14,24 @@ type middlewareListMember struct {
 	internal   func(*objs.Update, func())
 }
 
-func (l *middlewareListMember) execute(up *objs.Update) {
+/*
+Executes the middleware and calls dropped if it returns without calling next. A middleware may call next from another goroutine :
+if next is called before the middleware returns, the rest of the chain decides if the update is dropped, even if it finishes after the chain has returned.
+dropped is called at most once for each update.
+*/
+func (l *middlewareListMember) execute(up *objs.Update, dropped func()) {
+	if l.next == nil {
+		l.internal(up, func() {})
+		return
+	}
+	var called atomic.Bool
 	l.internal(up, func() {
-		if l.next != nil {
-			l.next.execute(up)
-		}
+		called.Store(true)
+		l.next.execute(up, dropped)
 	})
+	if !called.Load() {
+		dropped()
+	}
 }
 
 type middlewareLinkedList struct {
@@ -40,6 +53,8 @@ func (l *middlewareLinkedList) addToBegin(elm func(update *objs.Update, next fun
 	l.Unlock()
 }
 
-func (l *middlewareLinkedList) executeChain(up *objs.Update) {
-	l.first.execute(up)
+/*Executes the chain. dropped is called once if the update does not reach the last middleware.*/
+func (l *middlewareLinkedList) executeChain(up *objs.Update, dropped func()) {
+	var once sync.Once
+	l.first.execute(up, func() { once.Do(dropped) })
 }
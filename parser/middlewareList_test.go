@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	objs "github.com/hamidteimouri/telego/objects"
 )
@@ -32,9 +34,87 @@ func TestMiddleList(t *testing.T) {
 		next()
 	})
 
-	list.executeChain(fakeUpdate)
+	list.executeChain(fakeUpdate, func() { t.Error("the update should not be dropped") })
 
 	if fakeUpdate.Update_id != 4567 || fakeUpdate.Message.MessageId != 4567 || fakeUpdate.Poll.Id != "4567" {
 		t.FailNow()
 	}
 }
+
+func TestMiddleListDrop(t *testing.T) {
+	list := &middlewareLinkedList{}
+	last := false
+	list.addToBegin(func(update *objs.Update, next func()) {
+		last = true
+	})
+	list.addToBegin(func(update *objs.Update, next func()) {
+		if update.Update_id != 0 {
+			next()
+		}
+	})
+
+	dropped := 0
+	list.executeChain(&objs.Update{}, func() { dropped++ })
+	if dropped != 1 || last {
+		t.Fatal("update should have been dropped")
+	}
+	list.executeChain(&objs.Update{Update_id: 1}, func() { dropped++ })
+	if dropped != 1 || !last {
+		t.Fatal("update should have reached the last middleware")
+	}
+}
+
+func TestMiddleListAsync(t *testing.T) {
+	list := &middlewareLinkedList{}
+	reached := make(chan int, 1)
+	list.addToBegin(func(update *objs.Update, next func()) {
+		reached <- update.Update_id
+	})
+	//Waits until it is released and drops the updates with an odd id.
+	entered, release := make(chan bool), make(chan bool)
+	list.addToBegin(func(update *objs.Update, next func()) {
+		entered <- true
+		<-release
+		if update.Update_id%2 == 0 {
+			next()
+		}
+	})
+	//Calls next from another goroutine and returns before the rest of the chain finishes.
+	list.addToBegin(func(update *objs.Update, next func()) {
+		go next()
+		<-entered
+	})
+
+	for _, id := range []int{2, 3} {
+		var dropped atomic.Int32
+		done := make(chan bool)
+		list.executeChain(&objs.Update{Update_id: id}, func() {
+			dropped.Add(1)
+			close(done)
+		})
+		if dropped.Load() != 0 {
+			t.Fatalf("update %d should not be counted as dropped while it is being processed", id)
+		}
+		release <- true
+		if id%2 == 0 {
+			select {
+			case got := <-reached:
+				if got != id {
+					t.Errorf("unexpected update %d", got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the update should reach the last middleware")
+			}
+			continue
+		}
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the update should be dropped by the second middleware")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if n := dropped.Load(); n != 1 {
+			t.Errorf("the update should be counted as dropped once, got %d", n)
+		}
+	}
+}
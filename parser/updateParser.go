@@ -1,12 +1,14 @@
 package parser
 
 import (
-	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/hamidteimouri/telego/configs"
 	"github.com/hamidteimouri/telego/logger"
+	"github.com/hamidteimouri/telego/metrics"
 	objs "github.com/hamidteimouri/telego/objects"
+	"github.com/hamidteimouri/telego/tracing"
 )
 
 type UpdateParser struct {
@@ -17,12 +19,23 @@ type UpdateParser struct {
 	callbackHandlers   threadSafeMap[string, *callbackHandler]
 	userSharedHandlers threadSafeMap[int, *chatRequestHandler]
 	chatSharedHandlers threadSafeMap[int, *chatRequestHandler]
-	logger             *logger.BotLogger
+	typeHandlers       threadSafeMap[string, *typeHandler]
+	albumMu            sync.RWMutex
+	album              *albumHandler
+	logger             logger.Logger
 }
 
 // ExecuteChain executes the chained middlewares
 func (u *UpdateParser) ExecuteChain(up *objs.Update) {
-	middlewares.executeChain(up)
+	updateType := up.GetType()
+	metrics.UpdatesReceived.With(updateType).Inc()
+	ctx, span := tracing.Start(up.Context(), "telego.update", tracing.Int(tracing.AttrUpdateId, up.Update_id), tracing.String(tracing.AttrUpdateType, updateType))
+	defer span.End()
+	up.SetContext(ctx)
+	middlewares.executeChain(up, func() {
+		metrics.UpdatesDropped.With(updateType, metrics.DropReasonMiddleware).Inc()
+		span.SetAttributes(tracing.String(tracing.AttrDropped, metrics.DropReasonMiddleware))
+	})
 }
 
 // GetUpdateParserMiddleware returns a middleware that processes the given update object.
@@ -31,12 +44,13 @@ func (u *UpdateParser) GetUpdateParserMiddleware(uc *chan *objs.Update, cu *chan
 	return func(up *objs.Update, next func()) {
 		userId, isUserBlocked := u.isUserBlocked(up, cfg)
 		if !isUserBlocked {
-			u.logger.Log("Update", "\t\t\t\t", up.GetType(), "Parsed", logger.HEADER, logger.OKCYAN, logger.OKGREEN)
+			u.logger.Info("Update parsed", logger.UpdateId(up.Update_id), logger.UpdateType(up.GetType()))
 			if !u.checkHandlers(up) && !u.processChat(up, cu) {
-				*uc <- up
+				u.enqueue(metrics.QueueUpdates, func() { *uc <- up })
 			}
 		} else {
-			u.logger.Log("Update", "\t\t\t\t", up.GetType(), fmt.Sprintf("User %d is blocked", userId), logger.HEADER, logger.OKCYAN, logger.FAIL)
+			metrics.UpdatesDropped.With(up.GetType(), metrics.DropReasonBlockedUser).Inc()
+			u.logger.Info("Update dropped, user is blocked", logger.UpdateId(up.Update_id), logger.UpdateType(up.GetType()), logger.Any("user_id", userId))
 		}
 	}
 }
@@ -58,27 +72,45 @@ func (u *UpdateParser) processChat(update *objs.Update, chatUpdateChannel *chan
 		chat = update.ChatMember.Chat
 	case update.ChatJoinRequest != nil:
 		chat = update.ChatJoinRequest.Chat
-	case update.CallbackQuery != nil:
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		//Callback queries of the inline messages don't have a message.
 		chat = update.CallbackQuery.Message.Chat
+	case update.MessageReaction != nil:
+		chat = update.MessageReaction.Chat
+	case update.MessageReactionCount != nil:
+		chat = update.MessageReactionCount.Chat
+	case update.ChatBoost != nil:
+		chat = update.ChatBoost.Chat
+	case update.RemovedChatBoost != nil:
+		chat = update.RemovedChatBoost.Chat
 	}
 	if chat == nil {
 		return false
 	}
-	*chatUpdateChannel <- u.createChatUpdate(chat, update)
+	chatUpdate := u.createChatUpdate(chat, update)
+	u.enqueue(metrics.QueueChatUpdates, func() { *chatUpdateChannel <- chatUpdate })
 	return true
 }
 
+/*Runs send (which sends an update into one of the channels) while counting the update in the size of the queue.*/
+func (u *UpdateParser) enqueue(queue string, send func()) {
+	g := metrics.QueueSize.With(queue)
+	g.Inc()
+	defer g.Dec()
+	send()
+}
+
 func (u *UpdateParser) createChatUpdate(chat *objs.Chat, update *objs.Update) *objs.ChatUpdate {
 	out := objs.ChatUpdate{Update: update}
 	if chat.Type == "channel" {
 		out.ChatId = chat.Username
 	} else {
-		out.ChatId = strconv.Itoa(chat.Id)
+		out.ChatId = strconv.FormatInt(chat.Id, 10)
 	}
 	return &out
 }
 
-func (u *UpdateParser) isUserBlocked(up *objs.Update, cfg *configs.BotConfigs) (int, bool) {
+func (u *UpdateParser) isUserBlocked(up *objs.Update, cfg *configs.BotConfigs) (int64, bool) {
 	switch up.GetType() {
 	case "message":
 		return u.checkBlocked(up.Message.From, cfg)
@@ -96,12 +128,18 @@ func (u *UpdateParser) isUserBlocked(up *objs.Update, cfg *configs.BotConfigs) (
 		return u.checkBlocked(up.PreCheckoutQuery.From, cfg)
 	case "poll_answer":
 		return u.checkBlocked(up.PollAnswer.User, cfg)
+	case "message_reaction":
+		return u.checkBlocked(up.MessageReaction.User, cfg)
 	default:
 		return 0, false
 	}
 }
 
-func (u *UpdateParser) checkBlocked(user *objs.User, cfg *configs.BotConfigs) (int, bool) {
+func (u *UpdateParser) checkBlocked(user *objs.User, cfg *configs.BotConfigs) (int64, bool) {
+	//Anonymous reactions don't have a user.
+	if user == nil {
+		return 0, false
+	}
 	for _, us := range cfg.BlockedUsers {
 		if us.UserID == user.Id {
 			return us.UserID, true
@@ -114,7 +152,7 @@ func (u *UpdateParser) AddMiddleWare(middleware func(update *objs.Update, next f
 	middlewares.addToBegin(middleware)
 }
 
-func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs, botLogger *logger.BotLogger) *UpdateParser {
+func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs, botLogger logger.Logger) *UpdateParser {
 	up := &UpdateParser{
 		uc:                 uc,
 		cu:                 cu,
@@ -123,6 +161,7 @@ func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *c
 		callbackHandlers:   threadSafeMap[string, *callbackHandler]{internal: make(map[string]*callbackHandler)},
 		userSharedHandlers: threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)},
 		chatSharedHandlers: threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)},
+		typeHandlers:       threadSafeMap[string, *typeHandler]{internal: make(map[string]*typeHandler)},
 		logger:             botLogger,
 	}
 
@@ -130,12 +169,13 @@ func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *c
 		func(update *objs.Update, next func()) {
 			userId, isUserBlocked := up.isUserBlocked(update, cfg)
 			if !isUserBlocked {
-				up.logger.Log("Update", "\t\t\t\t", update.GetType(), "Parsed", logger.HEADER, logger.OKCYAN, logger.OKGREEN)
+				up.logger.Info("Update parsed", logger.UpdateId(update.Update_id), logger.UpdateType(update.GetType()))
 				if !up.checkHandlers(update) && !up.processChat(update, cu) {
-					*uc <- update
+					up.enqueue(metrics.QueueUpdates, func() { *uc <- update })
 				}
 			} else {
-				up.logger.Log("Update", "\t\t\t\t", update.GetType(), fmt.Sprintf("User %d is blocked", userId), logger.HEADER, logger.OKCYAN, logger.FAIL)
+				metrics.UpdatesDropped.With(update.GetType(), metrics.DropReasonBlockedUser).Inc()
+				up.logger.Info("Update dropped, user is blocked", logger.UpdateId(update.Update_id), logger.UpdateType(update.GetType()), logger.Any("user_id", userId))
 			}
 		},
 	)
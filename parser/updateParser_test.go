@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hamidteimouri/telego/configs"
+	"github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func newTestParser() (*UpdateParser, chan *objs.Update, chan *objs.ChatUpdate) {
+	uc := make(chan *objs.Update, 1)
+	cu := make(chan *objs.ChatUpdate, 1)
+	cfg := &configs.BotConfigs{BlockedUsers: []configs.BlockedUser{{UserID: 66}}}
+	return CreateUpdateParser(&uc, &cu, cfg, logger.Nop()), uc, cu
+}
+
+func TestReactionAndBoostUpdates(t *testing.T) {
+	raw := map[string]string{
+		"message_reaction":       `{"update_id":1,"message_reaction":{"chat":{"id":-100,"type":"supergroup"},"message_id":3,"date":1,"old_reaction":[],"new_reaction":[{"type":"emoji","emoji":"👍"}]}}`,
+		"message_reaction_count": `{"update_id":2,"message_reaction_count":{"chat":{"id":-100,"type":"supergroup"},"message_id":3,"date":1,"reactions":[{"type":{"type":"emoji","emoji":"👍"},"total_count":2}]}}`,
+		"chat_boost":             `{"update_id":3,"chat_boost":{"chat":{"id":-100,"type":"channel","username":"ch"},"boost":{"boost_id":"b","add_date":1,"expiration_date":2,"source":{"source":"premium","user":{"id":5}}}}}`,
+		"removed_chat_boost":     `{"update_id":4,"removed_chat_boost":{"chat":{"id":-100,"type":"supergroup"},"boost_id":"b","remove_date":3,"source":{"source":"giveaway","giveaway_message_id":9}}}`,
+	}
+	up, _, cu := newTestParser()
+	for updateType, js := range raw {
+		update := &objs.Update{}
+		if err := json.Unmarshal([]byte(js), update); err != nil {
+			t.Fatal(err)
+		}
+		if update.GetType() != updateType {
+			t.Fatalf("expected %s, got %q", updateType, update.GetType())
+		}
+		if !up.processChat(update, &cu) {
+			t.Fatalf("%s update should be sent to its chat", updateType)
+		}
+		chatUpdate := <-cu
+		expected := "-100"
+		if updateType == "chat_boost" {
+			expected = "ch"
+		}
+		if chatUpdate.ChatId != expected {
+			t.Fatalf("%s update was sent to chat %s", updateType, chatUpdate.ChatId)
+		}
+	}
+}
+
+func TestUpdateTypeHandler(t *testing.T) {
+	up, _, _ := newTestParser()
+	called := make(chan *objs.Update, 1)
+	up.AddUpdateTypeHandler("message_reaction", func(u *objs.Update) { called <- u })
+	update := &objs.Update{MessageReaction: &objs.MessageReactionUpdated{Chat: &objs.Chat{Id: 1}}}
+	if !up.checkHandlers(update) {
+		t.Fatal("the handler should handle the update")
+	}
+	select {
+	case u := <-called:
+		if u != update {
+			t.Fatal("the handler received another update")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the handler was not called")
+	}
+	if up.checkHandlers(&objs.Update{ChatBoost: &objs.ChatBoostUpdated{}}) {
+		t.Fatal("there is no handler for chat_boost updates")
+	}
+}
+
+func TestBlockedReactionUser(t *testing.T) {
+	up, _, _ := newTestParser()
+	blocked := &objs.Update{MessageReaction: &objs.MessageReactionUpdated{User: &objs.User{Id: 66}}}
+	if id, ok := up.isUserBlocked(blocked, up.cfg); !ok || id != 66 {
+		t.Fatal("the reaction of a blocked user should be dropped")
+	}
+	anonymous := &objs.Update{MessageReaction: &objs.MessageReactionUpdated{ActorChat: &objs.Chat{Id: 1}}}
+	if _, ok := up.isUserBlocked(anonymous, up.cfg); ok {
+		t.Fatal("anonymous reactions should not be dropped")
+	}
+}
@@ -11,15 +11,17 @@ var Polls = make(map[string]*Poll)
 
 // Poll is an automatic poll.
 type Poll struct {
-	bot                                                                     *Bot
-	chatIdInt, messageId, totalVoterCount                                   int
-	id, question, pollType, explanation, explanationParseMode, chatIdString string
-	options                                                                 []string
-	result                                                                  []objs.PollOption
-	isClosed, isAnonymouse, allowMultipleAnswers                            bool
-	correctOptionId, openPeriod, closeDate                                  int
-	updateChannel                                                           *chan bool
-	explanationEntities                                                     []objs.MessageEntity
+	bot                                                       *Bot
+	chatId                                                    objs.ChatID
+	messageId                                                 int64
+	totalVoterCount                                           int
+	id, question, pollType, explanation, explanationParseMode string
+	options                                                   []string
+	result                                                    []objs.PollOption
+	isClosed, isAnonymouse, allowMultipleAnswers              bool
+	correctOptionId, openPeriod, closeDate                    int
+	updateChannel                                             *chan bool
+	explanationEntities                                       []objs.MessageEntity
 }
 
 /*
@@ -168,9 +170,9 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (p *Poll) Send(silent, protectContent bool, replyTo int) error {
+func (p *Poll) Send(silent, protectContent bool, replyTo int64) error {
 	res, err := p.bot.apiInterface.SendPoll(
-		p.chatIdInt, p.chatIdString, p.question, p.options, p.isClosed, p.isAnonymouse,
+		p.chatId, p.question, p.options, p.isClosed, p.isAnonymouse,
 		p.pollType, p.allowMultipleAnswers, p.correctOptionId, p.explanation, p.explanationParseMode,
 		p.explanationEntities, p.openPeriod, p.closeDate, replyTo, 0, silent, false, protectContent, nil,
 	)
@@ -193,9 +195,9 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
-func (p *Poll) SendAdvanced(replyTo, messageThreadId int, silent, allowSendingWithOutReply, protectContent bool, replyMarkup objs.ReplyMarkup) error {
+func (p *Poll) SendAdvanced(replyTo, messageThreadId int64, silent, allowSendingWithOutReply, protectContent bool, replyMarkup objs.ReplyMarkup) error {
 	res, err := p.bot.apiInterface.SendPoll(
-		p.chatIdInt, p.chatIdString, p.question, p.options, p.isClosed, p.isAnonymouse,
+		p.chatId, p.question, p.options, p.isClosed, p.isAnonymouse,
 		p.pollType, p.allowMultipleAnswers, p.correctOptionId, p.explanation, p.explanationParseMode,
 		p.explanationEntities, p.openPeriod, p.closeDate, replyTo, messageThreadId, silent, allowSendingWithOutReply, protectContent, replyMarkup,
 	)
@@ -214,7 +216,7 @@ func (p *Poll) SendAdvanced(replyTo, messageThreadId int, silent, allowSendingWi
 /*Stop stops the poll*/
 func (p *Poll) Stop() error {
 	_, err := p.bot.apiInterface.StopPoll(
-		p.chatIdInt, p.chatIdString, p.messageId, nil,
+		p.chatId, p.messageId, nil,
 	)
 	return err
 }
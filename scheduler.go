@@ -0,0 +1,504 @@
+package telego
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+// The actions of a scheduled job.
+const (
+	JobSendMessage   = "send_message"
+	JobEditText      = "edit_text"
+	JobDeleteMessage = "delete_message"
+)
+
+// ScheduledJob is a message action which is run at a future time or repeatedly on a cron expression. Jobs are stored as JSON in the job store.
+type ScheduledJob struct {
+	/*Unique id of the job. It is generated when the job is scheduled.*/
+	Id string `json:"id"`
+	/*The action of the job. JobSendMessage, JobEditText or JobDeleteMessage.*/
+	Action string `json:"action"`
+	/*The target chat.*/
+	ChatId objs.ChatID `json:"chat_id"`
+	/*The target message of JobEditText and JobDeleteMessage.*/
+	MessageId int64 `json:"message_id,omitempty"`
+	/*The text of JobSendMessage and JobEditText.*/
+	Text      string `json:"text,omitempty"`
+	ParseMode string `json:"parse_mode,omitempty"`
+	/*The inline keyboard attached to the message. Optional.*/
+	ReplyMarkup    *objs.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	Silent         bool                       `json:"silent,omitempty"`
+	ProtectContent bool                       `json:"protect_content,omitempty"`
+	/*The next time the job is run.*/
+	RunAt time.Time `json:"run_at"`
+	/*If not empty, the job is run repeatedly on this cron expression (for example "0 9 * * 1" for every Monday at 9:00) instead of once.*/
+	Cron string `json:"cron,omitempty"`
+	/*The number of failed runs of a one time job. The job is retried later if it fails because of the network, the flood control or the API server.*/
+	Attempts int `json:"attempts,omitempty"`
+}
+
+/*The number of times a one time job is run before it is dropped, and the delay before the first retry. The delay is doubled after each failure.*/
+var (
+	maxJobAttempts = 5
+	jobRetryDelay  = time.Minute
+)
+
+// JobStore keeps the scheduled jobs so they survive restarts.
+type JobStore interface {
+	// Save adds the job or replaces the job with the same id.
+	Save(job *ScheduledJob) error
+	// Delete removes the job with the given id. Deleting a job which does not exist is not an error.
+	Delete(id string) error
+	// List returns all the jobs.
+	List() ([]*ScheduledJob, error)
+}
+
+// MemoryJobStore keeps the jobs in memory. Jobs are lost when the program stops.
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*ScheduledJob
+}
+
+// NewMemoryJobStore returns a new empty MemoryJobStore.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]*ScheduledJob)}
+}
+
+// Save adds the job or replaces the job with the same id.
+func (ms *MemoryJobStore) Save(job *ScheduledJob) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	cp := *job
+	ms.jobs[job.Id] = &cp
+	return nil
+}
+
+// Delete removes the job with the given id.
+func (ms *MemoryJobStore) Delete(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.jobs, id)
+	return nil
+}
+
+// List returns all the jobs.
+func (ms *MemoryJobStore) List() ([]*ScheduledJob, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	out := make([]*ScheduledJob, 0, len(ms.jobs))
+	for _, job := range ms.jobs {
+		cp := *job
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+// FileJobStore keeps the jobs in a JSON file. The whole file is rewritten on each change.
+type FileJobStore struct {
+	mem  *MemoryJobStore
+	path string
+}
+
+// NewFileJobStore returns a job store which keeps the jobs in the given file. The jobs already in the file are loaded.
+func NewFileJobStore(path string) (*FileJobStore, error) {
+	fs := &FileJobStore{mem: NewMemoryJobStore(), path: path}
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fs, nil
+		}
+		return nil, err
+	}
+	var jobs []*ScheduledJob
+	if err := json.Unmarshal(bts, &jobs); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		fs.mem.jobs[job.Id] = job
+	}
+	return fs, nil
+}
+
+// Save adds the job or replaces the job with the same id.
+func (fs *FileJobStore) Save(job *ScheduledJob) error {
+	fs.mem.Save(job)
+	return fs.flush()
+}
+
+// Delete removes the job with the given id.
+func (fs *FileJobStore) Delete(id string) error {
+	fs.mem.Delete(id)
+	return fs.flush()
+}
+
+// List returns all the jobs.
+func (fs *FileJobStore) List() ([]*ScheduledJob, error) {
+	return fs.mem.List()
+}
+
+/*Writes all the jobs into a temporary file and renames it to the store file, so a crash while writing does not corrupt the store.*/
+func (fs *FileJobStore) flush() error {
+	fs.mem.mu.Lock()
+	defer fs.mem.mu.Unlock()
+	jobs := make([]*ScheduledJob, 0, len(fs.mem.jobs))
+	for _, job := range fs.mem.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	bts, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := fs.path + ".tmp"
+	if err := os.WriteFile(tmp, bts, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fs.path)
+}
+
+/*
+Scheduler runs scheduled jobs (sending, editing and deleting messages) at their time. Jobs are kept in a JobStore, so if the store is persistent (like FileJobStore) the jobs survive restarts.
+Jobs whose time has passed while the program was stopped are run as soon as the scheduler starts.
+*/
+type Scheduler struct {
+	bot   *Bot
+	store JobStore
+
+	mu    sync.Mutex
+	jobs  map[string]*ScheduledJob
+	crons map[string]*cronSchedule
+	/*The one time jobs which are being run. They are kept in the store until they succeed.*/
+	inFlight map[string]bool
+	wake     chan bool
+	stop     chan bool
+	running  bool
+}
+
+/*
+NewScheduler creates a scheduler which keeps its jobs in the given store and loads the jobs already in the store. If store is nil, the jobs are kept in memory. Call Start to start running the jobs.
+Stored cron jobs whose expression can't be parsed or never matches again are logged and removed from the store, so they don't prevent the other jobs from being loaded.
+*/
+func (bot *Bot) NewScheduler(store JobStore) (*Scheduler, error) {
+	if store == nil {
+		store = NewMemoryJobStore()
+	}
+	s := &Scheduler{
+		bot:      bot,
+		store:    store,
+		jobs:     make(map[string]*ScheduledJob),
+		crons:    make(map[string]*cronSchedule),
+		inFlight: make(map[string]bool),
+		wake:     make(chan bool, 1),
+	}
+	jobs, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.Cron != "" {
+			cs, err := parseCron(job.Cron)
+			if err == nil && cs.next(time.Now()).IsZero() {
+				err = errors.New("cron expression never matches")
+			}
+			if err != nil {
+				bot.logger.Warn("Scheduler : Invalid stored job. Removing the job", logger.String("job_id", job.Id), logger.String("cron", job.Cron), logger.Err(err))
+				s.deleteJob(job.Id)
+				continue
+			}
+			next := cs.next(time.Now())
+			if job.RunAt.IsZero() {
+				job.RunAt = next
+			}
+			s.crons[job.Id] = cs
+		}
+		s.jobs[job.Id] = job
+	}
+	return s, nil
+}
+
+/*Start starts running the jobs in a new goroutine.*/
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan bool)
+	go s.run(s.stop)
+}
+
+/*Stop stops running the jobs. The jobs are kept in the store.*/
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		s.running = false
+		close(s.stop)
+	}
+}
+
+/*
+Schedule adds a job to the scheduler and saves it in the store. The id of the job is returned.
+If job.Cron is not empty, RunAt is ignored and the job is run on the cron expression, otherwise it is run once at RunAt.
+*/
+func (s *Scheduler) Schedule(job *ScheduledJob) (string, error) {
+	if job.Action != JobSendMessage && job.Action != JobEditText && job.Action != JobDeleteMessage {
+		return "", errors.New("unknown job action : " + job.Action)
+	}
+	if job.ChatId.IsZero() {
+		return "", errors.New("ChatId of the job is empty")
+	}
+	cp := *job
+	cp.Id = newJobId()
+	var cs *cronSchedule
+	if cp.Cron != "" {
+		var err error
+		cs, err = parseCron(cp.Cron)
+		if err != nil {
+			return "", err
+		}
+		cp.RunAt = cs.next(time.Now())
+		if cp.RunAt.IsZero() {
+			return "", errors.New("cron expression never matches : " + cp.Cron)
+		}
+	}
+	if err := s.store.Save(&cp); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.jobs[cp.Id] = &cp
+	if cs != nil {
+		s.crons[cp.Id] = cs
+	}
+	s.mu.Unlock()
+	s.notify()
+	return cp.Id, nil
+}
+
+/*ScheduleMessage sends a text message to the chat at the given time.*/
+func (s *Scheduler) ScheduleMessage(chatId objs.ChatID, text, parseMode string, keyboard *InlineKeyboard, at time.Time) (string, error) {
+	return s.Schedule(&ScheduledJob{Action: JobSendMessage, ChatId: chatId, Text: text, ParseMode: parseMode, ReplyMarkup: inlineMarkup(keyboard), RunAt: at})
+}
+
+/*ScheduleCronMessage sends a text message to the chat on the given cron expression (for example "0 9 * * *" for every day at 9:00 in local time).*/
+func (s *Scheduler) ScheduleCronMessage(chatId objs.ChatID, text, parseMode string, keyboard *InlineKeyboard, cron string) (string, error) {
+	return s.Schedule(&ScheduledJob{Action: JobSendMessage, ChatId: chatId, Text: text, ParseMode: parseMode, ReplyMarkup: inlineMarkup(keyboard), Cron: cron})
+}
+
+/*ScheduleEdit edits the text of the message at the given time.*/
+func (s *Scheduler) ScheduleEdit(chatId objs.ChatID, messageId int64, text, parseMode string, keyboard *InlineKeyboard, at time.Time) (string, error) {
+	return s.Schedule(&ScheduledJob{Action: JobEditText, ChatId: chatId, MessageId: messageId, Text: text, ParseMode: parseMode, ReplyMarkup: inlineMarkup(keyboard), RunAt: at})
+}
+
+/*ScheduleDelete deletes the message at the given time. Unlike MessageEditor.DeletIn, the deletion survives restarts (if the store is persistent) and can be canceled.*/
+func (s *Scheduler) ScheduleDelete(chatId objs.ChatID, messageId int64, at time.Time) (string, error) {
+	return s.Schedule(&ScheduledJob{Action: JobDeleteMessage, ChatId: chatId, MessageId: messageId, RunAt: at})
+}
+
+/*Cancel removes the job with the given id from the scheduler and the store.*/
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	_, ok := s.jobs[id]
+	delete(s.jobs, id)
+	delete(s.crons, id)
+	s.mu.Unlock()
+	if !ok {
+		return errors.New("job not found : " + id)
+	}
+	err := s.store.Delete(id)
+	s.notify()
+	return err
+}
+
+/*Jobs returns a copy of the scheduled jobs sorted by their next run time.*/
+func (s *Scheduler) Jobs() []ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ScheduledJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		out = append(out, *job)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RunAt.Before(out[j].RunAt) })
+	return out
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- true:
+	default:
+	}
+}
+
+func (s *Scheduler) run(stop chan bool) {
+	for {
+		wait := s.runDueJobs(time.Now())
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+/*
+Runs the jobs whose time has come and returns the time until the next job.
+A one time job stays in the store while it is run and is only deleted when it succeeds or can't be retried, so it is not lost if the program stops or the request fails.
+*/
+func (s *Scheduler) runDueJobs(now time.Time) time.Duration {
+	var due []*ScheduledJob
+	next := time.Hour
+	s.mu.Lock()
+	for id, job := range s.jobs {
+		if s.inFlight[id] {
+			continue
+		}
+		if job.RunAt.After(now) {
+			if d := job.RunAt.Sub(now); d < next {
+				next = d
+			}
+			continue
+		}
+		cp := *job
+		due = append(due, &cp)
+		if cs := s.crons[id]; cs != nil {
+			job.RunAt = cs.next(now)
+			if !job.RunAt.IsZero() {
+				if d := job.RunAt.Sub(now); d < next {
+					next = d
+				}
+				continue
+			}
+			//The expression doesn't match any time in the next years, so the job is run for the last time.
+			s.bot.logger.Warn("Scheduler : Cron expression never matches again. Removing the job", logger.String("job_id", id), logger.String("cron", job.Cron))
+			cp.Cron = ""
+			job.Cron = ""
+			delete(s.crons, id)
+		}
+		s.inFlight[id] = true
+	}
+	s.mu.Unlock()
+	for _, job := range due {
+		if job.Cron == "" {
+			go s.runOnce(job)
+			continue
+		}
+		s.mu.Lock()
+		updated, ok := s.jobs[job.Id]
+		var cp ScheduledJob
+		if ok {
+			cp = *updated
+		}
+		s.mu.Unlock()
+		if ok {
+			s.saveJob(&cp)
+		}
+		go s.runJob(job)
+	}
+	return next
+}
+
+/*Runs a one time job. The job is deleted if it succeeds, otherwise it is run again later if the error is temporary.*/
+func (s *Scheduler) runOnce(job *ScheduledJob) {
+	err := s.runJob(job)
+	defer func() {
+		s.mu.Lock()
+		delete(s.inFlight, job.Id)
+		s.mu.Unlock()
+		s.notify()
+	}()
+	s.mu.Lock()
+	current, ok := s.jobs[job.Id]
+	if !ok {
+		//Canceled while it was run.
+		s.mu.Unlock()
+		return
+	}
+	if err != nil && retryableJobError(err) && current.Attempts+1 < maxJobAttempts {
+		current.Attempts++
+		delay := jobRetryDelay << (current.Attempts - 1)
+		var apiErr *errs.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+		current.RunAt = time.Now().Add(delay)
+		//Saved while the lock is held, so a job canceled in the meantime is not written back to the store.
+		s.saveJob(current)
+		attempts := current.Attempts
+		s.mu.Unlock()
+		s.bot.logger.Warn("Scheduler : Job will be retried", logger.String("job_id", job.Id), logger.Any("attempts", attempts), logger.Any("delay", delay))
+		return
+	}
+	delete(s.jobs, job.Id)
+	s.mu.Unlock()
+	if err != nil {
+		s.bot.logger.Error("Scheduler : Job dropped", logger.String("job_id", job.Id), logger.String("action", job.Action))
+	}
+	s.deleteJob(job.Id)
+}
+
+/*Reports whether a failed job may succeed if it is run again later.*/
+func retryableJobError(err error) bool {
+	return errors.Is(err, errs.ErrNetwork) || errors.Is(err, errs.ErrTooManyRequests) || errors.Is(err, errs.ErrServer)
+}
+
+func (s *Scheduler) deleteJob(id string) {
+	if err := s.store.Delete(id); err != nil {
+		s.bot.logger.Error("Scheduler : Unable to delete the job from the store", logger.String("job_id", id), logger.Err(err))
+	}
+}
+
+func (s *Scheduler) saveJob(job *ScheduledJob) {
+	if err := s.store.Save(job); err != nil {
+		s.bot.logger.Error("Scheduler : Unable to save the job", logger.String("job_id", job.Id), logger.Err(err))
+	}
+}
+
+func (s *Scheduler) runJob(job *ScheduledJob) error {
+	bai := s.bot.apiInterface
+	var err error
+	switch job.Action {
+	case JobSendMessage:
+		var markup objs.ReplyMarkup
+		if job.ReplyMarkup != nil {
+			markup = job.ReplyMarkup
+		}
+		_, err = bai.SendMessage(job.ChatId, job.Text, job.ParseMode, nil, false, job.Silent, false, job.ProtectContent, 0, 0, markup)
+	case JobEditText:
+		_, err = bai.EditMessageText(job.ChatId, job.MessageId, "", job.Text, job.ParseMode, nil, false, job.ReplyMarkup)
+	case JobDeleteMessage:
+		_, err = bai.DeleteMessage(job.ChatId, job.MessageId)
+	}
+	if err != nil {
+		s.bot.logger.Error("Scheduler : Job failed", logger.String("job_id", job.Id), logger.String("action", job.Action), logger.Err(err))
+	}
+	return err
+}
+
+func inlineMarkup(keyboard *InlineKeyboard) *objs.InlineKeyboardMarkup {
+	if keyboard == nil {
+		return nil
+	}
+	markup := keyboard.toInlineKeyboardMarkup()
+	return &markup
+}
+
+func newJobId() string {
+	bts := make([]byte, 8)
+	rand.Read(bts)
+	return hex.EncodeToString(bts)
+}
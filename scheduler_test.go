@@ -0,0 +1,235 @@
+package telego
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func TestFileJobStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	store, err := NewFileJobStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	jobs := []*ScheduledJob{
+		{Id: "a", Action: JobSendMessage, ChatId: objs.IntChatID(-1001), Text: "hello", ParseMode: "HTML", Silent: true, RunAt: runAt,
+			ReplyMarkup: &objs.InlineKeyboardMarkup{InlineKeyboard: [][]*objs.InlineKeyboardButton{{{Text: "ok", CallbackData: "ok"}}}}},
+		{Id: "b", Action: JobEditText, ChatId: objs.UsernameChatID("@channel"), MessageId: 5, Text: "edited", RunAt: runAt, Cron: "0 9 * * 1"},
+		{Id: "c", Action: JobDeleteMessage, ChatId: objs.IntChatID(42), MessageId: 6, RunAt: runAt},
+	}
+	for _, job := range jobs {
+		if err := store.Save(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Delete("c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("missing"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("the temporary file should be renamed")
+	}
+	reloaded, err := NewFileJobStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := reloaded.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]*ScheduledJob)
+	for _, job := range list {
+		got[job.Id] = job
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(got))
+	}
+	for _, job := range jobs[:2] {
+		if !reflect.DeepEqual(got[job.Id], job) {
+			t.Errorf("job %s changed after reloading :\n%+v\n%+v", job.Id, job, got[job.Id])
+		}
+	}
+}
+
+func TestSchedulerRejectsCronWhichNeverMatches(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string { return okResult(true) })
+	s, err := bot.NewScheduler(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ScheduleCronMessage(objs.IntChatID(1), "hi", "", nil, "0 0 31 2 *"); err == nil {
+		t.Error("expected an error for a cron expression which never matches")
+	}
+}
+
+func TestSchedulerDropsInvalidStoredJobs(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string { return okResult(true) })
+	store := NewMemoryJobStore()
+	_ = store.Save(&ScheduledJob{Id: "never", Action: JobSendMessage, ChatId: objs.IntChatID(1), Cron: "0 0 31 2 *"})
+	_ = store.Save(&ScheduledJob{Id: "invalid", Action: JobSendMessage, ChatId: objs.IntChatID(1), Cron: "not a cron"})
+	_ = store.Save(&ScheduledJob{Id: "cron", Action: JobSendMessage, ChatId: objs.IntChatID(1), Cron: "0 9 * * *"})
+	_ = store.Save(&ScheduledJob{Id: "once", Action: JobSendMessage, ChatId: objs.IntChatID(1), RunAt: time.Now().Add(time.Hour)})
+	s, err := bot.NewScheduler(store)
+	if err != nil {
+		t.Fatalf("invalid jobs should not prevent loading the other jobs, got %v", err)
+	}
+	var ids []string
+	for _, job := range s.Jobs() {
+		ids = append(ids, job.Id)
+	}
+	sort.Strings(ids)
+	if !reflect.DeepEqual(ids, []string{"cron", "once"}) {
+		t.Errorf("expected the valid jobs to be loaded, got %v", ids)
+	}
+	if list, _ := store.List(); len(list) != 2 {
+		t.Errorf("the invalid jobs should be removed from the store, got %d jobs", len(list))
+	}
+}
+
+/*Waits until the job is no longer being run.*/
+func waitJobRun(t *testing.T, s *Scheduler, id string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		running := s.inFlight[id]
+		s.mu.Unlock()
+		if !running {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the job is still running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSchedulerRetriesFailedJob(t *testing.T) {
+	var mu sync.Mutex
+	response := errResult(502, "Bad Gateway", nil)
+	bot := newTestBot(t, func(string, *http.Request) string {
+		mu.Lock()
+		defer mu.Unlock()
+		return response
+	})
+	store := NewMemoryJobStore()
+	s, err := bot.NewScheduler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := s.ScheduleMessage(objs.IntChatID(1), "hi", "", nil, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.runDueJobs(time.Now())
+	waitJobRun(t, s, id)
+	list, _ := store.List()
+	if len(list) != 1 || list[0].Attempts != 1 || !list[0].RunAt.After(time.Now()) {
+		t.Fatalf("a job which failed because of the server should be kept in the store and run later, got %+v", list)
+	}
+
+	//The second run succeeds.
+	mu.Lock()
+	response = okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}})
+	mu.Unlock()
+	s.runDueJobs(list[0].RunAt)
+	waitJobRun(t, s, id)
+	if list, _ := store.List(); len(list) != 0 {
+		t.Errorf("the job should be deleted once it succeeds, got %+v", list)
+	}
+	if len(s.Jobs()) != 0 {
+		t.Error("the job should be removed from the scheduler")
+	}
+}
+
+func TestSchedulerDropsJobWhichCantSucceed(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string { return errResult(400, "Bad Request: chat not found", nil) })
+	store := NewMemoryJobStore()
+	s, err := bot.NewScheduler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := s.ScheduleMessage(objs.IntChatID(1), "hi", "", nil, time.Now())
+	s.runDueJobs(time.Now())
+	waitJobRun(t, s, id)
+	if list, _ := store.List(); len(list) != 0 {
+		t.Errorf("a job rejected by the API server should not be retried, got %+v", list)
+	}
+
+	//A temporary failure is retried up to maxJobAttempts times.
+	//The test bots share the API interface, so the scheduler now sends to the new server.
+	newTestBot(t, func(string, *http.Request) string { return errResult(502, "Bad Gateway", nil) })
+	id, _ = s.ScheduleMessage(objs.IntChatID(1), "hi", "", nil, time.Now())
+	for i := 0; i < maxJobAttempts; i++ {
+		s.runDueJobs(time.Now().Add(24 * time.Hour))
+		waitJobRun(t, s, id)
+	}
+	if list, _ := store.List(); len(list) != 0 {
+		t.Errorf("the job should be dropped after %d attempts, got %+v", maxJobAttempts, list)
+	}
+}
+
+func TestSchedulerLoadsCronJob(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string { return okResult(true) })
+	store := NewMemoryJobStore()
+	_ = store.Save(&ScheduledJob{Id: "x", Action: JobSendMessage, ChatId: objs.IntChatID(1), Cron: "0 9 * * *"})
+	s, err := bot.NewScheduler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait := s.runDueJobs(time.Now()); wait <= 0 {
+		t.Errorf("a loaded cron job without a run time should wait for its next time, got %v", wait)
+	}
+	if jobs := s.Jobs(); len(jobs) != 1 || jobs[0].RunAt.IsZero() {
+		t.Errorf("unexpected jobs %+v", jobs)
+	}
+}
+
+func TestSchedulerDropsCronWhichStopsMatching(t *testing.T) {
+	sent := make(chan string, 1)
+	bot := newTestBot(t, func(method string, r *http.Request) string {
+		if method == "sendMessage" {
+			sent <- requestParams(r)["text"]
+		}
+		return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}})
+	})
+	store := NewMemoryJobStore()
+	s, err := bot.NewScheduler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := &ScheduledJob{Id: "x", Action: JobSendMessage, ChatId: objs.IntChatID(1), Text: "last", Cron: "0 0 29 2 *", RunAt: time.Now().Add(-time.Minute)}
+	_ = store.Save(job)
+	s.jobs[job.Id] = job
+	//The schedule is replaced by one which has no time left, so the job is due now but has no next time.
+	s.crons[job.Id], _ = parseCron("0 0 31 2 *")
+	if wait := s.runDueJobs(time.Now()); wait <= 0 {
+		t.Errorf("the scheduler should not run the job again right away, got %v", wait)
+	}
+	select {
+	case text := <-sent:
+		if text != "last" {
+			t.Errorf("unexpected text %q", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job should be run for the last time")
+	}
+	waitJobRun(t, s, job.Id)
+	if len(s.Jobs()) != 0 {
+		t.Error("the job should be removed from the scheduler")
+	}
+	if list, _ := store.List(); len(list) != 0 {
+		t.Error("the job should be removed from the store")
+	}
+}
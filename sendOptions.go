@@ -0,0 +1,245 @@
+package telego
+
+import (
+	"io"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*
+SendOptions contains the optional parameters of the methods of the Sender. Each method uses the options which are supported by the matching Telegram method and ignores the rest.
+It is filled by the SendOption functions like WithReplyTo, Silent and WithKeyboard, so new parameters can be added without breaking the callers.
+*/
+type SendOptions struct {
+	/*The id of the message to reply to and the id of the forum topic to send the message to.*/
+	ReplyTo, MessageThreadId int64
+	/*Send the message even if the replied message is not found.*/
+	AllowSendingWithoutReply bool
+	/*Send the message without notification.*/
+	Silent bool
+	/*The message can't be forwarded or saved.*/
+	ProtectContent bool
+	/*Parse mode and entities of the text or the caption.*/
+	ParseMode string
+	Entities  []objs.MessageEntity
+	Keyboard  MarkUps
+	/*Caption of a media.*/
+	Caption               string
+	HasSpoiler            bool
+	DisableWebPagePreview bool
+	/*Media options.*/
+	Duration, Width, Height, Length                int
+	SupportsStreaming, DisableContentTypeDetection bool
+	Performer, Title                               string
+	Thumbnail                                      InputFile
+	/*The emoji of a sticker.*/
+	Emoji string
+	/*Contact options.*/
+	LastName, VCard string
+	/*Venue options.*/
+	FoursquareId, FoursquareType, GooglePlaceId, GooglePlaceType string
+	/*Location options.*/
+	HorizontalAccuracy            float32
+	Heading, ProximityAlertRadius int
+	/*The invoice options. Only used by the "Invoice" method.*/
+	Invoice InvoiceOptions
+}
+
+/*InvoiceOptions contains the optional parameters of an invoice.*/
+type InvoiceOptions struct {
+	MaxTipAmount                                                                                              int
+	SuggestedTipAmounts                                                                                       []int
+	StartParameter, ProviderData, PhotoURL                                                                    string
+	PhotoSize, PhotoWidth, PhotoHeight                                                                        int
+	NeedName, NeedPhoneNumber, NeedEmail, NeedShippingAddress, SendPhoneNumberToProvider, SendEmailToProvider bool
+	IsFlexible                                                                                                bool
+}
+
+/*SendOption sets an optional parameter of a send method.*/
+type SendOption func(*SendOptions)
+
+/*WithReplyTo sends the message as a reply to the given message.*/
+func WithReplyTo(messageId int64) SendOption {
+	return func(o *SendOptions) { o.ReplyTo = messageId }
+}
+
+/*WithThreadID sends the message to the given forum topic.*/
+func WithThreadID(messageThreadId int64) SendOption {
+	return func(o *SendOptions) { o.MessageThreadId = messageThreadId }
+}
+
+/*AllowSendingWithoutReply sends the message even if the replied message is not found.*/
+func AllowSendingWithoutReply() SendOption {
+	return func(o *SendOptions) { o.AllowSendingWithoutReply = true }
+}
+
+/*WithKeyboard attaches the keyboard to the message.*/
+func WithKeyboard(keyboard MarkUps) SendOption {
+	return func(o *SendOptions) { o.Keyboard = keyboard }
+}
+
+/*Silent sends the message without notification.*/
+func Silent() SendOption {
+	return func(o *SendOptions) { o.Silent = true }
+}
+
+/*Protect protects the content of the message from forwarding and saving.*/
+func Protect() SendOption {
+	return func(o *SendOptions) { o.ProtectContent = true }
+}
+
+/*WithParseMode sets the parse mode of the text or the caption ("HTML", "MarkdownV2" or "Markdown").*/
+func WithParseMode(parseMode string) SendOption {
+	return func(o *SendOptions) { o.ParseMode = parseMode }
+}
+
+/*WithEntities sets the entities of the text or the caption. Use TextFormatter to create them.*/
+func WithEntities(entities []objs.MessageEntity) SendOption {
+	return func(o *SendOptions) { o.Entities = entities }
+}
+
+/*WithCaption sets the caption of a media.*/
+func WithCaption(caption string) SendOption {
+	return func(o *SendOptions) { o.Caption = caption }
+}
+
+/*WithSpoiler covers the media with a spoiler animation.*/
+func WithSpoiler() SendOption {
+	return func(o *SendOptions) { o.HasSpoiler = true }
+}
+
+/*DisableWebPagePreview disables the link previews of a text message.*/
+func DisableWebPagePreview() SendOption {
+	return func(o *SendOptions) { o.DisableWebPagePreview = true }
+}
+
+/*WithDuration sets the duration of a video, an audio, an animation, a voice or a video note in seconds.*/
+func WithDuration(duration int) SendOption {
+	return func(o *SendOptions) { o.Duration = duration }
+}
+
+/*WithSize sets the width and the height of an animation.*/
+func WithSize(width, height int) SendOption {
+	return func(o *SendOptions) { o.Width, o.Height = width, height }
+}
+
+/*WithLength sets the diameter of a video note.*/
+func WithLength(length int) SendOption {
+	return func(o *SendOptions) { o.Length = length }
+}
+
+/*SupportsStreaming marks the video as suitable for streaming.*/
+func SupportsStreaming() SendOption {
+	return func(o *SendOptions) { o.SupportsStreaming = true }
+}
+
+/*DisableContentTypeDetection disables the automatic content type detection of an uploaded document.*/
+func DisableContentTypeDetection() SendOption {
+	return func(o *SendOptions) { o.DisableContentTypeDetection = true }
+}
+
+/*WithPerformer sets the performer of an audio.*/
+func WithPerformer(performer string) SendOption {
+	return func(o *SendOptions) { o.Performer = performer }
+}
+
+/*WithTitle sets the title of an audio.*/
+func WithTitle(title string) SendOption {
+	return func(o *SendOptions) { o.Title = title }
+}
+
+/*WithThumbnail sets the thumbnail of a media. It is ignored if the media does not support thumbnails.*/
+func WithThumbnail(thumbnail InputFile) SendOption {
+	return func(o *SendOptions) { o.Thumbnail = thumbnail }
+}
+
+/*WithEmoji sets the emoji of a sticker.*/
+func WithEmoji(emoji string) SendOption {
+	return func(o *SendOptions) { o.Emoji = emoji }
+}
+
+/*WithLastName sets the last name of a contact.*/
+func WithLastName(lastName string) SendOption {
+	return func(o *SendOptions) { o.LastName = lastName }
+}
+
+/*WithVCard sets the additional data about a contact in the form of a vCard.*/
+func WithVCard(vCard string) SendOption {
+	return func(o *SendOptions) { o.VCard = vCard }
+}
+
+/*WithFoursquare sets the Foursquare identifier and type of a venue.*/
+func WithFoursquare(id, venueType string) SendOption {
+	return func(o *SendOptions) { o.FoursquareId, o.FoursquareType = id, venueType }
+}
+
+/*WithGooglePlace sets the Google Places identifier and type of a venue.*/
+func WithGooglePlace(id, placeType string) SendOption {
+	return func(o *SendOptions) { o.GooglePlaceId, o.GooglePlaceType = id, placeType }
+}
+
+/*WithAccuracy sets the radius of uncertainty for a location, measured in meters (0-1500).*/
+func WithAccuracy(horizontalAccuracy float32) SendOption {
+	return func(o *SendOptions) { o.HorizontalAccuracy = horizontalAccuracy }
+}
+
+/*WithHeading sets the direction in which the user is moving (1-360) for a live location.*/
+func WithHeading(heading int) SendOption {
+	return func(o *SendOptions) { o.Heading = heading }
+}
+
+/*WithProximityAlertRadius sets the maximum distance in meters for proximity alerts about a live location.*/
+func WithProximityAlertRadius(radius int) SendOption {
+	return func(o *SendOptions) { o.ProximityAlertRadius = radius }
+}
+
+/*WithInvoiceOptions sets the optional parameters of an invoice.*/
+func WithInvoiceOptions(invoiceOptions InvoiceOptions) SendOption {
+	return func(o *SendOptions) { o.Invoice = invoiceOptions }
+}
+
+func newSendOptions(opts []SendOption) *SendOptions {
+	o := &SendOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+func (o *SendOptions) replyMarkup() objs.ReplyMarkup {
+	if o.Keyboard == nil {
+		return nil
+	}
+	return o.Keyboard.toMarkUp()
+}
+
+/*InputFile is a file which is sent by the Sender. Use FileIdOrURL or UploadFile to create one.*/
+type InputFile struct {
+	fileIdOrUrl string
+	file        objs.NamedReader
+}
+
+/*FileIdOrURL returns an InputFile of a file that already exists on telegram servers (file id) or a url on the web.*/
+func FileIdOrURL(fileIdOrUrl string) InputFile {
+	return InputFile{fileIdOrUrl: fileIdOrUrl}
+}
+
+/*
+UploadFile returns an InputFile which uploads the given file. An *os.File can be passed directly, use objs.NewNamedReader for other readers.
+The content is streamed to the API server while it is being read.
+*/
+func UploadFile(file objs.NamedReader) InputFile {
+	return InputFile{file: file}
+}
+
+/*UploadReader returns an InputFile which uploads the content of the reader with the given name.*/
+func UploadReader(name string, reader io.Reader) InputFile {
+	return UploadFile(objs.NewNamedReader(name, reader))
+}
+
+/*IsEmpty returns true if no file is set.*/
+func (f InputFile) IsEmpty() bool {
+	return f.fileIdOrUrl == "" && objs.IsNilFile(f.file)
+}
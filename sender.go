@@ -0,0 +1,275 @@
+package telego
+
+import (
+	"errors"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*
+Sender is a tool for sending messages with optional parameters. The required parameters of each method are passed as arguments and the rest are passed as SendOption values :
+
+	bot.Sender().Photo(chatId, telego.FileIdOrURL(fileId), telego.WithCaption("caption"), telego.WithReplyTo(messageId), telego.Silent())
+
+Use Bot.Sender to get one.
+*/
+type Sender struct {
+	bot *Bot
+}
+
+/*Sender returns a Sender which sends messages with option based methods.*/
+func (bot *Bot) Sender() *Sender {
+	return &Sender{bot: bot}
+}
+
+/*
+Message sends a text message to the chat and returns the sent message on success.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithParseMode, WithEntities, DisableWebPagePreview.
+*/
+func (s *Sender) Message(chatId objs.ChatID, text string, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendMessage(
+		chatId, text, o.ParseMode, o.Entities, o.DisableWebPagePreview,
+		o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.ReplyTo, o.MessageThreadId, o.replyMarkup(),
+	)
+}
+
+/*
+Photo sends a photo to the chat.
+
+Supported options : the options of Message method (except DisableWebPagePreview), WithCaption, WithSpoiler.
+*/
+func (s *Sender) Photo(chatId objs.ChatID, photo InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(PHOTO, "sendPhoto", chatId, photo, opts)
+}
+
+/*
+Video sends a video to the chat.
+
+Supported options : the options of Photo method, WithDuration, SupportsStreaming, WithThumbnail.
+*/
+func (s *Sender) Video(chatId objs.ChatID, video InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(VIDEO, "sendVideo", chatId, video, opts)
+}
+
+/*
+Audio sends an audio to the chat.
+
+Supported options : the options of Message method (except DisableWebPagePreview), WithCaption, WithDuration, WithPerformer, WithTitle, WithThumbnail.
+*/
+func (s *Sender) Audio(chatId objs.ChatID, audio InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(AUDIO, "sendAudio", chatId, audio, opts)
+}
+
+/*
+Document sends a document to the chat.
+
+Supported options : the options of Message method (except DisableWebPagePreview), WithCaption, DisableContentTypeDetection, WithThumbnail.
+*/
+func (s *Sender) Document(chatId objs.ChatID, document InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(DOCUMENT, "sendDocument", chatId, document, opts)
+}
+
+/*
+Animation sends an animation (GIF or H.264/MPEG-4 AVC video without sound) to the chat.
+
+Supported options : the options of Photo method, WithDuration, WithSize, WithThumbnail.
+*/
+func (s *Sender) Animation(chatId objs.ChatID, animation InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(ANIMATION, "sendAnimation", chatId, animation, opts)
+}
+
+/*
+Voice sends a voice message to the chat.
+
+Supported options : the options of Message method (except DisableWebPagePreview), WithCaption, WithDuration.
+*/
+func (s *Sender) Voice(chatId objs.ChatID, voice InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(VOICE, "sendVoice", chatId, voice, opts)
+}
+
+/*
+VideoNote sends a video note to the chat.
+
+Supported options : the options of Message method (except DisableWebPagePreview), WithDuration, WithLength, WithThumbnail.
+*/
+func (s *Sender) VideoNote(chatId objs.ChatID, videoNote InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(VIDEONOTE, "sendVideoNote", chatId, videoNote, opts)
+}
+
+/*
+Sticker sends a sticker to the chat.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithEmoji.
+*/
+func (s *Sender) Sticker(chatId objs.ChatID, sticker InputFile, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	return s.sendMedia(STICKER, "sendSticker", chatId, sticker, opts)
+}
+
+func (s *Sender) sendMedia(mediaType MediaType, method string, chatId objs.ChatID, file InputFile, opts []SendOption) (*objs.Result[*objs.Message], error) {
+	if file.IsEmpty() {
+		return nil, &errs.RequiredArgumentError{ArgName: "file", MethodName: method}
+	}
+	o := newSendOptions(opts)
+	ms := &MediaSender{
+		mediaType: mediaType, bot: s.bot, chatId: chatId, replyTo: o.ReplyTo, messageThreadId: o.MessageThreadId,
+		caption: o.Caption, parseMode: o.ParseMode, captionEntities: o.Entities, allowSendingWihoutReply: o.AllowSendingWithoutReply,
+		replyMarkup: o.replyMarkup(), hasSpoiler: o.HasSpoiler, duration: o.Duration, width: o.Width, height: o.Height, length: o.Length,
+		supportsStreaming: o.SupportsStreaming, disableContentTypeDetection: o.DisableContentTypeDetection,
+		performer: o.Performer, title: o.Title, stickerEmoji: o.Emoji,
+	}
+	if !objs.IsNilFile(o.Thumbnail.file) {
+		ms.SetThumbnailReader(o.Thumbnail.file.Name(), o.Thumbnail.file)
+	} else {
+		ms.SetThumbnail(o.Thumbnail.fileIdOrUrl)
+	}
+	if !objs.IsNilFile(file.file) {
+		return ms.sendFile(file.file, o.Silent, o.ProtectContent)
+	}
+	return ms.SendByFileIdOrUrl(file.fileIdOrUrl, o.Silent, o.ProtectContent)
+}
+
+/*
+Album sends the media group to the chat. The options override the reply and the keyboard settings of the media group.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect.
+*/
+func (s *Sender) Album(chatId objs.ChatID, mg *MediaGroup, opts ...SendOption) (*objs.Result[[]objs.Message], error) {
+	if len(mg.media) < 2 {
+		return nil, errors.New("the number os medias should be greater than 1")
+	}
+	o := newSendOptions(opts)
+	replyTo, messageThreadId, replyMarkup := mg.replyTo, mg.messageThreadId, mg.replyMarkup
+	if o.ReplyTo != 0 {
+		replyTo = o.ReplyTo
+	}
+	if o.MessageThreadId != 0 {
+		messageThreadId = o.MessageThreadId
+	}
+	if o.Keyboard != nil {
+		replyMarkup = o.replyMarkup()
+	}
+	return s.bot.apiInterface.SendMediaGroup(
+		chatId, replyTo, messageThreadId, mg.media, o.Silent, mg.allowSendingWihoutReply || o.AllowSendingWithoutReply, o.ProtectContent,
+		replyMarkup, mg.files...,
+	)
+}
+
+/*
+Venue sends a venue to the chat.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithFoursquare, WithGooglePlace.
+*/
+func (s *Sender) Venue(chatId objs.ChatID, latitude, longitude float32, title, address string, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendVenue(
+		chatId, latitude, longitude, title, address, o.FoursquareId, o.FoursquareType, o.GooglePlaceId, o.GooglePlaceType,
+		o.ReplyTo, o.MessageThreadId, o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup(),
+	)
+}
+
+/*
+Contact sends a phone contact to the chat.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithLastName, WithVCard.
+*/
+func (s *Sender) Contact(chatId objs.ChatID, phoneNumber, firstName string, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendContact(
+		chatId, phoneNumber, firstName, o.LastName, o.VCard,
+		o.ReplyTo, o.MessageThreadId, o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup(),
+	)
+}
+
+/*
+Dice sends an animated emoji that will display a random value. Available emojies : “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect.
+*/
+func (s *Sender) Dice(chatId objs.ChatID, emoji string, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendDice(
+		chatId, emoji, o.ReplyTo, o.MessageThreadId, o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup(),
+	)
+}
+
+/*
+Location sends a point on the map (not live) to the chat. To send a live location use "LiveLocation" method.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithAccuracy.
+*/
+func (s *Sender) Location(chatId objs.ChatID, latitude, longitude float32, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendLocation(
+		chatId, latitude, longitude, o.HorizontalAccuracy, 0, 0, 0,
+		o.ReplyTo, o.MessageThreadId, o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup(),
+	)
+}
+
+/*
+LiveLocation sends a live location to the chat and returns it for editing and stopping. "livePeriod" is the period in seconds for which the location will be updated (60-86400).
+
+Supported options : the options of Location method, WithHeading, WithProximityAlertRadius.
+*/
+func (s *Sender) LiveLocation(chatId objs.ChatID, latitude, longitude float32, livePeriod int, opts ...SendOption) (*LiveLocation, error) {
+	o := newSendOptions(opts)
+	ll := &LiveLocation{
+		bot: s.bot, replyTo: o.ReplyTo, messageThreadId: o.MessageThreadId, allowSendingWihoutReply: o.AllowSendingWithoutReply,
+		latitude: latitude, longitude: longitude, livePeriod: livePeriod, horizontalAccuracy: o.HorizontalAccuracy,
+		heading: o.Heading, proximityAlertRadius: o.ProximityAlertRadius, replyMarkUp: o.replyMarkup(),
+	}
+	if _, err := ll.Send(chatId, o.Silent, o.ProtectContent); err != nil {
+		return nil, err
+	}
+	return ll, nil
+}
+
+/*
+Poll sends the poll. Create the poll with "CreatePoll" method of the bot.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect.
+*/
+func (s *Sender) Poll(poll *Poll, opts ...SendOption) error {
+	o := newSendOptions(opts)
+	return poll.SendAdvanced(o.ReplyTo, o.MessageThreadId, o.Silent, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup())
+}
+
+/*
+Invoice sends an invoice to the chat. "prices" is the price breakdown (e.g. product price, tax, discount, delivery cost, delivery tax, bonus, etc.).
+The keyboard should be an inline keyboard and its first button should be a pay button. If it is empty, a pay button is added by Telegram.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect, WithInvoiceOptions.
+*/
+func (s *Sender) Invoice(chatId objs.ChatID, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	var replyMarkup objs.InlineKeyboardMarkup
+	if o.Keyboard != nil {
+		keyboard, ok := o.Keyboard.(*InlineKeyboard)
+		if !ok {
+			return nil, errors.New("the keyboard of an invoice should be an inline keyboard")
+		}
+		if len(keyboard.keys) == 0 || len(keyboard.keys[0]) == 0 || !keyboard.keys[0][0].Pay {
+			return nil, errors.New("first button in the keyboard should be a pay button")
+		}
+		replyMarkup = keyboard.toInlineKeyboardMarkup()
+	}
+	inv := o.Invoice
+	return s.bot.apiInterface.SendInvoice(
+		chatId, title, description, payload, providerToken, currency, prices, inv.MaxTipAmount, inv.SuggestedTipAmounts,
+		inv.StartParameter, inv.ProviderData, inv.PhotoURL, inv.PhotoSize, inv.PhotoWidth, inv.PhotoHeight,
+		inv.NeedName, inv.NeedPhoneNumber, inv.NeedEmail, inv.NeedShippingAddress, inv.SendPhoneNumberToProvider, inv.SendEmailToProvider,
+		inv.IsFlexible, o.Silent, o.ReplyTo, o.MessageThreadId, o.AllowSendingWithoutReply, o.ProtectContent, replyMarkup,
+	)
+}
+
+/*
+Game sends a game to the chat. Games can only be sent to private chats and groups, so the chat is identified by its numeric id.
+
+Supported options : WithReplyTo, WithThreadID, AllowSendingWithoutReply, WithKeyboard, Silent, Protect.
+*/
+func (s *Sender) Game(chatId int64, gameShortName string, opts ...SendOption) (*objs.Result[*objs.Message], error) {
+	o := newSendOptions(opts)
+	return s.bot.apiInterface.SendGame(chatId, gameShortName, o.Silent, o.ReplyTo, o.MessageThreadId, o.AllowSendingWithoutReply, o.ProtectContent, o.replyMarkup())
+}
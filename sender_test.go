@@ -0,0 +1,252 @@
+package telego
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func TestSenderOptions(t *testing.T) {
+	var method string
+	var params map[string]string
+	bot := newTestBot(t, func(m string, r *http.Request) string {
+		method, params = m, requestParams(r)
+		return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}})
+	})
+	s := bot.Sender()
+	chatId := objs.IntChatID(1)
+	keyboard := bot.CreateInlineKeyboard()
+	keyboard.AddCallbackButton("ok", "ok", 1)
+	common := []SendOption{WithReplyTo(5), WithThreadID(7), AllowSendingWithoutReply(), Silent(), Protect(), WithKeyboard(keyboard)}
+	commonParams := map[string]string{
+		"reply_to_message_id": "5", "message_thread_id": "7", "allow_sending_without_reply": "true",
+		"disable_notification": "true", "protect_content": "true",
+	}
+	tests := []struct {
+		name   string
+		send   func(opts ...SendOption) error
+		method string
+		opts   []SendOption
+		want   map[string]string
+	}{
+		{
+			name: "message", method: "sendMessage",
+			send: func(opts ...SendOption) error { _, err := s.Message(chatId, "hi", opts...); return err },
+			opts: []SendOption{WithParseMode("HTML"), WithEntities([]objs.MessageEntity{{Type: "bold", Length: 2}}), DisableWebPagePreview()},
+			want: map[string]string{"text": "hi", "parse_mode": "HTML", "entities": `[{"type":"bold","offset":0,"length":2,"custom_emoji_id":""}]`, "disable_web_page_preview": "true"},
+		},
+		{
+			name: "photo", method: "sendPhoto",
+			send: func(opts ...SendOption) error {
+				_, err := s.Photo(chatId, FileIdOrURL("photo-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithParseMode("HTML"), WithSpoiler()},
+			want: map[string]string{"photo": "photo-id", "caption": "caption", "parse_mode": "HTML", "has_spoiler": "true"},
+		},
+		{
+			name: "photo upload", method: "sendPhoto",
+			send: func(opts ...SendOption) error {
+				_, err := s.Photo(chatId, UploadReader("photo.jpg", strings.NewReader("photo")), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithSpoiler()},
+			want: map[string]string{"photo": "attach://photo.jpg", "caption": "caption", "has_spoiler": "true"},
+		},
+		{
+			name: "video", method: "sendVideo",
+			send: func(opts ...SendOption) error {
+				_, err := s.Video(chatId, FileIdOrURL("video-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithSpoiler(), WithDuration(30), SupportsStreaming(), WithThumbnail(FileIdOrURL("thumb-id"))},
+			want: map[string]string{"video": "video-id", "caption": "caption", "has_spoiler": "true", "duration": "30", "supports_streaming": "true", "thumbnail": "thumb-id"},
+		},
+		{
+			name: "audio", method: "sendAudio",
+			send: func(opts ...SendOption) error {
+				_, err := s.Audio(chatId, FileIdOrURL("audio-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithDuration(60), WithPerformer("performer"), WithTitle("title")},
+			want: map[string]string{"audio": "audio-id", "caption": "caption", "duration": "60", "performer": "performer", "title": "title"},
+		},
+		{
+			name: "document", method: "sendDocument",
+			send: func(opts ...SendOption) error {
+				_, err := s.Document(chatId, FileIdOrURL("document-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), DisableContentTypeDetection()},
+			want: map[string]string{"document": "document-id", "caption": "caption", "disable_content_type_detection": "true"},
+		},
+		{
+			name: "animation", method: "sendAnimation",
+			send: func(opts ...SendOption) error {
+				_, err := s.Animation(chatId, FileIdOrURL("animation-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithSpoiler(), WithDuration(3), WithSize(320, 240)},
+			want: map[string]string{"animation": "animation-id", "caption": "caption", "has_spoiler": "true", "duration": "3", "width": "320", "height": "240"},
+		},
+		{
+			name: "voice", method: "sendVoice",
+			send: func(opts ...SendOption) error {
+				_, err := s.Voice(chatId, FileIdOrURL("voice-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithCaption("caption"), WithDuration(4)},
+			want: map[string]string{"voice": "voice-id", "caption": "caption", "duration": "4"},
+		},
+		{
+			name: "video note", method: "sendVideoNote",
+			send: func(opts ...SendOption) error {
+				_, err := s.VideoNote(chatId, FileIdOrURL("note-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithDuration(5), WithLength(240)},
+			want: map[string]string{"video_note": "note-id", "duration": "5", "length": "240"},
+		},
+		{
+			name: "sticker", method: "sendSticker",
+			send: func(opts ...SendOption) error {
+				_, err := s.Sticker(chatId, FileIdOrURL("sticker-id"), opts...)
+				return err
+			},
+			opts: []SendOption{WithEmoji("😀")},
+			want: map[string]string{"sticker": "sticker-id", "emoji": "😀"},
+		},
+		{
+			name: "venue", method: "sendVenue",
+			send: func(opts ...SendOption) error {
+				_, err := s.Venue(chatId, 1.5, 2.5, "title", "address", opts...)
+				return err
+			},
+			opts: []SendOption{WithFoursquare("fs-id", "fs-type"), WithGooglePlace("gp-id", "gp-type")},
+			want: map[string]string{
+				"latitude": "1.5", "longitude": "2.5", "title": "title", "address": "address",
+				"foursquare_id": "fs-id", "foursquare_type": "fs-type", "google_place_id": "gp-id", "google_place_type": "gp-type",
+			},
+		},
+		{
+			name: "contact", method: "sendContact",
+			send: func(opts ...SendOption) error { _, err := s.Contact(chatId, "+100", "first", opts...); return err },
+			opts: []SendOption{WithLastName("last"), WithVCard("vcard")},
+			want: map[string]string{"phone_number": "+100", "first_name": "first", "last_name": "last", "vcard": "vcard"},
+		},
+		{
+			name: "dice", method: "sendDice",
+			send: func(opts ...SendOption) error { _, err := s.Dice(chatId, "🎲", opts...); return err },
+			want: map[string]string{"emoji": "🎲"},
+		},
+		{
+			name: "location", method: "sendLocation",
+			send: func(opts ...SendOption) error { _, err := s.Location(chatId, 1.5, 2.5, opts...); return err },
+			opts: []SendOption{WithAccuracy(10.5)},
+			want: map[string]string{"latitude": "1.5", "longitude": "2.5", "horizontal_accuracy": "10.5"},
+		},
+		{
+			name: "live location", method: "sendLocation",
+			send: func(opts ...SendOption) error { _, err := s.LiveLocation(chatId, 1.5, 2.5, 60, opts...); return err },
+			opts: []SendOption{WithAccuracy(10.5), WithHeading(90), WithProximityAlertRadius(100)},
+			want: map[string]string{"live_period": "60", "horizontal_accuracy": "10.5", "heading": "90", "proximity_alert_radius": "100"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, params = "", nil
+			if err := tt.send(append(append([]SendOption{}, common...), tt.opts...)...); err != nil {
+				t.Fatal(err)
+			}
+			if method != tt.method {
+				t.Errorf("expected method %s, got %s", tt.method, method)
+			}
+			want := map[string]string{"chat_id": "1"}
+			for key, value := range commonParams {
+				want[key] = value
+			}
+			for key, value := range tt.want {
+				want[key] = value
+			}
+			for key, value := range want {
+				if params[key] != value {
+					t.Errorf("expected %s=%s, got %q", key, value, params[key])
+				}
+			}
+			if !strings.Contains(params["reply_markup"], `"callback_data":"ok"`) {
+				t.Errorf("the keyboard should be sent, got %q", params["reply_markup"])
+			}
+		})
+	}
+}
+
+func TestSenderInvoiceOptions(t *testing.T) {
+	var params map[string]string
+	bot := newTestBot(t, func(_ string, r *http.Request) string {
+		params = requestParams(r)
+		return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}})
+	})
+	keyboard := bot.CreateInlineKeyboard()
+	keyboard.AddPayButton("pay")
+	prices := []objs.LabeledPrice{{Label: "product", Amount: 100}}
+	_, err := bot.Sender().Invoice(objs.IntChatID(1), "title", "description", "payload", "provider", "USD", prices,
+		WithReplyTo(5), WithThreadID(7), AllowSendingWithoutReply(), Silent(), Protect(), WithKeyboard(keyboard),
+		WithInvoiceOptions(InvoiceOptions{
+			MaxTipAmount: 50, SuggestedTipAmounts: []int{10, 20}, StartParameter: "start", ProviderData: "data", PhotoURL: "https://example.com/photo.jpg",
+			PhotoSize: 1000, PhotoWidth: 100, PhotoHeight: 10, NeedName: true, NeedPhoneNumber: true, NeedEmail: true, NeedShippingAddress: true,
+			SendPhoneNumberToProvider: true, SendEmailToProvider: true, IsFlexible: true,
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"chat_id": "1", "title": "title", "description": "description", "payload": "payload", "provider_token": "provider", "currency": "USD",
+		"prices": `[{"label":"product","amount":100}]`, "max_tip_amount": "50", "suggested_tip_amounts": "[10,20]",
+		"start_parameter": "start", "provider_data": "data", "photo_url": "https://example.com/photo.jpg",
+		"photo_size": "1000", "photo_width": "100", "photo_height": "10", "need_name": "true", "need_phone_number": "true",
+		"need_email": "true", "need_shipping_address": "true", "send_phone_number_to_provider": "true", "send_email_to_provider": "true",
+		"is_flexible": "true", "reply_to_message_id": "5", "message_thread_id": "7", "allow_sending_without_reply": "true",
+		"disable_notification": "true", "protect_content": "true",
+	}
+	for key, value := range want {
+		if params[key] != value {
+			t.Errorf("expected %s=%s, got %q", key, value, params[key])
+		}
+	}
+	if !strings.Contains(params["reply_markup"], `"pay":true`) {
+		t.Errorf("the keyboard should be sent, got %q", params["reply_markup"])
+	}
+}
+
+func TestSenderGameOptions(t *testing.T) {
+	var method string
+	var params map[string]string
+	bot := newTestBot(t, func(m string, r *http.Request) string {
+		method, params = m, requestParams(r)
+		return okResult(map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}})
+	})
+	keyboard := bot.CreateInlineKeyboard()
+	keyboard.AddGameButton("play", 1)
+	_, err := bot.Sender().Game(1, "game", WithReplyTo(5), WithThreadID(7), AllowSendingWithoutReply(), Silent(), Protect(), WithKeyboard(keyboard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "sendGame" {
+		t.Errorf("expected sendGame, got %s", method)
+	}
+	want := map[string]string{
+		"chat_id": "1", "game_short_name": "game", "reply_to_message_id": "5", "message_thread_id": "7",
+		"allow_sending_without_reply": "true", "disable_notification": "true", "protect_content": "true",
+	}
+	for key, value := range want {
+		if params[key] != value {
+			t.Errorf("expected %s=%s, got %q", key, value, params[key])
+		}
+	}
+	if !strings.Contains(params["reply_markup"], `"callback_game"`) {
+		t.Errorf("the keyboard should be sent, got %q", params["reply_markup"])
+	}
+}
@@ -2,9 +2,11 @@ package telego
 
 import (
 	"errors"
+	"io"
 	"os"
 
 	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
 	objs "github.com/hamidteimouri/telego/objects"
 )
 
@@ -13,8 +15,8 @@ type StickerSet struct {
 	bot                                     *Bot
 	stickerSet                              *objs.StickerSet
 	initStickers                            []*objs.InputSticker
-	initFiles                               []*os.File
-	userId                                  int
+	initFiles                               []objs.NamedReader
+	userId                                  int64
 	name, title, stickerFormat, stickerType string
 	needsRepainting                         bool
 	created                                 bool
@@ -25,7 +27,7 @@ func (ss *StickerSet) update() {
 	if ss.created && ss != nil {
 		res, err := ss.bot.apiInterface.GetStickerSet(ss.name)
 		if err != nil {
-			ss.bot.logger.GetRaw().Println("Error while updating sticker set.", err.Error())
+			ss.bot.logger.Error("Error while updating sticker set", logger.Err(err))
 		} else {
 			ss.stickerSet = res.Result
 		}
@@ -89,7 +91,7 @@ If not created yet, then the sticker is added to an internal array. The "Create"
 
 userId is the user id of the owner.
 */
-func (ss *StickerSet) AddNewSticker(fileIdOrURL string, userId int, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
+func (ss *StickerSet) AddNewSticker(fileIdOrURL string, userId int64, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
 	inputSticker := &objs.InputSticker{
 		Sticker:      fileIdOrURL,
 		EmojiList:    emojiList,
@@ -104,7 +106,10 @@ func (ss *StickerSet) AddNewSticker(fileIdOrURL string, userId int, emojiList, k
 			nil,
 		)
 		defer ss.update()
-		return res.Ok, err
+		if err != nil {
+			return false, err
+		}
+		return res.Ok, nil
 	}
 	ss.initStickers = append(ss.initStickers, inputSticker)
 	return true, nil
@@ -117,13 +122,21 @@ If not created yet, then the sticker is added to an internal array. The "Create"
 
 userId is the user id of the owner.
 */
-func (ss *StickerSet) AddNewStickerByFile(file *os.File, userId int, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
-	stat, err := file.Stat()
-	if err != nil {
+func (ss *StickerSet) AddNewStickerByFile(file *os.File, userId int64, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
+	if _, err := file.Stat(); err != nil {
 		return false, err
 	}
+	return ss.addNewStickerFile(file, userId, emojiList, keywords, maskPosition)
+}
+
+/*AddNewStickerByReader works like AddNewStickerByFile but takes the content of the reader as a file with the given name.*/
+func (ss *StickerSet) AddNewStickerByReader(name string, reader io.Reader, userId int64, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
+	return ss.addNewStickerFile(objs.NewNamedReader(name, reader), userId, emojiList, keywords, maskPosition)
+}
+
+func (ss *StickerSet) addNewStickerFile(file objs.NamedReader, userId int64, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
 	inputSticker := &objs.InputSticker{
-		Sticker:      "attach://" + stat.Name(),
+		Sticker:      objs.AttachName(file),
 		EmojiList:    emojiList,
 		MaskPosition: maskPosition,
 		KeyWords:     keywords,
@@ -133,10 +146,13 @@ func (ss *StickerSet) AddNewStickerByFile(file *os.File, userId int, emojiList,
 			userId,
 			ss.name,
 			inputSticker,
-			nil,
+			file,
 		)
 		defer ss.update()
-		return res.Ok, err
+		if err != nil {
+			return false, err
+		}
+		return res.Ok, nil
 	}
 	ss.initStickers = append(ss.initStickers, inputSticker)
 	ss.initFiles = append(ss.initFiles, file)
@@ -255,7 +271,7 @@ func (ss *StickerSet) DeleteStickerFromSet(sticker string) (*objs.Result[bool],
 }
 
 /*SetThumb can be used to set the thumbnail of a sticker set using url or file id. Animated thumbnails can be set for animated sticker sets only. Returns True on success.*/
-func (ss *StickerSet) SetThumb(userId int, thumb string) (*objs.Result[bool], error) {
+func (ss *StickerSet) SetThumb(userId int64, thumb string) (*objs.Result[bool], error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
@@ -263,15 +279,23 @@ func (ss *StickerSet) SetThumb(userId int, thumb string) (*objs.Result[bool], er
 }
 
 /*SetThumbByFile can be used to set the thumbnail of a sticker set using a file on the computer. Animated thumbnails can be set for animated sticker sets only. Returns True on success.*/
-func (ss *StickerSet) SetThumbByFile(userId int, thumb *os.File) (*objs.Result[bool], error) {
+func (ss *StickerSet) SetThumbByFile(userId int64, thumb *os.File) (*objs.Result[bool], error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
-	stats, err := thumb.Stat()
-	if err != nil {
+	if _, err := thumb.Stat(); err != nil {
 		return nil, err
 	}
-	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, "attach://"+stats.Name(), userId, thumb)
+	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, objs.AttachName(thumb), userId, thumb)
+}
+
+/*SetThumbByReader works like SetThumbByFile but takes the content of the reader as a file with the given name.*/
+func (ss *StickerSet) SetThumbByReader(userId int64, name string, reader io.Reader) (*objs.Result[bool], error) {
+	if ss == nil {
+		return nil, errors.New("sticker set is nil")
+	}
+	thumb := objs.NewNamedReader(name, reader)
+	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, objs.AttachName(thumb), userId, thumb)
 }
 
 // SetTitle changes this sticker set's title.
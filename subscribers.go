@@ -0,0 +1,191 @@
+package telego
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+// SubscriberStatus is the status of a private chat with the bot.
+type SubscriberStatus int
+
+const (
+	// SubscriberUnknown means the bot has not received any signal about the chat.
+	SubscriberUnknown SubscriberStatus = iota
+	// SubscriberActive means the user has started (or unblocked) the bot.
+	SubscriberActive
+	// SubscriberBlocked means the user has blocked the bot.
+	SubscriberBlocked
+)
+
+func (s SubscriberStatus) String() string {
+	switch s {
+	case SubscriberActive:
+		return "active"
+	case SubscriberBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+SubscriberStore keeps the status of the private chats with the bot and the time of the event which set it.
+The default store keeps the statuses in memory. Implement this interface to keep them in a database.
+*/
+type SubscriberStore interface {
+	// SetStatus sets the status of the chat. at is the time of the event which changed the status.
+	SetStatus(chatId int64, status SubscriberStatus, at time.Time) error
+	// GetStatus returns the status of the chat and the time passed to SetStatus. SubscriberUnknown and the zero time are returned for unknown chats.
+	GetStatus(chatId int64) (SubscriberStatus, time.Time, error)
+	// Chats returns the ids of the chats with the given status.
+	Chats(status SubscriberStatus) ([]int64, error)
+}
+
+type subscriber struct {
+	status SubscriberStatus
+	at     time.Time
+}
+
+// MemorySubscriberStore is a SubscriberStore which keeps the statuses in memory.
+type MemorySubscriberStore struct {
+	mu    sync.RWMutex
+	chats map[int64]subscriber
+}
+
+// NewMemorySubscriberStore returns a new empty MemorySubscriberStore.
+func NewMemorySubscriberStore() *MemorySubscriberStore {
+	return &MemorySubscriberStore{chats: make(map[int64]subscriber)}
+}
+
+// SetStatus sets the status of the chat.
+func (ms *MemorySubscriberStore) SetStatus(chatId int64, status SubscriberStatus, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if status == SubscriberUnknown {
+		delete(ms.chats, chatId)
+	} else {
+		ms.chats[chatId] = subscriber{status: status, at: at}
+	}
+	return nil
+}
+
+// GetStatus returns the status of the chat and the time it was set at.
+func (ms *MemorySubscriberStore) GetStatus(chatId int64) (SubscriberStatus, time.Time, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	sub := ms.chats[chatId]
+	return sub.status, sub.at, nil
+}
+
+// Chats returns the ids of the chats with the given status in ascending order.
+func (ms *MemorySubscriberStore) Chats(status SubscriberStatus) ([]int64, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	out := []int64{}
+	for id, sub := range ms.chats {
+		if sub.status == status {
+			out = append(out, id)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+/*Tracks the status of the private chats from the "my_chat_member" updates and the failed requests.*/
+type subscriberTracker struct {
+	mu       sync.RWMutex
+	store    SubscriberStore
+	handlers []func(chatId int64, status SubscriberStatus)
+	logger   logger.Logger
+	//Makes reading and setting the status atomic, so the handlers are called once when two events change the status at the same time.
+	statusMu sync.Mutex
+}
+
+func (st *subscriberTracker) getStore() SubscriberStore {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.store
+}
+
+/*Sets the status of the chat and calls the handlers if the status has changed. Events older than the stored status are ignored.*/
+func (st *subscriberTracker) setStatus(chatId int64, status SubscriberStatus, at time.Time) {
+	store := st.getStore()
+	st.statusMu.Lock()
+	old, oldAt, err := store.GetStatus(chatId)
+	if err != nil {
+		st.logger.Error("Unable to get the subscriber status", logger.ChatId(chatId), logger.Err(err))
+	}
+	if at.Before(oldAt) || (old == status && !at.After(oldAt)) {
+		st.statusMu.Unlock()
+		return
+	}
+	//The time is saved even if the status has not changed, so older events received later are still ignored.
+	err = store.SetStatus(chatId, status, at)
+	st.statusMu.Unlock()
+	if err != nil {
+		st.logger.Error("Unable to save the subscriber status", logger.ChatId(chatId), logger.Err(err))
+		return
+	}
+	if old == status {
+		return
+	}
+	st.logger.Info("Subscriber status changed", logger.ChatId(chatId), logger.String("status", status.String()))
+	st.mu.RLock()
+	handlers := append([]func(int64, SubscriberStatus){}, st.handlers...)
+	st.mu.RUnlock()
+	for _, handler := range handlers {
+		go handler(chatId, status)
+	}
+}
+
+/*Checks if the update is a "my_chat_member" update of a private chat and updates the status of the chat.*/
+func (st *subscriberTracker) checkUpdate(update *objs.Update) {
+	mcm := update.MyChatMember
+	if mcm == nil || mcm.Chat == nil || mcm.Chat.Type != "private" || mcm.NewChatMember == nil {
+		return
+	}
+	at := time.Unix(int64(mcm.Date), 0)
+	switch mcm.NewChatMember.GetStatus() {
+	case objs.ChatMemberStatusBanned:
+		st.setStatus(mcm.Chat.Id, SubscriberBlocked, at)
+	case objs.ChatMemberStatusMember:
+		st.setStatus(mcm.Chat.Id, SubscriberActive, at)
+	}
+}
+
+/*Called when a request fails because the user has blocked the bot. The time is truncated to seconds like the date of the updates, so an update of the same second is not ignored.*/
+func (st *subscriberTracker) onBotBlocked(chatId int64) {
+	st.setStatus(chatId, SubscriberBlocked, time.Now().Truncate(time.Second))
+}
+
+/*
+SetSubscriberStore sets the store which keeps the status of the private chats. By default the statuses are kept in memory.
+The bot marks a chat as blocked when it receives a "my_chat_member" update with "kicked" status or a request to the chat fails with errs.ErrBotBlocked, and marks it as active when it receives a "my_chat_member" update with "member" status.
+*/
+func (bot *Bot) SetSubscriberStore(store SubscriberStore) {
+	bot.subscribers.mu.Lock()
+	bot.subscribers.store = store
+	bot.subscribers.mu.Unlock()
+}
+
+/*GetSubscriberStore returns the store which keeps the status of the private chats.*/
+func (bot *Bot) GetSubscriberStore() SubscriberStore {
+	return bot.subscribers.getStore()
+}
+
+/*IsBlocked returns true if the user of the given private chat has blocked the bot.*/
+func (bot *Bot) IsBlocked(chatId int64) bool {
+	status, _, err := bot.subscribers.getStore().GetStatus(chatId)
+	return err == nil && status == SubscriberBlocked
+}
+
+/*AddSubscriberStatusHandler adds a function which is called when a user blocks or unblocks the bot.*/
+func (bot *Bot) AddSubscriberStatusHandler(handler func(chatId int64, status SubscriberStatus)) {
+	bot.subscribers.mu.Lock()
+	bot.subscribers.handlers = append(bot.subscribers.handlers, handler)
+	bot.subscribers.mu.Unlock()
+}
@@ -0,0 +1,164 @@
+package telego
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*Returns a "my_chat_member" update of the chat with the given new status of the bot.*/
+func myChatMemberUpdate(t *testing.T, chatId int64, chatType, status string, date int) *objs.Update {
+	t.Helper()
+	bt, _ := json.Marshal(map[string]any{
+		"update_id": 1,
+		"my_chat_member": map[string]any{
+			"chat": map[string]any{"id": chatId, "type": chatType}, "from": map[string]any{"id": chatId, "first_name": "user"}, "date": date,
+			"old_chat_member": map[string]any{"status": "member", "user": map[string]any{"id": 10, "is_bot": true, "first_name": "bot"}},
+			"new_chat_member": map[string]any{"status": status, "user": map[string]any{"id": 10, "is_bot": true, "first_name": "bot"}, "until_date": 0},
+		},
+	})
+	var update objs.Update
+	if err := json.Unmarshal(bt, &update); err != nil {
+		t.Fatal(err)
+	}
+	return &update
+}
+
+/*Returns a tracker whose handler sends the changes into the returned channel.*/
+func newTestTracker() (*subscriberTracker, chan SubscriberStatus) {
+	changes := make(chan SubscriberStatus, 100)
+	st := &subscriberTracker{store: NewMemorySubscriberStore(), logger: logger.Nop()}
+	st.handlers = append(st.handlers, func(_ int64, status SubscriberStatus) { changes <- status })
+	return st, changes
+}
+
+func TestSubscriberCheckUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		chatType string
+		status   string
+		want     SubscriberStatus
+	}{
+		{"kicked", "private", "kicked", SubscriberBlocked},
+		{"member", "private", "member", SubscriberActive},
+		{"left", "private", "left", SubscriberUnknown},
+		{"group", "group", "kicked", SubscriberUnknown},
+		{"channel", "channel", "member", SubscriberUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, changes := newTestTracker()
+			st.checkUpdate(myChatMemberUpdate(t, 5, tt.chatType, tt.status, 100))
+			status, at, _ := st.store.GetStatus(5)
+			if status != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, status)
+			}
+			if tt.want == SubscriberUnknown {
+				if !at.IsZero() {
+					t.Errorf("nothing should be stored, got %v", at)
+				}
+				return
+			}
+			if !at.Equal(time.Unix(100, 0)) {
+				t.Errorf("expected the date of the update, got %v", at)
+			}
+			select {
+			case got := <-changes:
+				if got != tt.want {
+					t.Errorf("the handler was called with %s", got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the handler should be called")
+			}
+		})
+	}
+	st, _ := newTestTracker()
+	st.checkUpdate(&objs.Update{})
+	if chats, _ := st.store.Chats(SubscriberBlocked); len(chats) != 0 {
+		t.Error("updates without my_chat_member should be ignored")
+	}
+}
+
+func TestSubscriberIgnoresOlderEvents(t *testing.T) {
+	st, changes := newTestTracker()
+	st.checkUpdate(myChatMemberUpdate(t, 5, "private", "kicked", 200))
+	<-changes
+	//The user unblocked the bot before blocking it, but the update is received later.
+	st.checkUpdate(myChatMemberUpdate(t, 5, "private", "member", 100))
+	if status, _, _ := st.store.GetStatus(5); status != SubscriberBlocked {
+		t.Errorf("an older update should not change the status, got %s", status)
+	}
+	st.checkUpdate(myChatMemberUpdate(t, 5, "private", "member", 200))
+	if status, _, _ := st.store.GetStatus(5); status != SubscriberActive {
+		t.Errorf("an update of the same time should change the status, got %s", status)
+	}
+	//The same status with a newer time moves the stored time forward.
+	st.checkUpdate(myChatMemberUpdate(t, 5, "private", "member", 300))
+	st.checkUpdate(myChatMemberUpdate(t, 5, "private", "kicked", 250))
+	if status, at, _ := st.store.GetStatus(5); status != SubscriberActive || !at.Equal(time.Unix(300, 0)) {
+		t.Errorf("expected active at 300, got %s at %v", status, at)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(changes) != 1 {
+		t.Errorf("expected the handler to be called once, got %d calls", len(changes))
+	}
+}
+
+func TestSubscriberConcurrentEvents(t *testing.T) {
+	st := &subscriberTracker{store: NewMemorySubscriberStore(), logger: logger.Nop()}
+	var calls atomic.Int32
+	st.handlers = append(st.handlers, func(int64, SubscriberStatus) { calls.Add(1) })
+	update := myChatMemberUpdate(t, 5, "private", "kicked", int(time.Now().Unix()))
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			st.onBotBlocked(5)
+		}()
+		go func() {
+			defer wg.Done()
+			st.checkUpdate(update)
+		}()
+	}
+	wg.Wait()
+	time.Sleep(50 * time.Millisecond)
+	if n := calls.Load(); n != 1 {
+		t.Errorf("the handler should be called once, got %d calls", n)
+	}
+}
+
+func TestSubscriberBotBlockedRequest(t *testing.T) {
+	bot := newTestBot(t, func(string, *http.Request) string {
+		return errResult(403, "Forbidden: bot was blocked by the user", nil)
+	})
+	changes := make(chan int64, 1)
+	bot.AddSubscriberStatusHandler(func(chatId int64, status SubscriberStatus) {
+		if status == SubscriberBlocked {
+			changes <- chatId
+		}
+	})
+	if _, err := bot.SendMessage(objs.IntChatID(7), "hi", "", 0, false, false); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !bot.IsBlocked(7) {
+		t.Error("the chat should be marked as blocked")
+	}
+	select {
+	case chatId := <-changes:
+		if chatId != 7 {
+			t.Errorf("unexpected chat %d", chatId)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler should be called")
+	}
+	if bot.IsBlocked(8) {
+		t.Error("other chats should not be blocked")
+	}
+}
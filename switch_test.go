@@ -0,0 +1,221 @@
+package telego
+
+import (
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+	"github.com/hamidteimouri/telego/tba"
+)
+
+/*Records the methods called on the test API server.*/
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (cl *callLog) add(method string) {
+	cl.mu.Lock()
+	cl.calls = append(cl.calls, method)
+	cl.mu.Unlock()
+}
+
+func (cl *callLog) get() []string {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return append([]string{}, cl.calls...)
+}
+
+/*Returns the index of the first call of the method, or -1.*/
+func (cl *callLog) index(method string) int {
+	for i, call := range cl.get() {
+		if call == method {
+			return i
+		}
+	}
+	return -1
+}
+
+func (cl *callLog) waitFor(t *testing.T, method string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for cl.index(method) < 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s was not called. Calls: %v", method, cl.get())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func freePort(t *testing.T) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestSwitchToPolling(t *testing.T) {
+	log := &callLog{}
+	var webhookSeen bool
+	bot := newWebhookTestBot(t, func(method string, r *http.Request) string {
+		log.add(method)
+		switch method {
+		case "getWebhookInfo":
+			//The webhook has been deleted, so the reconciler would set it again if it was running.
+			return okResult(map[string]any{"url": ""})
+		case "deleteWebhook":
+			webhookSeen = testCfg.Webhook
+		case "getUpdates":
+			return okResult([]any{})
+		}
+		return okResult(true)
+	})
+	bot.botCfg.WebHookConfigs.ReconcileInterval = time.Millisecond
+	bot.webhook = &tba.Webhook{}
+	bot.whReconciler.run(time.Millisecond)
+	log.waitFor(t, "getWebhookInfo")
+	err := bot.SwitchToPolling()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bot.apiInterface.StopUpdateRoutine)
+	if webhookSeen {
+		t.Error("the webhook option should be false before the webhook is deleted")
+	}
+	log.waitFor(t, "getUpdates")
+	calls := log.get()
+	deleted := log.index("deleteWebhook")
+	for _, call := range calls[deleted:] {
+		if call == "getWebhookInfo" || call == "setWebhook" {
+			t.Fatalf("the reconciler should be stopped before the webhook is deleted. Calls: %v", calls)
+		}
+	}
+	if bot.botCfg.Webhook || bot.webhook != nil || bot.botCfg.UpdateConfigs == nil {
+		t.Error("the bot should use polling")
+	}
+}
+
+func TestSwitchToPollingDeleteFails(t *testing.T) {
+	log := &callLog{}
+	bot := newWebhookTestBot(t, func(method string, r *http.Request) string {
+		log.add(method)
+		if method == "deleteWebhook" {
+			return errResult(500, "Internal Server Error", nil)
+		}
+		return okResult(map[string]any{"url": "https://example.com/token", "allowed_updates": []string{"message"}})
+	})
+	bot.botCfg.WebHookConfigs.ReconcileInterval = time.Millisecond
+	bot.webhook = &tba.Webhook{}
+	t.Cleanup(func() { _ = bot.stopWebhook() })
+	if err := bot.SwitchToPolling(); err == nil {
+		t.Fatal("expected the error of deleteWebhook")
+	}
+	if !bot.botCfg.Webhook || bot.webhook == nil {
+		t.Fatal("the bot should keep using webhook")
+	}
+	//The reconciler is started again.
+	n := len(log.get())
+	log.waitFor(t, "getWebhookInfo")
+	if log.index("getUpdates") >= 0 || len(log.get()) == n {
+		t.Errorf("unexpected calls %v", log.get())
+	}
+}
+
+func switchToWebhookConfigs(t *testing.T) *cfg.WebHookConfigs {
+	dir := t.TempDir()
+	return &cfg.WebHookConfigs{
+		URL:                 "https://127.0.0.1/",
+		Port:                freePort(t),
+		GenerateCertificate: true,
+		CertFile:            filepath.Join(dir, "cert.pem"),
+		KeyFile:             filepath.Join(dir, "key.pem"),
+	}
+}
+
+func TestSwitchToWebhook(t *testing.T) {
+	log := &callLog{}
+	var setURL, hasCertificate string
+	bot := newTestBot(t, func(method string, r *http.Request) string {
+		log.add(method)
+		switch method {
+		case "getUpdates":
+			return okResult([]any{})
+		case "setWebhook":
+			params := requestParams(r)
+			setURL, hasCertificate = params["url"], params["certificate"]
+		}
+		return okResult(true)
+	})
+	if err := bot.apiInterface.StartUpdateRoutine(); err != nil {
+		t.Fatal(err)
+	}
+	whcfg := switchToWebhookConfigs(t)
+	err := bot.SwitchToWebhook(whcfg)
+	if err != nil {
+		bot.apiInterface.StopUpdateRoutine()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = bot.stopWebhook() })
+	if !bot.botCfg.Webhook || bot.botCfg.WebHookConfigs != whcfg || bot.webhook == nil {
+		t.Fatal("the bot should use webhook")
+	}
+	if setURL != "https://127.0.0.1/token" || hasCertificate == "" {
+		t.Errorf("unexpected setWebhook request. url: %q, certificate: %q", setURL, hasCertificate)
+	}
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(whcfg.Port))
+	if err != nil {
+		t.Fatalf("the webhook server is not running: %v", err)
+	}
+	conn.Close()
+	calls := log.get()
+	time.Sleep(2 * cfg.DefaultUpdateConfigs().UpdateFrequency)
+	if len(log.get()) != len(calls) {
+		t.Errorf("the update routine should be stopped. Calls: %v", log.get())
+	}
+}
+
+func TestSwitchToWebhookRollBack(t *testing.T) {
+	log := &callLog{}
+	bot := newTestBot(t, func(method string, r *http.Request) string {
+		log.add(method)
+		switch method {
+		case "getUpdates":
+			return okResult([]any{})
+		case "setWebhook":
+			return errResult(400, "Bad Request: bad webhook", nil)
+		}
+		return okResult(true)
+	})
+	oldCfg := &cfg.WebHookConfigs{URL: "https://example.com/"}
+	bot.botCfg.WebHookConfigs = oldCfg
+	whcfg := switchToWebhookConfigs(t)
+	if err := bot.SwitchToWebhook(whcfg); err == nil {
+		t.Fatal("expected the error of setWebhook")
+	}
+	t.Cleanup(bot.apiInterface.StopUpdateRoutine)
+	if bot.botCfg.Webhook || bot.botCfg.WebHookConfigs != oldCfg || bot.webhook != nil {
+		t.Fatal("the bot should go back to polling")
+	}
+	if _, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(whcfg.Port)); err == nil {
+		t.Error("the webhook server should be stopped")
+	}
+	set := log.index("setWebhook")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		calls := log.get()
+		if calls[len(calls)-1] == "getUpdates" && len(calls)-1 > set {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the update routine should be started again. Calls: %v", calls)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -3,10 +3,10 @@ package tba
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/textproto"
-	"os"
 	"strconv"
 
 	mp "mime/multipart"
@@ -23,69 +23,70 @@ type httpSenderClient struct {
 /*This method sends an http request (without processing the response) as application/json. Returns the body of the response.*/
 func (hsc *httpSenderClient) sendHttpReqJson(method string, args objs.MethodArguments) ([]byte, error) {
 	if args == nil {
-		return hsc.sendHttpReq(method, "application/json", make([]byte, 0))
+		return hsc.sendHttpReq(method, "application/json", bytes.NewReader(nil))
 	}
-	bd := args.ToJson()
-	return hsc.sendHttpReq(method, "application/json", bd)
+	return hsc.sendHttpReq(method, "application/json", bytes.NewReader(args.ToJson()))
 }
 
 /*
 This method sends an http request (without processing the response) as multipart/formdata. Returns the body of the response.
-This method is only used for uploading files to bot api server.
+This method is only used for uploading files to bot api server. The form is encoded while it is being sent, so the files are streamed and never buffered in memory.
 */
-func (hsc *httpSenderClient) sendHttpReqMultiPart(method string, args objs.MethodArguments, files ...*os.File) ([]byte, error) {
-	body := &bytes.Buffer{}
-	writer := mp.NewWriter(body)
-	args.ToMultiPart(writer)
-	for _, file := range files {
-		if file == nil {
-			continue
+func (hsc *httpSenderClient) sendHttpReqMultiPart(method string, args objs.MethodArguments, files ...objs.NamedReader) ([]byte, error) {
+	pr, pw := io.Pipe()
+	writer := mp.NewWriter(pw)
+	go func() {
+		args.ToMultiPart(writer)
+		for _, file := range files {
+			if objs.IsNilFile(file) {
+				continue
+			}
+			err := hsc.addFileToMultiPartForm(file, writer)
+			if err != nil {
+				_ = pw.CloseWithError(&errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error()})
+				return
+			}
 		}
-		err := hsc.addFileToMultiPartForm(file, writer)
-		if err != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error()}
-		}
-	}
-	_ = writer.Close()
-	bts := body.Bytes()
-	return hsc.sendHttpReq(method, writer.FormDataContentType(), bts)
+		_ = pw.CloseWithError(writer.Close())
+	}()
+	return hsc.sendHttpReq(method, writer.FormDataContentType(), pr)
 }
 
-func (hsc *httpSenderClient) addFileToMultiPartForm(file *os.File, wr *mp.Writer) error {
-	if file != nil {
-		fileStat, err := file.Stat()
-		if err != nil {
-			return err
-		}
-		fw, err2 := wr.CreateFormFile(fileStat.Name(), fileStat.Name())
-		if err2 != nil {
-			return err2
-		}
-		_, err3 := io.Copy(fw, file)
-		if err3 != nil {
-			return err3
-		}
+func (hsc *httpSenderClient) addFileToMultiPartForm(file objs.NamedReader, wr *mp.Writer) error {
+	name := objs.FileName(file)
+	fw, err := wr.CreateFormFile(name, name)
+	if err != nil {
+		return err
 	}
-	return nil
+	_, err = io.Copy(fw, file)
+	return err
 }
 
-func (hsc *httpSenderClient) sendHttpReq(method, contetType string, body []byte) ([]byte, error) {
+/*Sends the request. The body is closed when the request is done, even on errors.*/
+func (hsc *httpSenderClient) sendHttpReq(method, contetType string, body io.Reader) ([]byte, error) {
 	cl := http.Client{}
-	req, err := http.NewRequest("POST", hsc.botApi+hsc.apiKey+"/"+method, bytes.NewReader(body))
+	req, err := http.NewRequest("POST", hsc.botApi+hsc.apiKey+"/"+method, body)
 	if err != nil {
+		if c, ok := body.(io.Closer); ok {
+			_ = c.Close()
+		}
 		return nil, err
 	}
 	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-type"), contetType)
-	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-length"), strconv.Itoa(len(body)))
 	res, err2 := cl.Do(req)
 	if err2 != nil {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error()}
+		//Errors of the multipart encoder are returned as they are.
+		var notSent *errs.MethodNotSentError
+		if errors.As(err2, &notSent) {
+			return nil, notSent
+		}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error(), Err: &errs.NetworkError{Method: method, Err: err2}}
 	}
+	defer res.Body.Close()
 	if res.StatusCode < 500 {
-		out := make([]byte, res.ContentLength)
-		_, err3 := res.Body.Read(out)
+		out, err3 := io.ReadAll(res.Body)
 		if err3 != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to parse body into byte slice. " + err3.Error()}
+			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to parse body into byte slice. " + err3.Error(), Err: &errs.NetworkError{Method: method, Err: err3}}
 		}
 		if res.StatusCode < 300 {
 			return out, nil
@@ -94,6 +95,7 @@ func (hsc *httpSenderClient) sendHttpReq(method, contetType string, body []byte)
 		_ = json.Unmarshal(out, fr)
 		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr}
 	} else {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode)}
+		fr := &objs.FailureResult{ErrorCode: res.StatusCode, Description: http.StatusText(res.StatusCode)}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr}
 	}
 }
@@ -0,0 +1,74 @@
+package tba
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func TestSendHttpReqMultiPartStreamsReaders(t *testing.T) {
+	var got string
+	var gotName string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("expected a streamed body, got content length %d", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			return
+		}
+		fl, hdr, err := r.FormFile("video.mp4")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bts, _ := io.ReadAll(fl)
+		got, gotName = string(bts), hdr.Filename
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+
+	cl := &httpSenderClient{botApi: srv.URL + "/bot", apiKey: "token"}
+	content := strings.Repeat("frame", 1000)
+	args := &objs.SetChatPhotoArgs{Photo: "attach://video.mp4"}
+	res, err := cl.sendHttpReqMultiPart("setChatPhoto", args, objs.NewNamedReader("dir/video.mp4", strings.NewReader(content)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != `{"ok":true,"result":true}` {
+		t.Fatalf("unexpected response %q", res)
+	}
+	if got != content || gotName != "video.mp4" {
+		t.Fatalf("server received %q (%d bytes)", gotName, len(got))
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk failure")
+}
+
+func TestSendHttpReqMultiPartReaderError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+
+	cl := &httpSenderClient{botApi: srv.URL + "/bot", apiKey: "token"}
+	_, err := cl.sendHttpReqMultiPart("setChatPhoto", &objs.SetChatPhotoArgs{}, objs.NewNamedReader("a.jpg", failingReader{}))
+	var notSent *errs.MethodNotSentError
+	if !errors.As(err, &notSent) || !strings.Contains(notSent.Reason, "disk failure") {
+		t.Fatalf("expected a MethodNotSentError about the reader, got %v", err)
+	}
+	if errors.Is(err, errs.ErrNetwork) {
+		t.Fatal("reader errors should not be reported as network errors")
+	}
+}
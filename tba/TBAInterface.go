@@ -1,10 +1,11 @@
 package tba
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -13,8 +14,10 @@ import (
 	cfgs "github.com/hamidteimouri/telego/configs"
 	errs "github.com/hamidteimouri/telego/errors"
 	logger "github.com/hamidteimouri/telego/logger"
+	"github.com/hamidteimouri/telego/metrics"
 	objs "github.com/hamidteimouri/telego/objects"
 	"github.com/hamidteimouri/telego/parser"
+	"github.com/hamidteimouri/telego/tracing"
 )
 
 var interfaceCreated = false
@@ -28,7 +31,21 @@ type BotAPIInterface struct {
 	updateRoutineChannel chan bool
 	updateParser         *parser.UpdateParser
 	lastOffset           int
-	logger               *logger.BotLogger
+	logger               logger.Logger
+	//ctx is the context of the API calls. It contains the parent tracing span of the calls.
+	ctx          context.Context
+	migrations   *chatMigrations
+	onBotBlocked func(chatId int64)
+}
+
+/*
+WithContext returns a copy of the interface whose API calls are traced as children of the span in the given context.
+The copy shares the update routine and the channels with this interface and should only be used for calling API methods.
+*/
+func (bai *BotAPIInterface) WithContext(ctx context.Context) *BotAPIInterface {
+	cp := *bai
+	cp.ctx = ctx
+	return &cp
 }
 
 /*StartUpdateRoutine starts the update routine to receive updates from api sever*/
@@ -54,6 +71,36 @@ func (bai *BotAPIInterface) StopUpdateRoutine() {
 	}
 }
 
+/*
+FlushUpdates receives the remaining updates from the API server until no update is left, so all the received updates are confirmed on the API server.
+The update routine should be stopped before calling this method. It is used before switching to webhook to make sure no update is lost or received twice.
+*/
+func (bai *BotAPIInterface) FlushUpdates() error {
+	if bai.updateRoutineRunning {
+		return errors.New("update routine is running")
+	}
+	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
+	for {
+		args := objs.GetUpdatesArgs{Offset: bai.lastOffset + 1}
+		if bai.botConfigs.UpdateConfigs != nil {
+			args.Limit = bai.botConfigs.UpdateConfigs.Limit
+			args.AllowedUpdates = bai.botConfigs.UpdateConfigs.AllowedUpdates
+		}
+		res, err := cl.sendHttpReqJson("getUpdates", &args)
+		if err != nil {
+			return err
+		}
+		of, err := bai.ParseUpdate(res)
+		if err != nil {
+			return err
+		}
+		if of <= bai.lastOffset {
+			return nil
+		}
+		bai.lastOffset = of
+	}
+}
+
 /*GetUpdateChannel returns the update channel*/
 func (bai *BotAPIInterface) GetUpdateChannel() *chan *objs.Update {
 	return bai.updateChannel
@@ -84,12 +131,12 @@ loop:
 			}
 			res, err := cl.sendHttpReqJson("getUpdates", &args)
 			if err != nil {
-				bai.logger.GetRaw().Println("Error receiving updates.", err)
+				bai.logger.Error("Error receiving updates", logger.Method("getUpdates"), logger.Err(err))
 				continue loop
 			}
 			err = bai.parseUpdateresults(res)
 			if err != nil {
-				bai.logger.GetRaw().Println("Error parsing the result of the update. " + err.Error())
+				bai.logger.Error("Error parsing the result of the update", logger.Method("getUpdates"), logger.Err(err))
 			}
 		}
 	}
@@ -134,14 +181,6 @@ func (bai *BotAPIInterface) ParseUpdate(body []byte) (int, error) {
 	return lastOffset, nil
 }
 
-func (bai *BotAPIInterface) isChatIdOk(chatIdInt int, chatIdString string) bool {
-	if chatIdInt == 0 {
-		return chatIdString != ""
-	} else {
-		return chatIdString == ""
-	}
-}
-
 /*GetMe gets the bot info*/
 func (bai *BotAPIInterface) GetMe() (*objs.Result[*objs.User], error) {
 	res, err := bai.SendCustom("getMe", nil, false, nil)
@@ -157,15 +196,12 @@ func (bai *BotAPIInterface) GetMe() (*objs.Result[*objs.User], error) {
 }
 
 /*
-SendMessage sends a message to the user. chatIdInt is used for all chats but channles and chatidString is used for channels (in form of @channleusername) and only of them has be populated, otherwise ChatIdProblem error will be returned.
+SendMessage sends a message to the given chat or channel.
 "chatId" and "text" arguments are required. other arguments are optional for bot api.
 */
-func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parseMode string, entities []objs.MessageEntity, disable_web_page_preview, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_to_message_id, messageThreadId int, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
-		def := bai.fixTheDefaultArguments(chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+func (bai *BotAPIInterface) SendMessage(chatId objs.ChatID, text, parseMode string, entities []objs.MessageEntity, disable_web_page_preview, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_to_message_id, messageThreadId int64, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
+		def := bai.fixTheDefaultArguments(chatId, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
 		args := &objs.SendMessageArgs{
 			Text:                        text,
 			DisableWebPagePreview:       disable_web_page_preview,
@@ -185,7 +221,7 @@ func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parse
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendMessage"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendMessage"}
 	}
 }
 
@@ -193,19 +229,16 @@ func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parse
 ForwardMessage forwards a message from a user or channel to a user or channel. If the source or destination (or both) of the forwarded message is a channel, only string chat ids should be given to the function, and if it is user only int chat ids should be given.
 "chatId", "fromChatId" and "messageId" arguments are required. other arguments are optional for bot api.
 */
-func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, disableNotif, ProtectContent bool, messageId, messageThreadId int) (*objs.Result[*objs.Message], error) {
-	if (chatIdInt != 0 && chatIdString != "") && (fromChatIdInt != 0 && fromChatIdString != "") {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) && bai.isChatIdOk(fromChatIdInt, fromChatIdString) {
+func (bai *BotAPIInterface) ForwardMessage(chatId, fromChatId objs.ChatID, disableNotif, ProtectContent bool, messageId, messageThreadId int64) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() && !fromChatId.IsZero() {
 		fm := &objs.ForwardMessageArgs{
 			DisableNotification: disableNotif,
 			MessageId:           messageId,
 			ProtectContent:      ProtectContent,
 			MessageThreadId:     messageThreadId,
 		}
-		fm.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-		fm.FromChatId = bai.fixChatId(fromChatIdInt, fromChatIdString)
+		fm.ChatId = bai.fixChatId(chatId)
+		fm.FromChatId = bai.fixChatId(fromChatId)
 		res, err := bai.SendCustom("forwardMessage", fm, false, nil, nil)
 		if err != nil {
 			return nil, err
@@ -217,22 +250,19 @@ func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdS
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString or fromChatIdInt or fromChatIdString", MethodName: "forwardMessage"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId or fromChatId", MethodName: "forwardMessage"}
 	}
 }
 
 /*
-SendPhoto sends a photo (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendPhoto sends a photo (file,url,telegramId) to a chat or a channel
 "chatId" and "photo" arguments are required. other arguments are optional for bot api.
 */
-func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string, photoFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendPhoto(chatId objs.ChatID, photo string, photoFile objs.NamedReader, caption, parseMode string, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendPhotoArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, protectContent, reply_markup,
 			),
 			Photo:           photo,
@@ -243,7 +273,7 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 		}
 		var res []byte
 		var err error
-		if photoFile != nil {
+		if !objs.IsNilFile(photoFile) {
 			res, err = bai.SendCustom("sendPhoto", args, true, photoFile, nil)
 		} else {
 			res, err = bai.SendCustom("sendPhoto", args, false, nil, nil)
@@ -258,22 +288,19 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendPhoto"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendPhoto"}
 	}
 }
 
 /*
-SendVideo sends a video (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendVideo sends a video (file,url,telegramId) to a chat or a channel
 "chatId" and "video" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string, videoFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendVideo(chatId objs.ChatID, video string, videoFile objs.NamedReader, caption, parseMode string, reply_to_message_id, messageThreadId int64, thumb string, thumbFile objs.NamedReader, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendVideoArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, protectContent, reply_markup,
 			),
 			Video:             video,
@@ -296,22 +323,19 @@ func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendVideo"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendVideo"}
 	}
 }
 
 /*
-SendAudio sends an audio (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendAudio sends an audio (file,url,telegramId) to a chat or a channel
 "chatId" and "audio" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0,to ignore string arguments pass "")
 */
-func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string, audioFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendAudio(chatId objs.ChatID, audio string, audioFile objs.NamedReader, caption, parseMode string, reply_to_message_id, messageThreadId int64, thumb string, thumbFile objs.NamedReader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendAudioArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Audio:           audio,
@@ -334,22 +358,19 @@ func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendAudio"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendAudio"}
 	}
 }
 
 /*
-sSendDocument sends a document (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+sSendDocument sends a document (file,url,telegramId) to a chat or a channel
 "chatId" and "document" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document string, documentFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendDocument(chatId objs.ChatID, document string, documentFile objs.NamedReader, caption, parseMode string, reply_to_message_id, messageThreadId int64, thumb string, thumbFile objs.NamedReader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendDocumentArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Document:                    document,
@@ -370,22 +391,19 @@ func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document s
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendDocument"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendDocument"}
 	}
 }
 
 /*
-SendAnimation sends an animation (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendAnimation sends an animation (file,url,telegramId) to a chat or a channel
 "chatId" and "animation" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation string, animationFile *os.File, caption, parseMode string, width, height, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendAnimation(chatId objs.ChatID, animation string, animationFile objs.NamedReader, caption, parseMode string, width, height, duration int, reply_to_message_id, messageThreadId int64, thumb string, thumbFile objs.NamedReader, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendAnimationArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, protectContent, reply_markup,
 			),
 			Animation:       animation,
@@ -409,22 +427,19 @@ func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendAnimation"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendAnimation"}
 	}
 }
 
 /*
-sSendVoice sends a voice (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+sSendVoice sends a voice (file,url,telegramId) to a chat or a channel
 "chatId" and "voice" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string, voiceFile *os.File, caption, parseMode string, duration int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendVoice(chatId objs.ChatID, voice string, voiceFile objs.NamedReader, caption, parseMode string, duration int, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendVoiceArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Voice:           voice,
@@ -444,23 +459,20 @@ func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendVoice"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendVoice"}
 	}
 }
 
 /*
-SendVideoNote sends a video note (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendVideoNote sends a video note (file,url,telegramId) to a chat or a channel
 "chatId" and "videoNote" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 Note that sending video note by URL is not supported by telegram.
 */
-func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile *os.File, caption, parseMode string, length, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendVideoNote(chatId objs.ChatID, videoNote string, videoNoteFile objs.NamedReader, caption, parseMode string, length, duration int, reply_to_message_id, messageThreadId int64, thumb string, thumbFile objs.NamedReader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendVideoNoteArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			VideoNote:       videoNote,
@@ -482,22 +494,19 @@ func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendVideoNote"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendVideoNote"}
 	}
 }
 
 /*
-SendMediaGroup sends an album of media (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
+SendMediaGroup sends an album of media (file,url,telegramId) to a chat or a channel
 "chatId" and "media" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id, messageThreadId int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*os.File) (*objs.Result[[]objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendMediaGroup(chatId objs.ChatID, reply_to_message_id, messageThreadId int64, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...objs.NamedReader) (*objs.Result[[]objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendMediaGroupArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Media: media,
@@ -513,22 +522,19 @@ func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, r
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendMediaGRoup"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendMediaGRoup"}
 	}
 }
 
 /*
-SendLocation sends a location to a channel (chatIdString) or a chat (chatIdInt)
+SendLocation sends a location to a chat or a channel
 "chatId","latitude" and "longitude" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendLocation(chatIdInt int, chatIdString string, latitude, longitude, horizontalAccuracy float32, livePeriod, heading, proximityAlertRadius, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendLocation(chatId objs.ChatID, latitude, longitude, horizontalAccuracy float32, livePeriod, heading, proximityAlertRadius int, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendLocationArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Latitude:             latitude,
@@ -549,19 +555,16 @@ func (bai *BotAPIInterface) SendLocation(chatIdInt int, chatIdString string, lat
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendLocation"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendLocation"}
 	}
 }
 
 /*
-EditMessageLiveLocation edits a live location sent to a channel (chatIdString) or a chat (chatIdInt)
+EditMessageLiveLocation edits a live location sent to a chat or a channel
 "chatId","latitude" and "longitude" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, latitude, longitude, horizontalAccuracy float32, heading, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) EditMessageLiveLocation(chatId objs.ChatID, inlineMessageId string, messageId int64, latitude, longitude, horizontalAccuracy float32, heading, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	if !chatId.IsZero() {
 		args := &objs.EditMessageLiveLocationArgs{
 			InlineMessageId:      inlineMessageId,
 			MessageId:            messageId,
@@ -572,7 +575,7 @@ func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString,
 			ProximityAlertRadius: proximityAlertRadius,
 			ReplyMarkup:          reply_markup,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("editMessageLiveLocation", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -584,25 +587,22 @@ func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "editMessageLiveLocation"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "editMessageLiveLocation"}
 	}
 }
 
 /*
-StopMessageLiveLocation stops a live location sent to a channel (chatIdString) or a chat (chatIdInt)
+StopMessageLiveLocation stops a live location sent to a chat or a channel
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) StopMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) StopMessageLiveLocation(chatId objs.ChatID, inlineMessageId string, messageId int64, replyMarkup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	if !chatId.IsZero() {
 		args := &objs.StopMessageLiveLocationArgs{
 			InlineMessageId: inlineMessageId,
 			MessageId:       messageId,
 			ReplyMarkup:     replyMarkup,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("stopMessageLiveLocation", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -614,22 +614,19 @@ func (bai *BotAPIInterface) StopMessageLiveLocation(chatIdInt int, chatIdString,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "stopMessageLiveLocation"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "stopMessageLiveLocation"}
 	}
 }
 
 /*
-SendVenue sends a venue to a channel (chatIdString) or a chat (chatIdInt)
+SendVenue sends a venue to a chat or a channel
 "chatId","latitude","longitude","title" and "address" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitude, longitude float32, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendVenue(chatId objs.ChatID, latitude, longitude float32, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType string, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendVenueArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Latitude:        latitude,
@@ -641,7 +638,7 @@ func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitu
 			GooglePlaceId:   googlePlaceId,
 			GooglePlaceType: googlePlaceType,
 		}
-		res, err := bai.SendCustom("sendVnue", args, false, nil)
+		res, err := bai.SendCustom("sendVenue", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -652,22 +649,19 @@ func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitu
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendcontact"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendcontact"}
 	}
 }
 
 /*
-SendContact sends a contact to a channel (chatIdString) or a chat (chatIdInt)
+SendContact sends a contact to a chat or a channel
 "chatId","phoneNumber" and "firstName" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendContact(chatIdInt int, chatIdString, phoneNumber, firstName, lastName, vCard string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendContact(chatId objs.ChatID, phoneNumber, firstName, lastName, vCard string, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendContactArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			PhoneNumber: phoneNumber,
@@ -686,22 +680,19 @@ func (bai *BotAPIInterface) SendContact(chatIdInt int, chatIdString, phoneNumber
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendContact"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendContact"}
 	}
 }
 
 /*
-SendPoll sends a poll to a channel (chatIdString) or a chat (chatIdInt)
+SendPoll sends a poll to a chat or a channel
 "chatId","phoneNumber" and "firstName" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendPoll(chatIdInt int, chatIdString, question string, options []string, isClosed, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod, closeDate int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendPoll(chatId objs.ChatID, question string, options []string, isClosed, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod, closeDate int, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendPollArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Question:              question,
@@ -728,22 +719,19 @@ func (bai *BotAPIInterface) SendPoll(chatIdInt int, chatIdString, question strin
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendPoll"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendPoll"}
 	}
 }
 
 /*
-SendDice sends a dice message to a channel (chatIdString) or a chat (chatIdInt)
+SendDice sends a dice message to a chat or a channel
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendDice(chatIdInt int, chatIdString, emoji string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendDice(chatId objs.ChatID, emoji string, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendDiceArgs{
 			DefaultSendMethodsArguments: bai.fixTheDefaultArguments(
-				chatIdInt, reply_to_message_id, messageThreadId, chatIdString, disable_notification,
+				chatId, reply_to_message_id, messageThreadId, disable_notification,
 				allow_sending_without_reply, ProtectContent, reply_markup,
 			),
 			Emoji: emoji,
@@ -759,24 +747,21 @@ func (bai *BotAPIInterface) SendDice(chatIdInt int, chatIdString, emoji string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendDice"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendDice"}
 	}
 }
 
 /*
-SendChatAction sends a chat action message to a channel (chatIdString) or a chat (chatIdInt)
+SendChatAction sends a chat action message to a chat or a channel
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendChatAction(chatIdInt, messageThreadId int, chatIdString, chatAction string) (*objs.Result[*objs.Message], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) SendChatAction(chatId objs.ChatID, messageThreadId int64, chatAction string) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() {
 		args := &objs.SendChatActionArgs{
 			Action:           chatAction,
 			MessageThreaddId: messageThreadId,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("sendChatAction", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -788,12 +773,12 @@ func (bai *BotAPIInterface) SendChatAction(chatIdInt, messageThreadId int, chatI
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "sendChatAction"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "sendChatAction"}
 	}
 }
 
 /*GetUserProfilePhotos gets the user profile photos*/
-func (bai *BotAPIInterface) GetUserProfilePhotos(userId, offset, limit int) (*objs.Result[*objs.UserProfilePhotos], error) {
+func (bai *BotAPIInterface) GetUserProfilePhotos(userId int64, offset, limit int) (*objs.Result[*objs.UserProfilePhotos], error) {
 	args := &objs.GetUserProfilePhototsArgs{UserId: userId, Offset: offset, Limit: limit}
 	res, err := bai.SendCustom("getUserProfilePhotos", args, false, nil)
 	if err != nil {
@@ -823,45 +808,37 @@ func (bai *BotAPIInterface) GetFile(fileId string) (*objs.Result[*objs.File], er
 }
 
 /*
-DownloadFile downloads a file from telegram servers and saves it into the given file.
+DownloadFile downloads a file from the API server and saves it into the given file.
 
-This method closes the given file. If the file is nil, this method will create a file based on the name of the file stored in telegram servers.
+The given file is not closed. If the file is nil, this method will create a file in the working directory based on the name of the file stored in telegram servers and close it.
+Use DownloadFileTo or OpenFile for more control over the download.
 */
 func (bai *BotAPIInterface) DownloadFile(fileObject *objs.File, file *os.File) error {
-	url := "https://api.telegram.org/file/bot" + bai.botConfigs.APIKey + "/" + fileObject.FilePath
-	res, err := http.Get(url)
-	if err != nil {
-		return err
+	if fileObject == nil {
+		return &errs.RequiredArgumentError{ArgName: "file_path", MethodName: "downloadFile"}
 	}
 	if file == nil {
 		ar := strings.Split(fileObject.FilePath, "/")
 		name := ar[len(ar)-1]
 		var er error
-		file, er = os.OpenFile(name, os.O_CREATE|os.O_WRONLY, 0666)
+		file, er = os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 		if er != nil {
 			return er
 		}
+		defer file.Close()
 	}
-	if res.StatusCode < 300 {
-		_, err2 := io.Copy(file, res.Body)
-		if err2 != nil {
-			return err2
-		}
-		err3 := file.Close()
-		return err3
-	} else {
-		return &errs.MethodNotSentError{Method: "getFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode)}
-	}
+	_, err := bai.DownloadFileTo(bai.ctx, fileObject, file, nil)
+	return err
 }
 
 /*BanChatMember bans a chat member*/
-func (bai *BotAPIInterface) BanChatMember(chatIdInt int, chatIdString string, userId, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) BanChatMember(chatId objs.ChatID, userId int64, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
 	args := &objs.BanChatMemberArgs{
 		UserId:         userId,
 		UntilDate:      untilDate,
 		RevokeMessages: revokeMessages,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("banChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -875,12 +852,12 @@ func (bai *BotAPIInterface) BanChatMember(chatIdInt int, chatIdString string, us
 }
 
 /*UnbanChatMember unbans a chat member*/
-func (bai *BotAPIInterface) UnbanChatMember(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) UnbanChatMember(chatId objs.ChatID, userId int64, onlyIfBanned bool) (*objs.Result[bool], error) {
 	args := &objs.UnbanChatMemberArgsArgs{
 		UserId:       userId,
 		OnlyIfBanned: onlyIfBanned,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("unbanChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -894,14 +871,14 @@ func (bai *BotAPIInterface) UnbanChatMember(chatIdInt int, chatIdString string,
 }
 
 /*RestrictChatMember restricts a chat member*/
-func (bai *BotAPIInterface) RestrictChatMember(chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, useIndependentChatPermissions bool, untilDate int) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) RestrictChatMember(chatId objs.ChatID, userId int64, permissions objs.ChatPermissions, useIndependentChatPermissions bool, untilDate int) (*objs.Result[bool], error) {
 	args := &objs.RestrictChatMemberArgs{
 		UserId:                        userId,
 		Permission:                    permissions,
 		UseIndependentChatPermissions: useIndependentChatPermissions,
 		UntilDate:                     untilDate,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("restrictChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -915,7 +892,7 @@ func (bai *BotAPIInterface) RestrictChatMember(chatIdInt int, chatIdString strin
 }
 
 /*PromoteChatMember promotes a chat member*/
-func (bai *BotAPIInterface) PromoteChatMember(chatIdInt int, chatIdString string, userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics bool) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) PromoteChatMember(chatId objs.ChatID, userId int64, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics bool) (*objs.Result[bool], error) {
 	args := &objs.PromoteChatMemberArgs{
 		UserId:              userId,
 		IsAnonymous:         isAnonymous,
@@ -934,7 +911,7 @@ func (bai *BotAPIInterface) PromoteChatMember(chatIdInt int, chatIdString string
 		CanPinMessages:      canPinMessages,
 		CanManageTopics:     canManageTopics,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("promoteChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -995,12 +972,12 @@ func (bai *BotAPIInterface) GetMyDefaultAdministratorRights(forChannels bool) (*
 }
 
 /*SetChatAdministratorCustomTitle sets a custom title for the administrator.*/
-func (bai *BotAPIInterface) SetChatAdministratorCustomTitle(chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatAdministratorCustomTitle(chatId objs.ChatID, userId int64, customTitle string) (*objs.Result[bool], error) {
 	args := &objs.SetChatAdministratorCustomTitleArgs{
 		UserId:      userId,
 		CustomTitle: customTitle,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("setChatAdministratorCustomTitle", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1014,11 +991,11 @@ func (bai *BotAPIInterface) SetChatAdministratorCustomTitle(chatIdInt int, chatI
 }
 
 /*BanOrUnbanChatSenderChat bans or unbans a channel in the group..*/
-func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatId objs.ChatID, senderChatId int64, ban bool) (*objs.Result[bool], error) {
 	args := &objs.BanChatSenderChatArgs{
 		SenderChatId: senderChatId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	var method string
 	if ban {
 		method = "banChatSenderChat"
@@ -1038,12 +1015,12 @@ func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString
 }
 
 /*SetChatPermissions sets default permissions for all users in the chat.*/
-func (bai *BotAPIInterface) SetChatPermissions(chatIdInt int, chatIdString string, useIndependentChatPermissions bool, permissions objs.ChatPermissions) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatPermissions(chatId objs.ChatID, useIndependentChatPermissions bool, permissions objs.ChatPermissions) (*objs.Result[bool], error) {
 	args := &objs.SetChatPermissionsArgs{
 		Permissions:                   permissions,
 		UseIndependentChatPermissions: useIndependentChatPermissions,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("setChatPermissions", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1057,9 +1034,9 @@ func (bai *BotAPIInterface) SetChatPermissions(chatIdInt int, chatIdString strin
 }
 
 /*ExportChatInviteLink exports the chat invite link and returns the new invite link as string.*/
-func (bai *BotAPIInterface) ExportChatInviteLink(chatIdInt int, chatIdString string) (*objs.Result[string], error) {
+func (bai *BotAPIInterface) ExportChatInviteLink(chatId objs.ChatID) (*objs.Result[string], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("exprotChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1073,14 +1050,14 @@ func (bai *BotAPIInterface) ExportChatInviteLink(chatIdInt int, chatIdString str
 }
 
 /*CreateChatInviteLink creates a new invite link for the chat.*/
-func (bai *BotAPIInterface) CreateChatInviteLink(chatIdInt int, chatIdString, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
+func (bai *BotAPIInterface) CreateChatInviteLink(chatId objs.ChatID, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
 	args := &objs.CreateChatInviteLinkArgs{
 		Name:               name,
 		ExpireDate:         expireDate,
 		MemberLimit:        memberLimit,
 		CreatesjoinRequest: createsJoinRequest,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("createChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1094,7 +1071,7 @@ func (bai *BotAPIInterface) CreateChatInviteLink(chatIdInt int, chatIdString, na
 }
 
 /*EditChatInviteLink edits an existing invite link for the chat.*/
-func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
+func (bai *BotAPIInterface) EditChatInviteLink(chatId objs.ChatID, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
 	args := &objs.EditChatInviteLinkArgs{
 		InviteLink:         inviteLink,
 		Name:               name,
@@ -1102,7 +1079,7 @@ func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, invi
 		MemberLimit:        memberLimit,
 		CreatesjoinRequest: createsJoinRequest,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("editChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1116,11 +1093,11 @@ func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, invi
 }
 
 /*RevokeChatInviteLink revokes the given invite link.*/
-func (bai *BotAPIInterface) RevokeChatInviteLink(chatIdInt int, chatIdString, inviteLink string) (*objs.Result[*objs.ChatInviteLink], error) {
+func (bai *BotAPIInterface) RevokeChatInviteLink(chatId objs.ChatID, inviteLink string) (*objs.Result[*objs.ChatInviteLink], error) {
 	args := &objs.RevokeChatInviteLinkArgs{
 		InviteLink: inviteLink,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("revokeChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1134,11 +1111,11 @@ func (bai *BotAPIInterface) RevokeChatInviteLink(chatIdInt int, chatIdString, in
 }
 
 /*ApproveChatJoinRequest approves a request from the given user to join the chat.*/
-func (bai *BotAPIInterface) ApproveChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) ApproveChatJoinRequest(chatId objs.ChatID, userId int64) (*objs.Result[bool], error) {
 	args := &objs.ApproveChatJoinRequestArgs{
 		UserId: userId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("approveChatJoinRequest", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1152,11 +1129,11 @@ func (bai *BotAPIInterface) ApproveChatJoinRequest(chatIdInt int, chatIdString s
 }
 
 /*DeclineChatJoinRequest declines a request from the given user to join the chat.*/
-func (bai *BotAPIInterface) DeclineChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) DeclineChatJoinRequest(chatId objs.ChatID, userId int64) (*objs.Result[bool], error) {
 	args := &objs.DeclineChatJoinRequestArgs{
 		UserId: userId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("declineChatJoinRequest", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1170,14 +1147,13 @@ func (bai *BotAPIInterface) DeclineChatJoinRequest(chatIdInt int, chatIdString s
 }
 
 /*SetChatPhoto sets the chat photo to given file.*/
-func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatPhoto(chatId objs.ChatID, file objs.NamedReader) (*objs.Result[bool], error) {
 	args := &objs.SetChatPhotoArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	stats, er := file.Stat()
-	if er != nil {
-		return nil, er
+	args.ChatId = bai.fixChatId(chatId)
+	if objs.IsNilFile(file) {
+		return nil, &errs.RequiredArgumentError{ArgName: "file", MethodName: "setChatPhoto"}
 	}
-	args.Photo = "attach://" + stats.Name()
+	args.Photo = objs.AttachName(file)
 	res, err := bai.SendCustom("setChatPhoto", args, true, file)
 	if err != nil {
 		return nil, err
@@ -1191,9 +1167,9 @@ func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, fil
 }
 
 /*DeleteChatPhoto deletes chat photo.*/
-func (bai *BotAPIInterface) DeleteChatPhoto(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) DeleteChatPhoto(chatId objs.ChatID) (*objs.Result[bool], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("deleteChatPhoto", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1207,11 +1183,11 @@ func (bai *BotAPIInterface) DeleteChatPhoto(chatIdInt int, chatIdString string)
 }
 
 /*SetChatTitle sets the chat title.*/
-func (bai *BotAPIInterface) SetChatTitle(chatIdInt int, chatIdString, title string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatTitle(chatId objs.ChatID, title string) (*objs.Result[bool], error) {
 	args := &objs.SetChatTitleArgs{
 		Title: title,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("setChatTitle", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1225,11 +1201,11 @@ func (bai *BotAPIInterface) SetChatTitle(chatIdInt int, chatIdString, title stri
 }
 
 /*SetChatDescription sets the chat description.*/
-func (bai *BotAPIInterface) SetChatDescription(chatIdInt int, chatIdString, descriptions string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatDescription(chatId objs.ChatID, descriptions string) (*objs.Result[bool], error) {
 	args := &objs.SetChatDescriptionArgs{
 		Description: descriptions,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("setChatDescription", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1243,12 +1219,12 @@ func (bai *BotAPIInterface) SetChatDescription(chatIdInt int, chatIdString, desc
 }
 
 /*PinChatMessage pins the message in the chat.*/
-func (bai *BotAPIInterface) PinChatMessage(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) PinChatMessage(chatId objs.ChatID, messageId int64, disableNotification bool) (*objs.Result[bool], error) {
 	args := &objs.PinChatMessageArgs{
 		MessageId:           messageId,
 		DisableNotification: disableNotification,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("pinChatMessage", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1262,11 +1238,11 @@ func (bai *BotAPIInterface) PinChatMessage(chatIdInt int, chatIdString string, m
 }
 
 /*UnpinChatMessage unpins the pinned message in the chat.*/
-func (bai *BotAPIInterface) UnpinChatMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) UnpinChatMessage(chatId objs.ChatID, messageId int64) (*objs.Result[bool], error) {
 	args := &objs.UnpinChatMessageArgs{
 		MessageId: messageId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("unpinChatMessage", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1280,9 +1256,9 @@ func (bai *BotAPIInterface) UnpinChatMessage(chatIdInt int, chatIdString string,
 }
 
 /*UnpinAllChatMessages unpins all the pinned messages in the chat.*/
-func (bai *BotAPIInterface) UnpinAllChatMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) UnpinAllChatMessages(chatId objs.ChatID) (*objs.Result[bool], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("unpinAllChatMessages", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1296,9 +1272,9 @@ func (bai *BotAPIInterface) UnpinAllChatMessages(chatIdInt int, chatIdString str
 }
 
 /*LeaveChat, the bot will leave the chat if this method is called.*/
-func (bai *BotAPIInterface) LeaveChat(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) LeaveChat(chatId objs.ChatID) (*objs.Result[bool], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("leaveChat", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1312,9 +1288,9 @@ func (bai *BotAPIInterface) LeaveChat(chatIdInt int, chatIdString string) (*objs
 }
 
 /*GetChat : a Chat object containing the information of the chat will be returned*/
-func (bai *BotAPIInterface) GetChat(chatIdInt int, chatIdString string) (*objs.Result[*objs.Chat], error) {
+func (bai *BotAPIInterface) GetChat(chatId objs.ChatID) (*objs.Result[*objs.Chat], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("getChat", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1328,25 +1304,29 @@ func (bai *BotAPIInterface) GetChat(chatIdInt int, chatIdString string) (*objs.R
 }
 
 /*GetChatAdministrators returns an array of ChatMember containing the informations of the chat administrators.*/
-func (bai *BotAPIInterface) GetChatAdministrators(chatIdInt int, chatIdString string) (*objs.Result[[]objs.ChatMemberOwner], error) {
+func (bai *BotAPIInterface) GetChatAdministrators(chatId objs.ChatID) (*objs.Result[[]objs.ChatMember], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("getChatAdministrators", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
-	msg := &objs.Result[[]objs.ChatMemberOwner]{}
+	msg := &objs.Result[[]json.RawMessage]{}
 	err3 := json.Unmarshal(res, msg)
 	if err3 != nil {
 		return nil, err3
 	}
-	return msg, nil
+	members, err4 := objs.UnmarshalChatMembers(msg.Result)
+	if err4 != nil {
+		return nil, err4
+	}
+	return &objs.Result[[]objs.ChatMember]{Ok: msg.Ok, Result: members}, nil
 }
 
 /* GetChatMemberCount returns the number of the memebrs of the chat.*/
-func (bai *BotAPIInterface) GetChatMemberCount(chatIdInt int, chatIdString string) (*objs.Result[int], error) {
+func (bai *BotAPIInterface) GetChatMemberCount(chatId objs.ChatID) (*objs.Result[int], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("getChatMemberCount", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1360,11 +1340,11 @@ func (bai *BotAPIInterface) GetChatMemberCount(chatIdInt int, chatIdString strin
 }
 
 /*GetChatMember returns the information of the member in a ChatMember object.*/
-func (bai *BotAPIInterface) GetChatMember(chatIdInt int, chatIdString string, userId int) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) GetChatMember(chatId objs.ChatID, userId int64) (*objs.Result[objs.ChatMember], error) {
 	args := &objs.GetChatMemberArgs{
 		UserId: userId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("getChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1374,15 +1354,19 @@ func (bai *BotAPIInterface) GetChatMember(chatIdInt int, chatIdString string, us
 	if err3 != nil {
 		return nil, err3
 	}
-	return msg, nil
+	member, err4 := objs.UnmarshalChatMember(msg.Result)
+	if err4 != nil {
+		return nil, err4
+	}
+	return &objs.Result[objs.ChatMember]{Ok: msg.Ok, Result: member}, nil
 }
 
 /*SetChatStickerSet sets the sticker set of the chat.*/
-func (bai *BotAPIInterface) SetChatStickerSet(chatIdInt int, chatIdString, stickerSetName string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatStickerSet(chatId objs.ChatID, stickerSetName string) (*objs.Result[bool], error) {
 	args := &objs.SetChatStcikerSet{
 		StickerSetName: stickerSetName,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("setChatStickerSet", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1396,9 +1380,9 @@ func (bai *BotAPIInterface) SetChatStickerSet(chatIdInt int, chatIdString, stick
 }
 
 /*DeleteChatStickerSet deletes the sticker set of the chat..*/
-func (bai *BotAPIInterface) DeleteChatStickerSet(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) DeleteChatStickerSet(chatId objs.ChatID) (*objs.Result[bool], error) {
 	args := &objs.DefaultChatArgs{}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("deleteChatStickerSet", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1490,7 +1474,7 @@ func (bai *BotAPIInterface) GetMyCommands(scope objs.BotCommandScope, languageCo
 }
 
 /*EditMessageText edits the text of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string, messageId int, inlineMessageId, text, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) EditMessageText(chatId objs.ChatID, messageId int64, inlineMessageId, text, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
 	args := &objs.EditMessageTextArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1502,7 +1486,7 @@ func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string,
 		Entities:              entities,
 		DisablewebpagePreview: disableWebPagePreview,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("editMessageText", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1516,7 +1500,7 @@ func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string,
 }
 
 /*EditMessageCaption edits the caption of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString string, messageId int, inlineMessageId, caption, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) EditMessageCaption(chatId objs.ChatID, messageId int64, inlineMessageId, caption, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
 	args := &objs.EditMessageCaptionArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1527,7 +1511,7 @@ func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString strin
 		ParseMode:       parseMode,
 		CaptionEntities: captionEntities,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("editMessageCaption", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1541,7 +1525,7 @@ func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString strin
 }
 
 /*EditMessageMedia edits the media of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) EditMessageMedia(chatId objs.ChatID, messageId int64, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...objs.NamedReader) (*objs.Result[json.RawMessage], error) {
 	args := &objs.EditMessageMediaArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1550,7 +1534,7 @@ func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string,
 		},
 		Media: media,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("editMessageMedia", args, true, file...)
 	if err != nil {
 		return nil, err
@@ -1564,7 +1548,7 @@ func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string,
 }
 
 /*EditMessagereplyMarkup edits the reply makrup of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) EditMessagereplyMarkup(chatId objs.ChatID, messageId int64, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
 	args := &objs.EditMessageReplyMakrupArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1572,7 +1556,7 @@ func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString s
 			ReplyMarkup:     replyMakrup,
 		},
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("editMessageReplyMarkup", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1586,12 +1570,12 @@ func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString s
 }
 
 /*StopPoll stops the poll.*/
-func (bai *BotAPIInterface) StopPoll(chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[*objs.Poll], error) {
+func (bai *BotAPIInterface) StopPoll(chatId objs.ChatID, messageId int64, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[*objs.Poll], error) {
 	args := &objs.StopPollArgs{
 		MessageId:   messageId,
 		ReplyMarkup: replyMakrup,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("stopPoll", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1605,11 +1589,11 @@ func (bai *BotAPIInterface) StopPoll(chatIdInt int, chatIdString string, message
 }
 
 /*DeleteMessage deletes the given message int the given chat.*/
-func (bai *BotAPIInterface) DeleteMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) DeleteMessage(chatId objs.ChatID, messageId int64) (*objs.Result[bool], error) {
 	args := &objs.DeleteMessageArgs{
 		MessageId: messageId,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("deleteMessage", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -1623,7 +1607,7 @@ func (bai *BotAPIInterface) DeleteMessage(chatIdInt int, chatIdString string, me
 }
 
 /*SendSticker sends an sticker to the given chat id.*/
-func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker, emoji string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo, messageThreadId int, replyMarkup objs.ReplyMarkup, file *os.File) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendSticker(chatId objs.ChatID, sticker, emoji string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo, messageThreadId int64, replyMarkup objs.ReplyMarkup, file objs.NamedReader) (*objs.Result[*objs.Message], error) {
 	args := &objs.SendStickerArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			DisableNotification:      disableNotif,
@@ -1636,7 +1620,7 @@ func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker, em
 		Sticker: sticker,
 		Emoji:   emoji,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("sendSticker", args, true, file)
 	if err != nil {
 		return nil, err
@@ -1667,7 +1651,7 @@ func (bai *BotAPIInterface) GetStickerSet(name string) (*objs.Result[*objs.Stick
 }
 
 /*UploadStickerFile uploads the given file as an sticker on the telegram servers.*/
-func (bai *BotAPIInterface) UploadStickerFile(userId int, stickerFormat string, sticker *objs.InputSticker, file *os.File) (*objs.Result[*objs.File], error) {
+func (bai *BotAPIInterface) UploadStickerFile(userId int64, stickerFormat string, sticker *objs.InputSticker, file objs.NamedReader) (*objs.Result[*objs.File], error) {
 	args := &objs.UploadStickerFileArgs{
 		UserId:        userId,
 		Sticker:       sticker,
@@ -1686,7 +1670,7 @@ func (bai *BotAPIInterface) UploadStickerFile(userId int, stickerFormat string,
 }
 
 /*CreateNewStickerSet creates a new sticker set with the given arguments*/
-func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, StickerFormat, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) CreateNewStickerSet(userId int64, name, title, StickerFormat, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...objs.NamedReader) (*objs.Result[bool], error) {
 	args := &objs.CreateNewStickerSetArgs{
 		UserId:          userId,
 		Name:            name,
@@ -1709,7 +1693,7 @@ func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, Sticker
 }
 
 /*AddStickerToSet adds a new sticker to the given set.*/
-func (bai *BotAPIInterface) AddStickerToSet(userId int, name string, sticker *objs.InputSticker, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) AddStickerToSet(userId int64, name string, sticker *objs.InputSticker, file objs.NamedReader) (*objs.Result[bool], error) {
 	args := &objs.AddStickerSetArgs{
 		UserId:  userId,
 		Name:    name,
@@ -1763,7 +1747,7 @@ func (bai *BotAPIInterface) DeleteStickerFromSet(sticker string) (*objs.Result[b
 }
 
 /*SetStickerSetThumb sets the thumbnail for the given sticker*/
-func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int64, file objs.NamedReader) (*objs.Result[bool], error) {
 	args := &objs.SetStickerSetThumbnailArgs{
 		Name:   name,
 		Thumb:  thumb,
@@ -1893,7 +1877,7 @@ func (bai *BotAPIInterface) AnswerInlineQuery(inlineQueryId string, results []ob
 }
 
 /*SendInvoice sends an invoice*/
-func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif bool, replyToMessageId, messageThreadId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendInvoice(chatId objs.ChatID, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif bool, replyToMessageId, messageThreadId int64, allowSendingWithoutReply, protectContent bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.Result[*objs.Message], error) {
 	args := &objs.SendInvoiceArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			DisableNotification:      disableNotif,
@@ -1901,6 +1885,7 @@ func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, desc
 			ReplyToMessageId:         replyToMessageId,
 			ReplyMarkup:              &replyMarkup,
 			MessageThreadId:          messageThreadId,
+			ProtectContent:           protectContent,
 		},
 		Title:                     title,
 		Description:               description,
@@ -1924,7 +1909,7 @@ func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, desc
 		SendEmailToProvider:       sendEmailToProvider,
 		IsFlexible:                isFlexible,
 	}
-	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	args.ChatId = bai.fixChatId(chatId)
 	res, err := bai.SendCustom("sendInvoice", args, false, nil)
 	if err != nil {
 		return nil, err
@@ -2016,18 +2001,15 @@ func (bai *BotAPIInterface) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok
 CopyMessage copies a message from a user or channel and sends it to a user or channel. If the source or destination (or both) of the forwarded message is a channel, only string chat ids should be given to the function, and if it is user only int chat ids should be given.
 "chatId", "fromChatId" and "messageId" arguments are required. other arguments are optional for bot api.
 */
-func (bai *BotAPIInterface) CopyMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, messageId int, disableNotif bool, caption, parseMode string, replyTo int, allowSendingWihtoutReply, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
-	if (chatIdInt != 0 && chatIdString != "") && (fromChatIdInt != 0 && fromChatIdString != "") {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) && bai.isChatIdOk(fromChatIdInt, fromChatIdString) {
+func (bai *BotAPIInterface) CopyMessage(chatId, fromChatId objs.ChatID, messageId int64, disableNotif bool, caption, parseMode string, replyTo int64, allowSendingWihtoutReply, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
+	if !chatId.IsZero() && !fromChatId.IsZero() {
 		fm := objs.ForwardMessageArgs{
 			DisableNotification: disableNotif,
 			MessageId:           messageId,
 			ProtectContent:      ProtectContent,
 		}
-		fm.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-		fm.FromChatId = bai.fixChatId(fromChatIdInt, fromChatIdString)
+		fm.ChatId = bai.fixChatId(chatId)
+		fm.FromChatId = bai.fixChatId(fromChatId)
 		cp := &objs.CopyMessageArgs{
 			ForwardMessageArgs:       fm,
 			Caption:                  caption,
@@ -2050,12 +2032,12 @@ func (bai *BotAPIInterface) CopyMessage(chatIdInt, fromChatIdInt int, chatIdStri
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString or fromChatIdInt or fromChatIdString", MethodName: "copyMessage"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId or fromChatId", MethodName: "copyMessage"}
 	}
 }
 
 /*SetPassportDataErrors sets passport data errors*/
-func (bai *BotAPIInterface) SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetPassportDataErrors(userId int64, errors []objs.PassportElementError) (*objs.Result[bool], error) {
 	args := &objs.SetPassportDataErrorsArgs{
 		UserId: userId, Errors: errors,
 	}
@@ -2072,13 +2054,15 @@ func (bai *BotAPIInterface) SetPassportDataErrors(userId int, errors []objs.Pass
 }
 
 /*SendGame sends a game*/
-func (bai *BotAPIInterface) SendGame(chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendGame(chatId int64, gameShortName string, disableNotif bool, replyTo, messageThreadId int64, allowSendingWithoutReply, protectContent bool, replyMarkup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	args := &objs.SendGameArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			ReplyToMessageId:         replyTo,
+			MessageThreadId:          messageThreadId,
 			DisableNotification:      disableNotif,
 			ReplyMarkup:              replyMarkup,
 			AllowSendingWithoutReply: allowSendingWithoutReply,
+			ProtectContent:           protectContent,
 		},
 		GameShortName: gameShortName,
 	}
@@ -2097,7 +2081,7 @@ func (bai *BotAPIInterface) SendGame(chatId int, gameShortName string, disableNo
 }
 
 /*SetGameScore sets the game high score*/
-func (bai *BotAPIInterface) SetGameScore(userId, score int, force, disableEditMessage bool, chatId, messageId int, inlineMessageId string) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) SetGameScore(userId int64, score int, force, disableEditMessage bool, chatId, messageId int64, inlineMessageId string) (*objs.Result[json.RawMessage], error) {
 	args := &objs.SetGameScoreArgs{
 		UserId:             userId,
 		Score:              score,
@@ -2120,7 +2104,7 @@ func (bai *BotAPIInterface) SetGameScore(userId, score int, force, disableEditMe
 }
 
 /*GetGameHighScores gets the high scores of the user*/
-func (bai *BotAPIInterface) GetGameHighScores(userId, chatId, messageId int, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error) {
+func (bai *BotAPIInterface) GetGameHighScores(userId, chatId, messageId int64, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error) {
 	args := &objs.GetGameHighScoresArgs{
 		UserId:          userId,
 		ChatId:          chatId,
@@ -2154,7 +2138,7 @@ func (bai *BotAPIInterface) GetWebhookInfo() (*objs.Result[*objs.WebhookInfo], e
 }
 
 /*SetWebhook sets a webhook for the bot.*/
-func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile objs.NamedReader) (*objs.Result[bool], error) {
 	args := objs.SetWebhookArgs{
 		URL:                url,
 		IPAddress:          ip,
@@ -2162,14 +2146,11 @@ func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdate
 		AllowedUpdates:     allowedUpdates,
 		DropPendingUpdates: dropPendingUpdates,
 	}
-	if keyFile != nil {
-		stat, errs := keyFile.Stat()
-		if errs != nil {
-			return nil, errs
-		}
-		args.Certificate = "attach://" + stat.Name()
+	if !objs.IsNilFile(keyFile) {
+		args.Certificate = objs.AttachName(keyFile)
 	}
-	res, err := bai.SendCustom("setWebhook", &args, keyFile != nil, keyFile)
+	//The arguments of setWebhook are only encoded as multipart, even when there is no certificate.
+	res, err := bai.SendCustom("setWebhook", &args, true, keyFile)
 	if err != nil {
 		return nil, err
 	}
@@ -2283,18 +2264,15 @@ func (bai *BotAPIInterface) GetForumTopicIconStickers() (*objs.Result[[]*objs.St
 }
 
 /*CreateForumTopic creates a topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights*/
-func (bai *BotAPIInterface) CreateForumTopic(chatIdInt int, chatIdString, name, iconCustomEmojiId string, iconColor int) (*objs.Result[*objs.ForumTopic], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) CreateForumTopic(chatId objs.ChatID, name, iconCustomEmojiId string, iconColor int) (*objs.Result[*objs.ForumTopic], error) {
+	if !chatId.IsZero() {
 		args := &objs.CreateForumTopicArgs{
-			ChatId:            bai.fixChatId(chatIdInt, chatIdString),
+			ChatId:            bai.fixChatId(chatId),
 			Name:              name,
 			IconColor:         iconColor,
 			IconCustomEmojiId: iconCustomEmojiId,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("createForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2306,23 +2284,20 @@ func (bai *BotAPIInterface) CreateForumTopic(chatIdInt int, chatIdString, name,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "createForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "createForumTopic"}
 	}
 }
 
 /*EditForumTopic edits name and icon of a topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have can_manage_topics administrator rights, unless it is the creator of the topic*/
-func (bai *BotAPIInterface) EditForumTopic(chatIdInt int, chatIdString, name, iconCustomEmojiId string, messageThreadId int) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) EditForumTopic(chatId objs.ChatID, name, iconCustomEmojiId string, messageThreadId int64) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.EditForumTopicArgs{
-			ChatId:            bai.fixChatId(chatIdInt, chatIdString),
+			ChatId:            bai.fixChatId(chatId),
 			Name:              name,
 			IconCustomEmojiId: iconCustomEmojiId,
 			MessageThreadId:   messageThreadId,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("editForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2334,21 +2309,18 @@ func (bai *BotAPIInterface) EditForumTopic(chatIdInt int, chatIdString, name, ic
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "editForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "editForumTopic"}
 	}
 }
 
 /*CloseForumTopic closes an open topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic*/
-func (bai *BotAPIInterface) CloseForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) CloseForumTopic(chatId objs.ChatID, messageThreadId int64) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.CloseForumTopicArgs{
-			ChatId:          bai.fixChatId(chatIdInt, chatIdString),
+			ChatId:          bai.fixChatId(chatId),
 			MessageThreadId: messageThreadId,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("closeForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2360,23 +2332,20 @@ func (bai *BotAPIInterface) CloseForumTopic(chatIdInt int, chatIdString string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "closeForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "closeForumTopic"}
 	}
 }
 
 /*ReopenForumTopic reopens a closed topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic*/
-func (bai *BotAPIInterface) ReopenForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) ReopenForumTopic(chatId objs.ChatID, messageThreadId int64) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.ReopenForumTopicArgs{
 			CloseForumTopicArgs: &objs.CloseForumTopicArgs{
-				ChatId:          bai.fixChatId(chatIdInt, chatIdString),
+				ChatId:          bai.fixChatId(chatId),
 				MessageThreadId: messageThreadId,
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("reopenForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2388,23 +2357,20 @@ func (bai *BotAPIInterface) ReopenForumTopic(chatIdInt int, chatIdString string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "reopenForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "reopenForumTopic"}
 	}
 }
 
 /*DeleteForumTopic deletes a forum topic along with all its messages in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_delete_messages administrator rights*/
-func (bai *BotAPIInterface) DeleteForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) DeleteForumTopic(chatId objs.ChatID, messageThreadId int64) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.DeleteForumTopicArgs{
 			CloseForumTopicArgs: &objs.CloseForumTopicArgs{
-				ChatId:          bai.fixChatId(chatIdInt, chatIdString),
+				ChatId:          bai.fixChatId(chatId),
 				MessageThreadId: messageThreadId,
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("deleteForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2416,23 +2382,20 @@ func (bai *BotAPIInterface) DeleteForumTopic(chatIdInt int, chatIdString string,
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "deleteForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "deleteForumTopic"}
 	}
 }
 
 /*UnpinAllForumTopicMessages clears the list of pinned messages in a forum topic. The bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup*/
-func (bai *BotAPIInterface) UnpinAllForumTopicMessages(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) UnpinAllForumTopicMessages(chatId objs.ChatID, messageThreadId int64) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.UnpinAllForumTopicMessages{
 			CloseForumTopicArgs: &objs.CloseForumTopicArgs{
-				ChatId:          bai.fixChatId(chatIdInt, chatIdString),
+				ChatId:          bai.fixChatId(chatId),
 				MessageThreadId: messageThreadId,
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("unpinAllForumTopicMessages", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2444,21 +2407,18 @@ func (bai *BotAPIInterface) UnpinAllForumTopicMessages(chatIdInt int, chatIdStri
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "unpinAllForumTopicMessages"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "unpinAllForumTopicMessages"}
 	}
 }
 
 /*EditGeneralForumTopic edits the name of the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have can_manage_topics administrator rights*/
-func (bai *BotAPIInterface) EditGeneralForumTopic(chatIdInt int, chatIdString, name string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) EditGeneralForumTopic(chatId objs.ChatID, name string) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.EditGeneralForumTopic{
-			ChatId: bai.fixChatId(chatIdInt, chatIdString),
+			ChatId: bai.fixChatId(chatId),
 			Name:   name,
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("editGeneralForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2470,20 +2430,17 @@ func (bai *BotAPIInterface) EditGeneralForumTopic(chatIdInt int, chatIdString, n
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "editGeneralForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "editGeneralForumTopic"}
 	}
 }
 
 /*CloseGeneralForumTopic closes an open 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights*/
-func (bai *BotAPIInterface) CloseGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) CloseGeneralForumTopic(chatId objs.ChatID) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.CloseGeneralForumTopic{
-			ChatId: bai.fixChatId(chatIdInt, chatIdString),
+			ChatId: bai.fixChatId(chatId),
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("closeGeneralForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2495,22 +2452,19 @@ func (bai *BotAPIInterface) CloseGeneralForumTopic(chatIdInt int, chatIdString s
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "closeGeneralForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "closeGeneralForumTopic"}
 	}
 }
 
 /*ReopenGeneralForumTopic reopens a closed 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically unhidden if it was hidden.*/
-func (bai *BotAPIInterface) ReopenGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) ReopenGeneralForumTopic(chatId objs.ChatID) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.ReopenGeneralForumTopic{
 			CloseGeneralForumTopic: &objs.CloseGeneralForumTopic{
-				ChatId: bai.fixChatId(chatIdInt, chatIdString),
+				ChatId: bai.fixChatId(chatId),
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("reopenGeneralForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2522,22 +2476,19 @@ func (bai *BotAPIInterface) ReopenGeneralForumTopic(chatIdInt int, chatIdString
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "reopenGeneralForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "reopenGeneralForumTopic"}
 	}
 }
 
 /*HideGeneralForumTopic hides the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically closed if it was open.*/
-func (bai *BotAPIInterface) HideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) HideGeneralForumTopic(chatId objs.ChatID) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.HideGeneralForumTopic{
 			CloseGeneralForumTopic: &objs.CloseGeneralForumTopic{
-				ChatId: bai.fixChatId(chatIdInt, chatIdString),
+				ChatId: bai.fixChatId(chatId),
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("hideGeneralForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2549,22 +2500,19 @@ func (bai *BotAPIInterface) HideGeneralForumTopic(chatIdInt int, chatIdString st
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "hideGeneralForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "hideGeneralForumTopic"}
 	}
 }
 
 /*UnhideGeneralForumTopic unhides the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights.*/
-func (bai *BotAPIInterface) UnhideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) UnhideGeneralForumTopic(chatId objs.ChatID) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.UnhideGeneralForumTopic{
 			CloseGeneralForumTopic: &objs.CloseGeneralForumTopic{
-				ChatId: bai.fixChatId(chatIdInt, chatIdString),
+				ChatId: bai.fixChatId(chatId),
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("unhideGeneralForumTopic", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2576,22 +2524,19 @@ func (bai *BotAPIInterface) UnhideGeneralForumTopic(chatIdInt int, chatIdString
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "unhideGeneralForumTopic"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "unhideGeneralForumTopic"}
 	}
 }
 
 /*UnpinAllGeneralForumTopicMessages clears the list of pinned messages in a General forum topic. The bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup.*/
-func (bai *BotAPIInterface) UnpinAllGeneralForumTopicMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
-	if chatIdInt != 0 && chatIdString != "" {
-		return nil, &errs.ChatIdProblem{}
-	}
-	if bai.isChatIdOk(chatIdInt, chatIdString) {
+func (bai *BotAPIInterface) UnpinAllGeneralForumTopicMessages(chatId objs.ChatID) (*objs.Result[bool], error) {
+	if !chatId.IsZero() {
 		args := &objs.UnpinAllGeneralForumTopicMessages{
 			CloseGeneralForumTopic: &objs.CloseGeneralForumTopic{
-				ChatId: bai.fixChatId(chatIdInt, chatIdString),
+				ChatId: bai.fixChatId(chatId),
 			},
 		}
-		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+		args.ChatId = bai.fixChatId(chatId)
 		res, err := bai.SendCustom("unpinAllGeneralForumTopicMessages", args, false, nil)
 		if err != nil {
 			return nil, err
@@ -2603,7 +2548,7 @@ func (bai *BotAPIInterface) UnpinAllGeneralForumTopicMessages(chatIdInt int, cha
 		}
 		return msg, nil
 	} else {
-		return nil, &errs.RequiredArgumentError{ArgName: "chatIdInt or chatIdString", MethodName: "unpinAllGeneralForumTopicMessages"}
+		return nil, &errs.RequiredArgumentError{ArgName: "chatId", MethodName: "unpinAllGeneralForumTopicMessages"}
 	}
 }
 
@@ -2712,9 +2657,56 @@ func (bai *BotAPIInterface) GetMyName(languageCode string) (*objs.Result[*objs.B
 	return msg, nil
 }
 
-/*SendCustom calls the given method on api server with the given arguments. "MP" options indicates that the request should be made in multipart/formdata form. If this method sends a file to the api server the "MP" option should be true*/
-func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
-	start := time.Now().UnixMicro()
+/*
+SendCustom calls the given method on api server with the given arguments. "MP" options indicates that the request should be made in multipart/formdata form. If this method sends a file to the api server the "MP" option should be true.
+If the target group has been migrated to a supergroup, the migration is recorded and the request is sent again to the supergroup.
+*/
+func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...objs.NamedReader) ([]byte, error) {
+	res, err := bai.sendCustom(methodName, args, MP, files...)
+	if err == nil {
+		return res, nil
+	}
+	chatId, hasChatId := argsChatId(args)
+	if hasChatId && bai.onBotBlocked != nil && errors.Is(err, errs.ErrBotBlocked) {
+		bai.onBotBlocked(chatId)
+	}
+	var apiErr *errs.APIError
+	if !hasChatId || !errors.As(err, &apiErr) || apiErr.MigrateToChatId == 0 {
+		return res, err
+	}
+	oldChatId := chatId
+	bai.MigrateChat(oldChatId, apiErr.MigrateToChatId)
+	setter, ok := args.(chatIdSetter)
+	if !ok {
+		return res, err
+	}
+	//The files are sent again, so they should be read from the start. Readers which are not seekable can't be sent again.
+	for _, file := range files {
+		if objs.IsNilFile(file) {
+			continue
+		}
+		seeker, ok := file.(io.Seeker)
+		if !ok {
+			return res, err
+		}
+		if _, err3 := seeker.Seek(0, io.SeekStart); err3 != nil {
+			return res, err
+		}
+	}
+	bai.logger.Info("Chat has been migrated to a supergroup. Sending the request again", logger.Method(methodName), logger.ChatId(oldChatId), logger.Any("new_chat_id", apiErr.MigrateToChatId))
+	setter.SetChatId(bai.fixChatId(objs.IntChatID(apiErr.MigrateToChatId)))
+	return bai.sendCustom(methodName, args, MP, files...)
+}
+
+func (bai *BotAPIInterface) sendCustom(methodName string, args objs.MethodArguments, MP bool, files ...objs.NamedReader) ([]byte, error) {
+	_, span := tracing.Start(bai.ctx, "telego.api "+methodName, tracing.String(tracing.AttrMethod, methodName))
+	defer span.End()
+	fields := []logger.Field{logger.Method(methodName)}
+	if chatId := argsChatIdString(args); chatId != "" {
+		span.SetAttributes(tracing.String(tracing.AttrChatId, chatId))
+		fields = append(fields, logger.ChatId(chatId))
+	}
+	start := time.Now()
 	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
 	var res []byte
 	var err2 error
@@ -2723,16 +2715,66 @@ func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArgume
 	} else {
 		res, err2 = cl.sendHttpReqJson(methodName, args)
 	}
-	done := time.Now().UnixMicro()
+	latency := time.Since(start)
+	metrics.APIRequestDuration.With(methodName).ObserveDuration(latency)
 	if err2 != nil {
-		bai.logger.Log(methodName, "\t\t\t", "Error  ", strconv.FormatInt((done-start), 10)+"µs", logger.BOLD+logger.OKBLUE, logger.FAIL, "")
+		bai.recordFailure(methodName, err2, span)
+		bai.logger.Error("API call failed", append(fields, logger.Latency(latency), logger.Err(err2))...)
 		return nil, err2
 	}
-	bai.logger.Log(methodName, "\t\t\t", "Success", strconv.FormatInt((done-start), 10)+"µs", logger.BOLD+logger.OKBLUE, logger.OKGREEN, "")
-	return bai.preParseResult(res, methodName)
+	out, err := bai.preParseResult(res, methodName)
+	if err != nil {
+		bai.recordFailure(methodName, err, span)
+		bai.logger.Error("API call failed", append(fields, logger.Latency(latency), logger.Err(err))...)
+		return nil, err
+	}
+	metrics.APIRequests.With(methodName, metrics.OutcomeOk).Inc()
+	bai.logger.Info("API call succeeded", append(fields, logger.Latency(latency))...)
+	return out, nil
+}
+
+/*Updates the metrics and the span of a failed API call. Flood control errors (429) are counted separately too.*/
+func (bai *BotAPIInterface) recordFailure(methodName string, err error, span tracing.Span) {
+	span.RecordError(err)
+	var apiErr *errs.APIError
+	if !errors.As(err, &apiErr) {
+		//The request could not be sent or the response could not be parsed.
+		metrics.APIRequests.With(methodName, metrics.OutcomeNetworkError).Inc()
+		return
+	}
+	span.SetAttributes(tracing.Int(tracing.AttrErrorCode, apiErr.ErrorCode))
+	metrics.APIRequests.With(methodName, metrics.OutcomeAPIError).Inc()
+	if errors.Is(err, errs.ErrTooManyRequests) {
+		metrics.FloodWaits.With(methodName).Inc()
+		metrics.FloodWaitSeconds.With(methodName).Add(apiErr.RetryAfter.Seconds())
+	}
+}
+
+/*Returns the integer chat id of the given arguments. The second return value is false if the arguments don't have an integer chat id.*/
+func argsChatId(args objs.MethodArguments) (int64, bool) {
+	chatId, err := strconv.ParseInt(argsChatIdString(args), 10, 64)
+	return chatId, err == nil
 }
 
-func (bai *BotAPIInterface) fixTheDefaultArguments(chatIdInt, reply_to_message_id, messageThreadId int, chatIdString string, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) objs.DefaultSendMethodsArguments {
+/*SetBotBlockedHandler sets the function which is called when a request fails because the user has blocked the bot.*/
+func (bai *BotAPIInterface) SetBotBlockedHandler(handler func(chatId int64)) {
+	bai.onBotBlocked = handler
+}
+
+/*Returns the chat id of the given arguments, a username is returned without the quotes. It is empty if the arguments don't have a chat id.*/
+func argsChatIdString(args objs.MethodArguments) string {
+	getter, ok := args.(chatIdGetter)
+	if !ok {
+		return ""
+	}
+	chatId := getter.GetChatId()
+	if len(chatId) == 0 || string(chatId) == "null" {
+		return ""
+	}
+	return string(bytes.Trim(chatId, "\""))
+}
+
+func (bai *BotAPIInterface) fixTheDefaultArguments(chatId objs.ChatID, reply_to_message_id, messageThreadId int64, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) objs.DefaultSendMethodsArguments {
 	def := objs.DefaultSendMethodsArguments{
 		DisableNotification:      disable_notification,
 		AllowSendingWithoutReply: allow_sending_without_reply,
@@ -2741,7 +2783,7 @@ func (bai *BotAPIInterface) fixTheDefaultArguments(chatIdInt, reply_to_message_i
 		ReplyMarkup:              reply_markup,
 		MessageThreadId:          messageThreadId,
 	}
-	def.ChatId = bai.fixChatId(chatIdInt, chatIdString)
+	def.ChatId = bai.fixChatId(chatId)
 	return def
 }
 
@@ -2762,24 +2804,27 @@ func (bai *BotAPIInterface) preParseResult(res []byte, method string) ([]byte, e
 	return res, nil
 }
 
-func (bai *BotAPIInterface) fixChatId(chatIdInt int, chatIdString string) []byte {
-	if chatIdInt == 0 {
-		if !strings.HasPrefix(chatIdString, "@") {
-			chatIdString = "@" + chatIdString
-		}
-		bt, _ := json.Marshal(chatIdString)
-		return bt
-	} else {
-		bt, _ := json.Marshal(chatIdInt)
-		return bt
+func (bai *BotAPIInterface) fixChatId(chatId objs.ChatID) []byte {
+	if chatId.IsZero() {
+		return nil
+	}
+	bt, _ := json.Marshal(bai.migrateChatId(chatId))
+	return bt
+}
+
+/*Returns the new id of the chat if it has been migrated to a supergroup. Usernames are returned as they are.*/
+func (bai *BotAPIInterface) migrateChatId(chatId objs.ChatID) objs.ChatID {
+	if chatId.IsZero() || chatId.IsUsername() {
+		return chatId
 	}
+	return objs.IntChatID(bai.MigratedChatId(chatId.Int()))
 }
 
 /*
 CreateInterface returns an iterface to communicate with the bot api.
 If the updateFrequency argument is not nil, the update routine begins automtically
 */
-func CreateInterface(botCfg *cfgs.BotConfigs, botLogger *logger.BotLogger) (*BotAPIInterface, error) {
+func CreateInterface(botCfg *cfgs.BotConfigs, botLogger logger.Logger) (*BotAPIInterface, error) {
 	if interfaceCreated {
 		return nil, &errs.BotInterfaceAlreadyCreated{}
 	}
@@ -2792,6 +2837,7 @@ func CreateInterface(botCfg *cfgs.BotConfigs, botLogger *logger.BotLogger) (*Bot
 		chatUpadateChannel: &ch3,
 		updateParser:       parser.CreateUpdateParser(&ch, &ch3, botCfg, botLogger),
 		logger:             botLogger,
+		migrations:         &chatMigrations{chats: make(map[int64]int64)},
 	}
 	return temp, nil
 }
@@ -1,22 +1,36 @@
 package tba
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	cfg "github.com/hamidteimouri/telego/configs"
 	log "github.com/hamidteimouri/telego/logger"
+	"github.com/hamidteimouri/telego/metrics"
 	objs "github.com/hamidteimouri/telego/objects"
 	up "github.com/hamidteimouri/telego/parser"
 )
 
+// certificateCheckInterval is the interval between two checks of the generated certificate expiry date.
+const certificateCheckInterval = time.Hour
+
 type Webhook struct {
 	configs          *cfg.BotConfigs
 	isSecretTokenSet bool
 	parser           *up.UpdateParser
-	Logger           *log.BotLogger
+	certificates     *certificateStore
+	server           *http.Server
+	challengeServer  *http.Server
+	stopChannel      chan bool
+	Logger           log.Logger
+	//OnCertificateRotated is called after the generated certificate is rotated, so the new certificate can be uploaded to the API server.
+	OnCertificateRotated func() error
 }
 
 // StartWebHook starts the webhook.
@@ -26,20 +40,121 @@ func (w *Webhook) StartWebHook(cfg *cfg.BotConfigs, parser *up.UpdateParser) err
 	// chatUpdateChannel = cuc
 	w.isSecretTokenSet = cfg.WebHookConfigs.SecretToken != ""
 	w.parser = parser
-	w.startTheServer()
+	w.stopChannel = make(chan bool)
+	if cfg.WebHookConfigs.ACME != nil {
+		m, err := newACMEManager(cfg.WebHookConfigs)
+		if err != nil {
+			return err
+		}
+		if cfg.WebHookConfigs.ACME.HTTPChallengePort != 0 {
+			w.challengeServer = &http.Server{
+				Addr:    ":" + strconv.Itoa(cfg.WebHookConfigs.ACME.HTTPChallengePort),
+				Handler: m.HTTPHandler(nil),
+			}
+			go w.startChallengeServer()
+		}
+		return w.startTheServer(m.TLSConfig())
+	}
+	w.certificates = &certificateStore{}
+	err := w.certificates.load(cfg.WebHookConfigs.CertFile, cfg.WebHookConfigs.KeyFile)
+	if err != nil {
+		return err
+	}
+	err = w.startTheServer(&tls.Config{GetCertificate: w.certificates.getCertificate})
+	if err != nil {
+		return err
+	}
+	if cfg.WebHookConfigs.GenerateCertificate {
+		go w.startCertificateRotation()
+	}
 	return nil
 }
 
-func (w *Webhook) startTheServer() {
+/*
+StopWebHook stops the webhook server gracefully. Requests that are being processed are completed before this method returns.
+This method does not delete the webhook from the API server.
+*/
+func (w *Webhook) StopWebHook() error {
+	if w.server == nil {
+		return nil
+	}
+	close(w.stopChannel)
+	if w.challengeServer != nil {
+		_ = w.challengeServer.Shutdown(context.Background())
+		w.challengeServer = nil
+	}
+	err := w.server.Shutdown(context.Background())
+	w.server = nil
+	return err
+}
+
+/*Starts the HTTP server that answers the http-01 challenges of the ACME server.*/
+func (w *Webhook) startChallengeServer() {
+	err := w.challengeServer.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		w.Logger.Error("Webhook : Failed to start the ACME challenge server", log.Err(err))
+	}
+}
+
+func (w *Webhook) startTheServer(tlsConfig *tls.Config) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", w.mainHandler)
 	mux.HandleFunc("/"+w.configs.APIKey, w.handleReq)
+	if w.configs.WebHookConfigs.MetricsPath != "" {
+		mux.Handle(w.configs.WebHookConfigs.MetricsPath, metrics.Handler())
+	}
+	w.server = &http.Server{
+		Addr:      ":" + strconv.Itoa(w.configs.WebHookConfigs.Port),
+		Handler:   mux,
+		TLSConfig: tlsConfig,
+	}
+	ln, err := net.Listen("tcp", w.server.Addr)
+	if err != nil {
+		return err
+	}
+	server := w.server
 	go func() {
-		err := http.ListenAndServeTLS(":"+strconv.Itoa(w.configs.WebHookConfigs.Port), w.configs.WebHookConfigs.CertFile, w.configs.WebHookConfigs.KeyFile, mux)
-		if err != nil {
-			w.Logger.GetRaw().Fatalln("Webhook : Failed to start the HTTPS server.", err)
+		err := server.ServeTLS(ln, "", "")
+		if err != nil && err != http.ErrServerClosed {
+			w.Logger.Error("Webhook : HTTPS server stopped", log.Err(err))
 		}
 	}()
+	return nil
+}
+
+func (w *Webhook) startCertificateRotation() {
+	ticker := time.NewTicker(certificateCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stopChannel:
+			return
+		case <-ticker.C:
+			w.rotateCertificate()
+		}
+	}
+}
+
+func (w *Webhook) rotateCertificate() {
+	whc := w.configs.WebHookConfigs
+	if time.Until(w.certificates.expiresAt()) > whc.CertificateRenewBefore {
+		return
+	}
+	w.Logger.Info("Webhook : Certificate is about to expire. Generating a new certificate", log.Any("expires_at", w.certificates.expiresAt()))
+	err := generateWebhookCertificate(whc)
+	if err == nil {
+		err = w.certificates.load(whc.CertFile, whc.KeyFile)
+	}
+	if err != nil {
+		w.Logger.Error("Webhook : Failed to rotate the certificate", log.Err(err))
+		return
+	}
+	if w.OnCertificateRotated != nil {
+		err = w.OnCertificateRotated()
+		if err != nil {
+			w.Logger.Error("Webhook : Failed to upload the new certificate", log.Err(err))
+		}
+	}
 }
 
 func (w *Webhook) mainHandler(wr http.ResponseWriter, req *http.Request) {
@@ -68,19 +183,19 @@ func (w *Webhook) handleReq(wr http.ResponseWriter, req *http.Request) {
 					// up.ParseSingleUpdate(update, interfaceUpdateChannel, chatUpdateChannel, configs)
 					w.parser.ExecuteChain(update)
 				} else {
-					w.Logger.GetRaw().Println("Webhook : Error parsing the update. Address :", req.RemoteAddr, ". Error :", jsonErr)
+					w.Logger.Warn("Webhook : Error parsing the update", log.String("address", req.RemoteAddr), log.Err(jsonErr))
 				}
 			} else {
-				w.Logger.GetRaw().Println("Webhook : Error reading the body. Address :", req.RemoteAddr, ". Error :", err)
+				w.Logger.Warn("Webhook : Error reading the body", log.String("address", req.RemoteAddr), log.Err(err))
 			}
 			wr.WriteHeader(200)
 			wr.Write([]byte{})
 		} else {
-			w.Logger.GetRaw().Println("Webhook : Request has no body. Address :", req.RemoteAddr)
+			w.Logger.Warn("Webhook : Request has no body", log.String("address", req.RemoteAddr))
 			w.send400(&wr, "Request has no body")
 		}
 	} else {
-		w.Logger.GetRaw().Println("Webhook : \"Content-Type\" header is not json or it's missing. Address :", req.RemoteAddr)
+		w.Logger.Warn("Webhook : \"Content-Type\" header is not json or it's missing", log.String("address", req.RemoteAddr))
 		w.send400(&wr, " \"Content-Type\" header is not json or it's missing")
 	}
 }
@@ -0,0 +1,56 @@
+package tba
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+/*Creates a certificate manager that obtains and renews the webhook certificate from the ACME server given in the configs. The directory url of the configs can point to a test server such as Pebble.*/
+func newACMEManager(whc *cfg.WebHookConfigs) (*autocert.Manager, error) {
+	u, err := url.Parse(whc.URL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Hostname()
+	if host == "" {
+		return nil, errors.New("unable to find the host name of the webhook url")
+	}
+	if net.ParseIP(host) != nil {
+		return nil, errors.New("ACME certificates can't be obtained for an ip address : " + host)
+	}
+	//The configs are copied, so the defaults are set even if the configs have not been checked.
+	ac := *whc.ACME
+	ac.FixDefaults()
+	client := &acme.Client{DirectoryURL: ac.DirectoryURL}
+	if ac.RootCAFile != "" {
+		pemData, err := os.ReadFile(ac.RootCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, errors.New("no certificate found in " + ac.RootCAFile)
+		}
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		}
+	}
+	m := &autocert.Manager{
+		Prompt:      func(string) bool { return ac.AcceptTOS },
+		Cache:       autocert.DirCache(ac.CacheDir),
+		HostPolicy:  autocert.HostWhitelist(host),
+		Email:       ac.Email,
+		Client:      client,
+		RenewBefore: whc.CertificateRenewBefore,
+	}
+	return m, nil
+}
@@ -0,0 +1,321 @@
+package tba
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+func TestNewACMEManager(t *testing.T) {
+	//A self signed certificate stands for the root certificate of the test ACME server.
+	root := newCertificateConfigs(t, "https://pebble.local/token", "")
+	if _, err := EnsureWebhookCertificate(root); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	whc := &cfg.WebHookConfigs{
+		URL: "https://example.com:8443/token", CertificateRenewBefore: 10 * 24 * time.Hour,
+		ACME: &cfg.ACMEConfigs{
+			DirectoryURL: "https://localhost:14000/dir", Email: "admin@example.com", CacheDir: cacheDir,
+			RootCAFile: root.CertFile, AcceptTOS: true,
+		},
+	}
+	m, err := newACMEManager(whc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Client.DirectoryURL != "https://localhost:14000/dir" {
+		t.Errorf("expected the directory url of the configs, got %s", m.Client.DirectoryURL)
+	}
+	if m.Email != "admin@example.com" {
+		t.Errorf("unexpected email %s", m.Email)
+	}
+	if m.RenewBefore != 10*24*time.Hour {
+		t.Errorf("expected the renew duration of the configs, got %v", m.RenewBefore)
+	}
+	if m.Cache != autocert.DirCache(cacheDir) {
+		t.Errorf("unexpected cache %v", m.Cache)
+	}
+	if !m.Prompt("https://localhost:14000/tos") {
+		t.Error("the terms of service should be accepted")
+	}
+	if err := m.HostPolicy(context.Background(), "example.com"); err != nil {
+		t.Errorf("the host of the webhook url should be allowed : %v", err)
+	}
+	if err := m.HostPolicy(context.Background(), "other.com"); err == nil {
+		t.Error("other hosts should not be allowed")
+	}
+	transport, ok := m.Client.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil {
+		t.Fatalf("the http client should trust the root certificate, got %#v", m.Client.HTTPClient)
+	}
+	pool := x509.NewCertPool()
+	pemData, _ := os.ReadFile(root.CertFile)
+	pool.AppendCertsFromPEM(pemData)
+	if !transport.TLSClientConfig.RootCAs.Equal(pool) {
+		t.Error("the root certificates should be loaded from the root ca file")
+	}
+}
+
+func TestNewACMEManagerDefaults(t *testing.T) {
+	whc := &cfg.WebHookConfigs{URL: "https://example.com/token", ACME: &cfg.ACMEConfigs{}}
+	m, err := newACMEManager(whc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Client.DirectoryURL != cfg.DefaultACMEDirectory {
+		t.Errorf("expected the default directory url, got %s", m.Client.DirectoryURL)
+	}
+	if m.Cache != autocert.DirCache(cfg.DefaultACMECacheDir) {
+		t.Errorf("expected the default cache directory, got %v", m.Cache)
+	}
+	if m.Client.HTTPClient != nil {
+		t.Error("the default http client should be used without a root ca file")
+	}
+	if m.Prompt("tos") {
+		t.Error("the terms of service should not be accepted unless AcceptTOS is true")
+	}
+	if whc.ACME.DirectoryURL != "" || whc.ACME.CacheDir != "" {
+		t.Error("the configs should not be modified")
+	}
+}
+
+func TestNewACMEManagerErrors(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "root.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		whc  *cfg.WebHookConfigs
+	}{
+		{"url without host", &cfg.WebHookConfigs{URL: "/token", ACME: &cfg.ACMEConfigs{}}},
+		{"ip host", &cfg.WebHookConfigs{URL: "https://203.0.113.5/token", ACME: &cfg.ACMEConfigs{}}},
+		{"missing root ca file", &cfg.WebHookConfigs{URL: "https://example.com/token", ACME: &cfg.ACMEConfigs{RootCAFile: filepath.Join(t.TempDir(), "missing.pem")}}},
+		{"root ca file without certificates", &cfg.WebHookConfigs{URL: "https://example.com/token", ACME: &cfg.ACMEConfigs{RootCAFile: notPEM}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newACMEManager(tt.whc); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+/*A minimal ACME server for the tests. It validates the tls-alpn-01 challenge by asking the manager for the challenge certificate and signs the certificate requests with its own root certificate. The signatures of the requests are not verified.*/
+type fakeACMEServer struct {
+	*httptest.Server
+	t         *testing.T
+	manager   *autocert.Manager
+	host      string
+	rootKey   *ecdsa.PrivateKey
+	root      *x509.Certificate
+	mu        sync.Mutex
+	status    string
+	certChain []byte
+	validated bool
+}
+
+func newFakeACMEServer(t *testing.T, host string) *fakeACMEServer {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "fake acme root"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(24 * time.Hour),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &rootKey.PublicKey, rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ := x509.ParseCertificate(der)
+	s := &fakeACMEServer{t: t, host: host, rootKey: rootKey, root: root, status: acme.StatusPending}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeACMEServer) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", strconv.FormatInt(time.Now().UnixNano(), 36))
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/dir":
+		writeJSON(w, http.StatusOK, map[string]any{
+			"newNonce": s.URL + "/nonce", "newAccount": s.URL + "/account", "newOrder": s.URL + "/order",
+			"revokeCert": s.URL + "/revoke", "keyChange": s.URL + "/key",
+		})
+	case "/account":
+		w.Header().Set("Location", s.URL+"/account/1")
+		writeJSON(w, http.StatusCreated, map[string]any{"status": acme.StatusValid})
+	case "/order":
+		w.Header().Set("Location", s.URL+"/order/1")
+		writeJSON(w, http.StatusCreated, s.order())
+	case "/order/1":
+		writeJSON(w, http.StatusOK, s.order())
+	case "/authz/1":
+		status := acme.StatusPending
+		if s.status != acme.StatusPending {
+			status = acme.StatusValid
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status": status, "identifier": map[string]string{"type": "dns", "value": s.host},
+			"challenges": []map[string]string{{"type": "tls-alpn-01", "url": s.URL + "/challenge/1", "token": "token", "status": status}},
+		})
+	case "/challenge/1":
+		//The manager must serve the challenge certificate before the challenge is accepted.
+		cert, err := s.manager.GetCertificate(&tls.ClientHelloInfo{ServerName: s.host, SupportedProtos: []string{acme.ALPNProto}})
+		if err != nil {
+			s.t.Errorf("the challenge certificate should be served : %v", err)
+			writeJSON(w, http.StatusBadRequest, map[string]any{"type": "urn:ietf:params:acme:error:unauthorized"})
+			return
+		}
+		s.validated = cert != nil
+		s.status = acme.StatusReady
+		writeJSON(w, http.StatusOK, map[string]any{"type": "tls-alpn-01", "url": s.URL + "/challenge/1", "token": "token", "status": acme.StatusValid})
+	case "/finalize/1":
+		if s.status != acme.StatusReady {
+			writeJSON(w, http.StatusForbidden, map[string]any{"type": "urn:ietf:params:acme:error:orderNotReady"})
+			return
+		}
+		if err := s.issue(r); err != nil {
+			s.t.Errorf("unable to issue the certificate : %v", err)
+			writeJSON(w, http.StatusBadRequest, map[string]any{"type": "urn:ietf:params:acme:error:badCSR"})
+			return
+		}
+		s.status = acme.StatusValid
+		writeJSON(w, http.StatusOK, s.order())
+	case "/cert/1":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.Write(s.certChain)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]any{"type": "urn:ietf:params:acme:error:malformed"})
+	}
+}
+
+func (s *fakeACMEServer) order() map[string]any {
+	order := map[string]any{
+		"status": s.status, "identifiers": []map[string]string{{"type": "dns", "value": s.host}},
+		"authorizations": []string{s.URL + "/authz/1"}, "finalize": s.URL + "/finalize/1",
+	}
+	if s.status == acme.StatusValid {
+		order["certificate"] = s.URL + "/cert/1"
+	}
+	return order
+}
+
+/*Signs the certificate request in the payload of the finalize request.*/
+func (s *fakeACMEServer) issue(r *http.Request) error {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return err
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return err
+	}
+	var req struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return err
+	}
+	csrDER, err := base64.RawURLEncoding.DecodeString(req.CSR)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: s.host}, DNSNames: csr.DNSNames,
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage: x509.KeyUsageDigitalSignature, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, s.root, csr.PublicKey, s.rootKey)
+	if err != nil {
+		return err
+	}
+	s.certChain = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.root.Raw})...)
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestACMEManagerIssuesCertificate(t *testing.T) {
+	server := newFakeACMEServer(t, "example.com")
+	rootCAFile := filepath.Join(t.TempDir(), "root.pem")
+	if err := os.WriteFile(rootCAFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	m, err := newACMEManager(&cfg.WebHookConfigs{
+		URL: "https://example.com:8443/token",
+		ACME: &cfg.ACMEConfigs{
+			DirectoryURL: server.URL + "/dir", CacheDir: cacheDir, RootCAFile: rootCAFile, AcceptTOS: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.manager = m
+	cert, err := m.GetCertificate(&tls.ClientHelloInfo{
+		ServerName: "example.com", CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		SignatureSchemes: []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256}, SupportedCurves: []tls.CurveID{tls.CurveP256},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.mu.Lock()
+	validated := server.validated
+	server.mu.Unlock()
+	if !validated {
+		t.Error("the challenge should be validated")
+	}
+	if err := cert.Leaf.VerifyHostname("example.com"); err != nil {
+		t.Error(err)
+	}
+	if err := cert.Leaf.CheckSignatureFrom(server.root); err != nil {
+		t.Errorf("the certificate should be issued by the ACME server : %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "example.com")); err != nil {
+		t.Errorf("the certificate should be cached : %v", err)
+	}
+	if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "other.com"}); err == nil {
+		t.Error("certificates should not be issued for other hosts")
+	}
+}
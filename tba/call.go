@@ -0,0 +1,49 @@
+package tba
+
+import (
+	"encoding/json"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*
+Call calls the given method of the bot API and decodes the result of the method into T. It can be used for calling the methods which don't have a wrapper yet.
+
+The arguments can be any MethodArguments. Use objs.Args to pass them as a map. If objs.Args contains files, the request is sent as a multipart form.
+If the "chat_id" argument is an objs.ChatID, the migrated groups are handled like the other methods.
+
+Failures returned by the API server can be checked with errors.Is and errors.As like the other methods (*errs.APIError). If the result can not be decoded into T, an *errs.ResultDecodeError is returned.
+*/
+func Call[T any](bai *BotAPIInterface, method string, args objs.MethodArguments) (T, error) {
+	var zero T
+	if method == "" {
+		return zero, &errs.RequiredArgumentError{ArgName: "method", MethodName: "call"}
+	}
+	var files []objs.NamedReader
+	if a, ok := args.(objs.Args); ok {
+		//The map of the caller is not modified.
+		cp := make(objs.Args, len(a))
+		for key, v := range a {
+			cp[key] = v
+		}
+		if chatId, ok := cp["chat_id"].(objs.ChatID); ok {
+			if chatId.IsZero() {
+				delete(cp, "chat_id")
+			} else {
+				cp["chat_id"] = json.RawMessage(bai.fixChatId(chatId))
+			}
+		}
+		files = cp.Files()
+		args = cp
+	}
+	res, err := bai.SendCustom(method, args, len(files) != 0, files...)
+	if err != nil {
+		return zero, err
+	}
+	out := &objs.Result[T]{}
+	if err := json.Unmarshal(res, out); err != nil {
+		return zero, &errs.ResultDecodeError{Method: method, Err: err}
+	}
+	return out.Result, nil
+}
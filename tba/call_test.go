@@ -0,0 +1,179 @@
+package tba
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cfgs "github.com/hamidteimouri/telego/configs"
+	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func newCallTestInterface(t *testing.T, handler http.HandlerFunc) *BotAPIInterface {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &BotAPIInterface{
+		botConfigs: &cfgs.BotConfigs{BotAPI: srv.URL + "/bot", APIKey: "token"},
+		logger:     logger.Nop(),
+		migrations: &chatMigrations{chats: map[int64]int64{-1: -1001}},
+	}
+}
+
+func TestCall(t *testing.T) {
+	var gotPath string
+	var gotArgs map[string]any
+	bai := newCallTestInterface(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotArgs)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":5,"date":1,"chat":{"id":-1001,"type":"supergroup"}}}`))
+	})
+	args := objs.Args{"chat_id": objs.IntChatID(-1), "text": "hi", "unset": nil}
+	msg, err := Call[*objs.Message](bai, "sendMessage", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.MessageId != 5 || msg.Chat.Id != -1001 {
+		t.Fatalf("unexpected result %#v", msg)
+	}
+	if gotPath != "/bottoken/sendMessage" {
+		t.Fatalf("request was sent to %s", gotPath)
+	}
+	if gotArgs["chat_id"] != float64(-1001) || gotArgs["text"] != "hi" || len(gotArgs) != 2 {
+		t.Fatalf("server received %v", gotArgs)
+	}
+	if _, ok := args["chat_id"].(objs.ChatID); !ok {
+		t.Fatal("the arguments of the caller should not be modified")
+	}
+}
+
+func TestCallMultiPart(t *testing.T) {
+	var gotPhoto, gotChatId, gotFile string
+	bai := newCallTestInterface(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			return
+		}
+		gotPhoto, gotChatId = r.FormValue("photo"), r.FormValue("chat_id")
+		if fl, _, err := r.FormFile("photo.jpg"); err == nil {
+			bts, _ := io.ReadAll(fl)
+			gotFile = string(bts)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	})
+	ok, err := Call[bool](bai, "setChatPhoto", objs.Args{
+		"chat_id": objs.UsernameChatID("channel"),
+		"photo":   objs.NewNamedReader("photo.jpg", strings.NewReader("image")),
+	})
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v", ok, err)
+	}
+	if gotPhoto != "attach://photo.jpg" || gotChatId != "@channel" || gotFile != "image" {
+		t.Fatalf("server received photo %q, chat id %q and file %q", gotPhoto, gotChatId, gotFile)
+	}
+}
+
+func TestCallErrors(t *testing.T) {
+	bai := newCallTestInterface(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			_, _ = w.Write([]byte(`{"ok":true,"result":"not a user"}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+	})
+	_, err := Call[bool](bai, "sendMessage", objs.Args{"chat_id": 10, "text": "hi"})
+	if !errors.Is(err, errs.ErrBotBlocked) {
+		t.Fatalf("expected ErrBotBlocked, got %v", err)
+	}
+	_, err = Call[*objs.User](bai, "getMe", nil)
+	var decodeErr *errs.ResultDecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Method != "getMe" {
+		t.Fatalf("expected ResultDecodeError, got %v", err)
+	}
+}
+
+func TestGeneratedMethod(t *testing.T) {
+	var gotArgs map[string]any
+	bai := newCallTestInterface(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotArgs)
+		_, _ = w.Write([]byte(`{"ok":true,"result":[{"message_id":7},{"message_id":8}]}`))
+	})
+	args := &objs.CopyMessagesArgs{ChatId: objs.IntChatID(-1), FromChatId: objs.UsernameChatID("channel"), MessageIds: []int64{1, 2}}
+	res, err := bai.CopyMessages(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Result) != 2 || res.Result[1].MessageId != 8 {
+		t.Fatalf("unexpected result %#v", res.Result)
+	}
+	if gotArgs["chat_id"] != float64(-1001) || gotArgs["from_chat_id"] != "@channel" {
+		t.Fatalf("server received %v", gotArgs)
+	}
+	if args.ChatId.Int() != -1 {
+		t.Fatal("the arguments of the caller should not be modified")
+	}
+	_, err = bai.CopyMessages(&objs.CopyMessagesArgs{ChatId: objs.IntChatID(1)})
+	var reqErr *errs.RequiredArgumentError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequiredArgumentError, got %v", err)
+	}
+}
+
+func TestArgsChatIdString(t *testing.T) {
+	tests := []struct {
+		name string
+		args objs.MethodArguments
+		want string
+	}{
+		{"no arguments", nil, ""},
+		{"no chat", &objs.GetUpdatesArgs{}, ""},
+		{"send method", &objs.SendMessageArgs{DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{ChatId: json.RawMessage("-100")}}, "-100"},
+		{"username", &objs.DefaultChatArgs{ChatId: json.RawMessage(`"@channel"`)}, "@channel"},
+		{"embedded edit arguments", &objs.EditMessageTextArgs{EditMessageDefaultArgs: objs.EditMessageDefaultArgs{ChatId: json.RawMessage("7")}}, "7"},
+		{"inline message", &objs.EditMessageTextArgs{}, ""},
+		{"integer chat id", &objs.SetGameScoreArgs{ChatId: 8}, "8"},
+		{"generated arguments", &objs.DeleteMessagesArgs{ChatId: objs.IntChatID(9)}, "9"},
+		{"generated arguments without chat", &objs.DeleteMessagesArgs{}, ""},
+		{"map arguments", objs.Args{"chat_id": objs.UsernameChatID("@group")}, "@group"},
+		{"map arguments without chat", objs.Args{"text": "hi"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := argsChatIdString(tt.args); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSendCustomLogsChatId(t *testing.T) {
+	bai := newCallTestInterface(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	})
+	var buf strings.Builder
+	lg := logger.NewTextLogger(&buf, "bot", logger.LevelDebug)
+	lg.Uncolor()
+	bai.logger = lg
+	if _, err := bai.SendCustom("getMe", nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bai.SendCustom("sendChatAction", &objs.SendChatActionArgs{ChatId: json.RawMessage(`"@channel"`), Action: "typing"}, false); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", buf.String())
+	}
+	if strings.Contains(lines[0], "chat_id") {
+		t.Errorf("the chat id should be omitted when there is no chat, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "chat_id=@channel") {
+		t.Errorf("the chat id should be logged, got %q", lines[1])
+	}
+}
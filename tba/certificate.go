@@ -0,0 +1,163 @@
+package tba
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+/*Holds the certificate of the webhook server and swaps it when the certificate is rotated.*/
+type certificateStore struct {
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	notAfter time.Time
+}
+
+func (cs *certificateStore) load(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	cs.mu.Lock()
+	cs.cert = &cert
+	cs.notAfter = leaf.NotAfter
+	cs.mu.Unlock()
+	return nil
+}
+
+func (cs *certificateStore) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if cs.cert == nil {
+		return nil, errors.New("webhook certificate is not loaded")
+	}
+	return cs.cert, nil
+}
+
+func (cs *certificateStore) expiresAt() time.Time {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.notAfter
+}
+
+/*
+EnsureWebhookCertificate makes sure a valid self signed certificate exists in the CertFile and KeyFile of the given configs.
+A new certificate is generated if the files don't exist, can't be parsed or the certificate expires within CertificateRenewBefore.
+Returns true if a new certificate has been generated, which means it should be uploaded to the API server again.
+
+This function does nothing if GenerateCertificate option is false.
+*/
+func EnsureWebhookCertificate(whc *cfg.WebHookConfigs) (bool, error) {
+	if !whc.GenerateCertificate {
+		return false, nil
+	}
+	cs := &certificateStore{}
+	if err := cs.load(whc.CertFile, whc.KeyFile); err == nil && time.Until(cs.expiresAt()) > whc.CertificateRenewBefore {
+		return false, nil
+	}
+	return true, generateWebhookCertificate(whc)
+}
+
+/*Generates a new self signed certificate for the host of the webhook url and saves it in the cert and key files.*/
+func generateWebhookCertificate(whc *cfg.WebHookConfigs) error {
+	u, err := url.Parse(whc.URL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("unable to find the host name of the webhook url")
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(whc.CertificateValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	} else {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+	if ip := net.ParseIP(whc.IP); ip != nil && ip.String() != host {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	certBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	//Both files are written completely before they replace the old ones, so a failure while writing doesn't leave a new key with the old certificate.
+	keyTmp, err := writeTempFile(whc.KeyFile, keyBytes, 0600)
+	if err != nil {
+		return err
+	}
+	certTmp, err := writeTempFile(whc.CertFile, certBytes, 0644)
+	if err != nil {
+		os.Remove(keyTmp)
+		return err
+	}
+	if err := os.Rename(keyTmp, whc.KeyFile); err != nil {
+		os.Remove(keyTmp)
+		os.Remove(certTmp)
+		return err
+	}
+	if err := os.Rename(certTmp, whc.CertFile); err != nil {
+		os.Remove(certTmp)
+		return err
+	}
+	return nil
+}
+
+/*Writes the data into a new temporary file in the directory of the given file and returns the name of the temporary file.*/
+func writeTempFile(name string, data []byte, perm os.FileMode) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".tmp*")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,159 @@
+package tba
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+func newCertificateConfigs(t *testing.T, url, ip string) *cfg.WebHookConfigs {
+	dir := t.TempDir()
+	return &cfg.WebHookConfigs{
+		URL: url, IP: ip, GenerateCertificate: true,
+		CertFile: filepath.Join(dir, "cert.pem"), KeyFile: filepath.Join(dir, "key.pem"),
+		CertificateValidity: 30 * 24 * time.Hour, CertificateRenewBefore: 7 * 24 * time.Hour,
+	}
+}
+
+func loadLeaf(t *testing.T, whc *cfg.WebHookConfigs) *x509.Certificate {
+	t.Helper()
+	cert, err := tls.LoadX509KeyPair(whc.CertFile, whc.KeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf
+}
+
+func TestEnsureWebhookCertificate(t *testing.T) {
+	whc := newCertificateConfigs(t, "https://example.com:8443/token", "203.0.113.5")
+	generated, err := EnsureWebhookCertificate(whc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !generated {
+		t.Fatal("a certificate should be generated when the files don't exist")
+	}
+	leaf := loadLeaf(t, whc)
+	if len(leaf.DNSNames) != 1 || leaf.DNSNames[0] != "example.com" {
+		t.Errorf("expected the host of the url in the DNS names, got %v", leaf.DNSNames)
+	}
+	if len(leaf.IPAddresses) != 1 || !leaf.IPAddresses[0].Equal(net.ParseIP("203.0.113.5")) {
+		t.Errorf("expected the ip of the configs in the ip addresses, got %v", leaf.IPAddresses)
+	}
+	now := time.Now()
+	if leaf.NotBefore.After(now) {
+		t.Errorf("the certificate should be valid now, it is valid from %v", leaf.NotBefore)
+	}
+	if d := leaf.NotAfter.Sub(now.Add(whc.CertificateValidity)); d < -time.Minute || d > time.Minute {
+		t.Errorf("the certificate should be valid for %v, it expires at %v", whc.CertificateValidity, leaf.NotAfter)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: "example.com", Roots: pool}); err != nil {
+		t.Errorf("the self signed certificate should be valid for the host : %v", err)
+	}
+	if info, err := os.Stat(whc.KeyFile); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("the key file should only be readable by the owner, got %v", info.Mode())
+	}
+
+	//The certificate is reused while it doesn't expire within CertificateRenewBefore.
+	before, _ := os.ReadFile(whc.CertFile)
+	generated, err = EnsureWebhookCertificate(whc)
+	if err != nil || generated {
+		t.Fatalf("the existing certificate should be reused, got %v and %v", generated, err)
+	}
+	if after, _ := os.ReadFile(whc.CertFile); !bytes.Equal(before, after) {
+		t.Error("the certificate file should not be changed")
+	}
+
+	whc.CertificateRenewBefore = 31 * 24 * time.Hour
+	generated, err = EnsureWebhookCertificate(whc)
+	if err != nil || !generated {
+		t.Fatalf("a certificate which expires soon should be renewed, got %v and %v", generated, err)
+	}
+	if loadLeaf(t, whc).SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+		t.Error("the renewed certificate should be a new one")
+	}
+}
+
+func TestEnsureWebhookCertificateIPHost(t *testing.T) {
+	whc := newCertificateConfigs(t, "https://203.0.113.7/token", "203.0.113.7")
+	if _, err := EnsureWebhookCertificate(whc); err != nil {
+		t.Fatal(err)
+	}
+	leaf := loadLeaf(t, whc)
+	if len(leaf.DNSNames) != 0 {
+		t.Errorf("an ip host should not be added to the DNS names, got %v", leaf.DNSNames)
+	}
+	if len(leaf.IPAddresses) != 1 || !leaf.IPAddresses[0].Equal(net.ParseIP("203.0.113.7")) {
+		t.Errorf("the ip should be added once, got %v", leaf.IPAddresses)
+	}
+}
+
+func TestEnsureWebhookCertificateInvalidFiles(t *testing.T) {
+	whc := newCertificateConfigs(t, "https://example.com/token", "")
+	if err := os.WriteFile(whc.CertFile, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := EnsureWebhookCertificate(whc)
+	if err != nil || !generated {
+		t.Fatalf("a certificate which can't be parsed should be replaced, got %v and %v", generated, err)
+	}
+	loadLeaf(t, whc)
+}
+
+func TestEnsureWebhookCertificateDisabled(t *testing.T) {
+	whc := newCertificateConfigs(t, "https://example.com/token", "")
+	whc.GenerateCertificate = false
+	generated, err := EnsureWebhookCertificate(whc)
+	if err != nil || generated {
+		t.Fatalf("nothing should be generated, got %v and %v", generated, err)
+	}
+	if _, err := os.Stat(whc.CertFile); !os.IsNotExist(err) {
+		t.Error("the certificate file should not be created")
+	}
+	whc.GenerateCertificate = true
+	whc.URL = "/token"
+	if _, err := EnsureWebhookCertificate(whc); err == nil {
+		t.Error("expected an error for a url without a host")
+	}
+}
+
+func TestEnsureWebhookCertificateWriteError(t *testing.T) {
+	whc := newCertificateConfigs(t, "https://example.com/token", "")
+	if _, err := EnsureWebhookCertificate(whc); err != nil {
+		t.Fatal(err)
+	}
+	key, _ := os.ReadFile(whc.KeyFile)
+	cert, _ := os.ReadFile(whc.CertFile)
+	//The certificate can't be written, so the old files should be kept.
+	whc.CertificateRenewBefore = 31 * 24 * time.Hour
+	certFile := whc.CertFile
+	whc.CertFile = filepath.Join(t.TempDir(), "missing", "cert.pem")
+	if _, err := EnsureWebhookCertificate(whc); err == nil {
+		t.Fatal("expected an error")
+	}
+	if after, _ := os.ReadFile(whc.KeyFile); !bytes.Equal(key, after) {
+		t.Error("the key file should not be replaced when the certificate can't be written")
+	}
+	whc.CertFile = certFile
+	if after, _ := os.ReadFile(whc.CertFile); !bytes.Equal(cert, after) {
+		t.Error("the certificate file should not be changed")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(whc.KeyFile))
+	if len(entries) != 2 {
+		t.Errorf("the temporary files should be removed, got %d files", len(entries))
+	}
+	loadLeaf(t, whc)
+}
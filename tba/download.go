@@ -0,0 +1,136 @@
+package tba
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*
+FileURL returns the url for downloading the file with the given path (the "FilePath" field of the File object) from the configured API server.
+The url contains the API key of the bot, so it should not be shared.
+*/
+func (bai *BotAPIInterface) FileURL(filePath string) string {
+	//BotAPI is like "https://api.telegram.org/bot" and the files are served from "https://api.telegram.org/file/bot<token>/<file_path>".
+	base := strings.TrimSuffix(bai.botConfigs.BotAPI, "bot")
+	return base + "file/bot" + bai.botConfigs.APIKey + "/" + strings.TrimPrefix(filePath, "/")
+}
+
+/*
+OpenFile opens the given file for reading. The content is streamed from the API server while it is being read and the returned reader should be closed.
+A local API server (started with "--local") returns absolute paths of the files on its disk. These files are opened directly if they are accessible.
+The download is canceled when the context is done. Reading fails with errors.FileTooLargeError if the file is larger than the "MaxSize" option.
+*/
+func (bai *BotAPIInterface) OpenFile(ctx context.Context, fileObject *objs.File, opts *objs.DownloadOptions) (io.ReadCloser, error) {
+	if fileObject == nil || fileObject.FilePath == "" {
+		return nil, &errs.RequiredArgumentError{ArgName: "file_path", MethodName: "downloadFile"}
+	}
+	if opts == nil {
+		opts = &objs.DownloadOptions{}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.MaxSize > 0 && fileObject.FileSize > opts.MaxSize {
+		return nil, &errs.FileTooLargeError{FilePath: fileObject.FilePath, MaxSize: opts.MaxSize}
+	}
+	total := fileObject.FileSize
+	if total <= 0 {
+		total = -1
+	}
+	if filepath.IsAbs(fileObject.FilePath) {
+		fl, err := os.Open(fileObject.FilePath)
+		if err == nil {
+			return bai.newDownloadReader(ctx, fl, fileObject.FilePath, total, opts), nil
+		}
+		//The API server is not on this machine. Fall back to downloading the file.
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", bai.FileURL(fileObject.FilePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err2 := http.DefaultClient.Do(req)
+	if err2 != nil {
+		return nil, &errs.MethodNotSentError{Method: "downloadFile", Reason: err2.Error(), Err: &errs.NetworkError{Method: "downloadFile", Err: err2}}
+	}
+	if res.StatusCode >= 300 {
+		_ = res.Body.Close()
+		fr := &objs.FailureResult{ErrorCode: res.StatusCode, Description: http.StatusText(res.StatusCode)}
+		return nil, &errs.MethodNotSentError{Method: "downloadFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode), FailureResult: fr}
+	}
+	if res.ContentLength >= 0 {
+		total = res.ContentLength
+	}
+	if opts.MaxSize > 0 && total > opts.MaxSize {
+		_ = res.Body.Close()
+		return nil, &errs.FileTooLargeError{FilePath: fileObject.FilePath, MaxSize: opts.MaxSize}
+	}
+	return bai.newDownloadReader(ctx, res.Body, fileObject.FilePath, total, opts), nil
+}
+
+/*
+DownloadFileTo downloads the given file and writes it into the writer. Returns the number of the written bytes.
+The writer is not closed. See OpenFile for the options.
+*/
+func (bai *BotAPIInterface) DownloadFileTo(ctx context.Context, fileObject *objs.File, wr io.Writer, opts *objs.DownloadOptions) (int64, error) {
+	start := time.Now()
+	rc, err := bai.OpenFile(ctx, fileObject, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	n, err := io.Copy(wr, rc)
+	if err != nil {
+		bai.logger.Error("Unable to download the file", logger.String("file_path", fileObject.FilePath), logger.Err(err))
+		return n, err
+	}
+	bai.logger.Info("File downloaded", logger.String("file_path", fileObject.FilePath), logger.Any("size", n), logger.Latency(time.Since(start)))
+	return n, nil
+}
+
+func (bai *BotAPIInterface) newDownloadReader(ctx context.Context, rc io.ReadCloser, filePath string, total int64, opts *objs.DownloadOptions) *downloadReader {
+	return &downloadReader{ctx: ctx, rc: rc, filePath: filePath, total: total, maxSize: opts.MaxSize, onProgress: opts.OnProgress}
+}
+
+/*Reader of a downloaded file which reports the progress and enforces the maximum size.*/
+type downloadReader struct {
+	ctx        context.Context
+	rc         io.ReadCloser
+	filePath   string
+	read       int64
+	total      int64
+	maxSize    int64
+	onProgress func(downloaded, total int64)
+}
+
+func (dr *downloadReader) Read(p []byte) (int, error) {
+	//Http bodies are canceled by the request context, local files are checked here.
+	if err := dr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := dr.rc.Read(p)
+	dr.read += int64(n)
+	if dr.maxSize > 0 && dr.read > dr.maxSize {
+		//The bytes after the maximum size are not returned.
+		n -= int(dr.read - dr.maxSize)
+		dr.read = dr.maxSize
+		return n, &errs.FileTooLargeError{FilePath: dr.filePath, MaxSize: dr.maxSize}
+	}
+	if n > 0 && dr.onProgress != nil {
+		dr.onProgress(dr.read, dr.total)
+	}
+	return n, err
+}
+
+func (dr *downloadReader) Close() error {
+	return dr.rc.Close()
+}
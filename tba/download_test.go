@@ -0,0 +1,109 @@
+package tba
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	cfgs "github.com/hamidteimouri/telego/configs"
+	errs "github.com/hamidteimouri/telego/errors"
+	logger "github.com/hamidteimouri/telego/logger"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+func newDownloadTestInterface(t *testing.T, content string) *BotAPIInterface {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/file/bottoken/photos/file_1.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(srv.Close)
+	return &BotAPIInterface{botConfigs: &cfgs.BotConfigs{BotAPI: srv.URL + "/bot", APIKey: "token"}, logger: logger.Nop()}
+}
+
+func TestDownloadFileTo(t *testing.T) {
+	content := strings.Repeat("x", 100000)
+	bai := newDownloadTestInterface(t, content)
+	var buf bytes.Buffer
+	var lastDownloaded, lastTotal int64
+	n, err := bai.DownloadFileTo(context.Background(), &objs.File{FilePath: "photos/file_1.jpg"}, &buf, &objs.DownloadOptions{
+		OnProgress: func(downloaded, total int64) {
+			lastDownloaded, lastTotal = downloaded, total
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(content)) || buf.String() != content {
+		t.Fatalf("downloaded %d bytes, want %d", n, len(content))
+	}
+	if lastDownloaded != n || lastTotal != n {
+		t.Fatalf("last progress was %d/%d", lastDownloaded, lastTotal)
+	}
+}
+
+func TestDownloadFileToErrors(t *testing.T) {
+	bai := newDownloadTestInterface(t, strings.Repeat("x", 1000))
+
+	_, err := bai.DownloadFileTo(context.Background(), &objs.File{FilePath: "photos/file_1.jpg"}, &bytes.Buffer{}, &objs.DownloadOptions{MaxSize: 10})
+	var tooLarge *errs.FileTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected FileTooLargeError, got %v", err)
+	}
+
+	_, err = bai.DownloadFileTo(context.Background(), &objs.File{FilePath: "photos/missing.jpg"}, &bytes.Buffer{}, nil)
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bai.DownloadFileTo(ctx, &objs.File{FilePath: "photos/file_1.jpg"}, &bytes.Buffer{}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+/*The size of a file is not known before downloading it if the file size is not given and the response has no Content-Length.*/
+func TestDownloadFileToMaxSize(t *testing.T) {
+	content := strings.Repeat("x", 1000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//Flushing before writing the body makes the response chunked.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(srv.Close)
+	local := filepath.Join(t.TempDir(), "file_1.jpg")
+	if err := os.WriteFile(local, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	bai := &BotAPIInterface{botConfigs: &cfgs.BotConfigs{BotAPI: srv.URL + "/bot", APIKey: "token"}, logger: logger.Nop()}
+	for _, filePath := range []string{"photos/file_1.jpg", local} {
+		var buf bytes.Buffer
+		var lastDownloaded int64
+		n, err := bai.DownloadFileTo(context.Background(), &objs.File{FilePath: filePath}, &buf, &objs.DownloadOptions{
+			MaxSize:    10,
+			OnProgress: func(downloaded, total int64) { lastDownloaded = downloaded },
+		})
+		var tooLarge *errs.FileTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("%s : expected FileTooLargeError, got %v", filePath, err)
+		}
+		if n != 10 || buf.Len() != 10 {
+			t.Errorf("%s : %d bytes were written (%d reported), expected at most 10", filePath, buf.Len(), n)
+		}
+		if lastDownloaded > 10 {
+			t.Errorf("%s : the progress should not exceed the maximum size, got %d", filePath, lastDownloaded)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package tba
+
+//The objects, arguments and methods of the bot API which are not written by hand are generated from api/botapi.json.
+//go:generate go run ../internal/apigen -spec ../api/botapi.json -objects ../objects -tba .
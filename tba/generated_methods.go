@@ -0,0 +1,128 @@
+// Code generated by apigen from botapi.json. DO NOT EDIT.
+
+package tba
+
+import (
+	"encoding/json"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+/*Use this method to copy messages of any kind. If some of the specified messages can't be found or copied, they are skipped. Service messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. The method is analogous to the method forwardMessages, but the copied messages don't have a link to the original message. Album grouping is kept for copied messages. On success, an array of MessageId of the sent messages is returned.*/
+func (bai *BotAPIInterface) CopyMessages(args *objs.CopyMessagesArgs) (*objs.Result[[]objs.MessageId], error) {
+	if args == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "args", MethodName: "copyMessages"}
+	}
+	if args.ChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "chat_id", MethodName: "copyMessages"}
+	}
+	if args.FromChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "from_chat_id", MethodName: "copyMessages"}
+	}
+	cp := *args
+	cp.ChatId = bai.migrateChatId(cp.ChatId)
+	cp.FromChatId = bai.migrateChatId(cp.FromChatId)
+	args = &cp
+	res, err := bai.SendCustom("copyMessages", args, false)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[[]objs.MessageId]{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+/*Use this method to delete multiple messages simultaneously. If some of the specified messages can't be found, they are skipped. Returns True on success.*/
+func (bai *BotAPIInterface) DeleteMessages(args *objs.DeleteMessagesArgs) (*objs.Result[bool], error) {
+	if args == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "args", MethodName: "deleteMessages"}
+	}
+	if args.ChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "chat_id", MethodName: "deleteMessages"}
+	}
+	cp := *args
+	cp.ChatId = bai.migrateChatId(cp.ChatId)
+	args = &cp
+	res, err := bai.SendCustom("deleteMessages", args, false)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[bool]{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+/*Use this method to forward multiple messages of any kind. If some of the specified messages can't be found or forwarded, they are skipped. Service messages and messages with protected content can't be forwarded. Album grouping is kept for forwarded messages. On success, an array of MessageId of the sent messages is returned.*/
+func (bai *BotAPIInterface) ForwardMessages(args *objs.ForwardMessagesArgs) (*objs.Result[[]objs.MessageId], error) {
+	if args == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "args", MethodName: "forwardMessages"}
+	}
+	if args.ChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "chat_id", MethodName: "forwardMessages"}
+	}
+	if args.FromChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "from_chat_id", MethodName: "forwardMessages"}
+	}
+	cp := *args
+	cp.ChatId = bai.migrateChatId(cp.ChatId)
+	cp.FromChatId = bai.migrateChatId(cp.FromChatId)
+	args = &cp
+	res, err := bai.SendCustom("forwardMessages", args, false)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[[]objs.MessageId]{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+/*Use this method to get the list of boosts added to a chat by a user. Requires administrator rights in the chat. Returns a UserChatBoosts object.*/
+func (bai *BotAPIInterface) GetUserChatBoosts(args *objs.GetUserChatBoostsArgs) (*objs.Result[*objs.UserChatBoosts], error) {
+	if args == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "args", MethodName: "getUserChatBoosts"}
+	}
+	if args.ChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "chat_id", MethodName: "getUserChatBoosts"}
+	}
+	cp := *args
+	cp.ChatId = bai.migrateChatId(cp.ChatId)
+	args = &cp
+	res, err := bai.SendCustom("getUserChatBoosts", args, false)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[*objs.UserChatBoosts]{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+/*Use this method to change the chosen reactions on a message. Service messages can't be reacted to. Automatically forwarded messages from a channel to its discussion group have the same available reactions as messages in the channel. Returns True on success.*/
+func (bai *BotAPIInterface) SetMessageReaction(args *objs.SetMessageReactionArgs) (*objs.Result[bool], error) {
+	if args == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "args", MethodName: "setMessageReaction"}
+	}
+	if args.ChatId.IsZero() {
+		return nil, &errs.RequiredArgumentError{ArgName: "chat_id", MethodName: "setMessageReaction"}
+	}
+	cp := *args
+	cp.ChatId = bai.migrateChatId(cp.ChatId)
+	args = &cp
+	res, err := bai.SendCustom("setMessageReaction", args, false)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[bool]{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
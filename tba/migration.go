@@ -0,0 +1,72 @@
+package tba
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+/*Keeps the chat ids of the groups which have been migrated to supergroups.*/
+type chatMigrations struct {
+	mu         sync.RWMutex
+	chats      map[int64]int64
+	onMigrated func(oldChatId, newChatId int64)
+}
+
+/*Arguments that implement this interface can be retried with a new chat id when the chat has been migrated.*/
+type chatIdSetter interface {
+	SetChatId(chatId json.RawMessage)
+}
+
+/*Arguments that implement this interface have a target chat. Its id is logged and used for handling the migrations and the blocked users.*/
+type chatIdGetter interface {
+	GetChatId() json.RawMessage
+}
+
+/*
+SetChatMigrationHandler sets the function which is called when a group is migrated to a supergroup.
+It is called once for each migration, whether the migration is learned from an update or from a failed request.
+*/
+func (bai *BotAPIInterface) SetChatMigrationHandler(handler func(oldChatId, newChatId int64)) {
+	bai.migrations.mu.Lock()
+	bai.migrations.onMigrated = handler
+	bai.migrations.mu.Unlock()
+}
+
+/*
+MigrateChat records that the group with oldChatId has been migrated to the supergroup with newChatId. After that all requests to oldChatId are sent to newChatId.
+Returns true if the migration was not known before.
+*/
+func (bai *BotAPIInterface) MigrateChat(oldChatId, newChatId int64) bool {
+	if oldChatId == 0 || newChatId == 0 || oldChatId == newChatId {
+		return false
+	}
+	m := bai.migrations
+	m.mu.Lock()
+	if m.chats[oldChatId] == newChatId {
+		m.mu.Unlock()
+		return false
+	}
+	m.chats[oldChatId] = newChatId
+	handler := m.onMigrated
+	m.mu.Unlock()
+	if handler != nil {
+		handler(oldChatId, newChatId)
+	}
+	return true
+}
+
+/*MigratedChatId returns the id of the supergroup which the given chat has been migrated to. If the chat has not been migrated, the given id is returned.*/
+func (bai *BotAPIInterface) MigratedChatId(chatId int64) int64 {
+	m := bai.migrations
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	//A supergroup can't be migrated again, but the loop is bounded in case of a malformed chain.
+	for i := 0; i < 4; i++ {
+		newId, ok := m.chats[chatId]
+		if !ok {
+			break
+		}
+		chatId = newId
+	}
+	return chatId
+}
@@ -0,0 +1,134 @@
+package tba
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	errs "github.com/hamidteimouri/telego/errors"
+	objs "github.com/hamidteimouri/telego/objects"
+)
+
+const migratedResponse = `{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":%s}}`
+
+/*A test API server which answers the requests to oldChatId with a migration error. It records the chat id and the uploaded file of each request.*/
+type migrationServer struct {
+	mu         sync.Mutex
+	oldChatId  string
+	newChatId  string
+	chatIds    []string
+	files      []string
+	migrations [][2]int64
+}
+
+func (ms *migrationServer) handle(w http.ResponseWriter, r *http.Request) {
+	chatId, file := "", ""
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			chatId = r.FormValue("chat_id")
+			for _, headers := range r.MultipartForm.File {
+				fl, _ := headers[0].Open()
+				bts, _ := io.ReadAll(fl)
+				file = string(bts)
+			}
+		}
+	} else {
+		var body struct {
+			ChatId json.RawMessage `json:"chat_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		chatId = string(body.ChatId)
+	}
+	ms.mu.Lock()
+	ms.chatIds = append(ms.chatIds, chatId)
+	ms.files = append(ms.files, file)
+	ms.mu.Unlock()
+	if chatId == ms.oldChatId {
+		_, _ = w.Write([]byte(strings.Replace(migratedResponse, "%s", ms.newChatId, 1)))
+		return
+	}
+	_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":5,"date":1,"chat":{"id":` + chatId + `,"type":"supergroup"}}}`))
+}
+
+func newMigrationTestInterface(t *testing.T, oldChatId, newChatId string) (*BotAPIInterface, *migrationServer) {
+	ms := &migrationServer{oldChatId: oldChatId, newChatId: newChatId}
+	bai := newCallTestInterface(t, ms.handle)
+	bai.SetChatMigrationHandler(func(oldChatId, newChatId int64) {
+		ms.migrations = append(ms.migrations, [2]int64{oldChatId, newChatId})
+	})
+	return bai, ms
+}
+
+func TestSendCustomMigrationRetry(t *testing.T) {
+	bai, ms := newMigrationTestInterface(t, "-5", "-1005")
+	res, err := bai.SendMessage(objs.IntChatID(-5), "hi", "", nil, false, false, false, false, 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Result.Chat.Id != -1005 {
+		t.Errorf("the result should be the result of the new chat, got %d", res.Result.Chat.Id)
+	}
+	if strings.Join(ms.chatIds, ",") != "-5,-1005" {
+		t.Errorf("the request should be sent again to the new chat, requests: %v", ms.chatIds)
+	}
+	if len(ms.migrations) != 1 || ms.migrations[0] != [2]int64{-5, -1005} {
+		t.Errorf("the migration handler should be called once, got %v", ms.migrations)
+	}
+	//The later requests are sent to the new chat directly.
+	ms.chatIds = nil
+	if _, err := bai.SendMessage(objs.IntChatID(-5), "hi", "", nil, false, false, false, false, 0, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ms.chatIds, ",") != "-1005" || len(ms.migrations) != 1 {
+		t.Errorf("unexpected requests %v and migrations %v", ms.chatIds, ms.migrations)
+	}
+}
+
+func TestSendCustomMigrationResendsFile(t *testing.T) {
+	bai, ms := newMigrationTestInterface(t, "-6", "-1006")
+	path := filepath.Join(t.TempDir(), "doc.txt")
+	if err := os.WriteFile(path, []byte("document content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fl, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fl.Close()
+	_, err = Call[*objs.Message](bai, "sendDocument", objs.Args{"chat_id": objs.IntChatID(-6), "document": fl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ms.chatIds, ",") != "-6,-1006" {
+		t.Fatalf("the request should be sent again to the new chat, requests: %v", ms.chatIds)
+	}
+	for i, file := range ms.files {
+		if file != "document content" {
+			t.Errorf("request %d received the file %q, the whole file should be sent", i, file)
+		}
+	}
+}
+
+func TestSendCustomMigrationNotSeekable(t *testing.T) {
+	bai, ms := newMigrationTestInterface(t, "-8", "-1008")
+	_, err := Call[*objs.Message](bai, "sendDocument", objs.Args{
+		"chat_id":  objs.IntChatID(-8),
+		"document": objs.NewNamedReader("doc.txt", io.MultiReader(strings.NewReader("document content"))),
+	})
+	var apiErr *errs.APIError
+	if !errors.As(err, &apiErr) || apiErr.MigrateToChatId != -1008 {
+		t.Fatalf("expected the migration error, got %v", err)
+	}
+	if len(ms.chatIds) != 1 {
+		t.Errorf("a file which can't be read again should not be sent again, requests: %v", ms.chatIds)
+	}
+	if bai.MigratedChatId(-8) != -1008 {
+		t.Error("the migration should be recorded")
+	}
+}
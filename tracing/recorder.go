@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Recorder is a tracer that keeps all the spans in memory. It is useful in tests.
+type Recorder struct {
+	mu     sync.Mutex
+	nextId uint64
+	spans  []*RecordedSpan
+}
+
+// NewRecorder returns a new empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// RecordedSpan is a span recorded by a Recorder.
+type RecordedSpan struct {
+	rec *Recorder
+	// Name is the name of the span.
+	Name string
+	// TraceId is the id of the trace which this span belongs to. It is the SpanId of the root span of the trace.
+	TraceId uint64
+	// SpanId is the id of this span.
+	SpanId uint64
+	// ParentId is the id of the parent span. Zero for root spans.
+	ParentId uint64
+	// StartTime is the time the span has been started.
+	StartTime time.Time
+	// EndTime is the time the span has been ended. Zero if the span has not been ended yet.
+	EndTime time.Time
+	// Attributes are the attributes of the span.
+	Attributes map[string]any
+	// Errors are the errors recorded in the span.
+	Errors []error
+}
+
+// Start starts a new span and records it.
+func (r *Recorder) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	r.mu.Lock()
+	r.nextId++
+	s := &RecordedSpan{rec: r, Name: name, SpanId: r.nextId, TraceId: r.nextId, StartTime: time.Now(), Attributes: make(map[string]any)}
+	if parent, ok := SpanFromContext(ctx).(*RecordedSpan); ok && parent.rec == r {
+		s.ParentId = parent.SpanId
+		s.TraceId = parent.TraceId
+	}
+	for _, a := range attrs {
+		s.Attributes[a.Key] = a.Value
+	}
+	r.spans = append(r.spans, s)
+	r.mu.Unlock()
+	return ContextWithSpan(ctx, s), s
+}
+
+// Spans returns a copy of the recorded spans in the order they have been started.
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordedSpan, len(r.spans))
+	for i, s := range r.spans {
+		out[i] = *s
+		out[i].Attributes = make(map[string]any, len(s.Attributes))
+		for k, v := range s.Attributes {
+			out[i].Attributes[k] = v
+		}
+		out[i].Errors = append([]error{}, s.Errors...)
+	}
+	return out
+}
+
+// Reset removes all the recorded spans.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.spans = nil
+	r.mu.Unlock()
+}
+
+// SetAttributes adds the given attributes to the span.
+func (s *RecordedSpan) SetAttributes(attrs ...Attribute) {
+	s.rec.mu.Lock()
+	for _, a := range attrs {
+		s.Attributes[a.Key] = a.Value
+	}
+	s.rec.mu.Unlock()
+}
+
+// RecordError records the given error in the span.
+func (s *RecordedSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.rec.mu.Lock()
+	s.Errors = append(s.Errors, err)
+	s.rec.mu.Unlock()
+}
+
+// End ends the span.
+func (s *RecordedSpan) End() {
+	s.rec.mu.Lock()
+	if s.EndTime.IsZero() {
+		s.EndTime = time.Now()
+	}
+	s.rec.mu.Unlock()
+}
+
+// Duration returns the duration of the span. Zero if the span has not been ended yet.
+func (s RecordedSpan) Duration() time.Duration {
+	if s.EndTime.IsZero() {
+		return 0
+	}
+	return s.EndTime.Sub(s.StartTime)
+}
@@ -0,0 +1,121 @@
+/*
+Package tracing contains the tracing spans of the bot.
+
+Each received update starts a span which is propagated through the middlewares and the handlers using the context of the update (update.Context()).
+API calls made with a bot returned by bot.WithContext(update.Context()) are recorded as child spans of the update span.
+The default tracer does nothing. Use SetTracer to plug in your own tracer (for example an adapter to OpenTelemetry) or a Recorder.
+*/
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracer creates spans.
+type Tracer interface {
+	// Start starts a new span with the given name and attributes. If ctx contains a span, the new span is its child. The returned context contains the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single operation within a trace.
+type Span interface {
+	// SetAttributes adds the given attributes to the span.
+	SetAttributes(attrs ...Attribute)
+	// RecordError records the given error in the span.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns an attribute with the given key and string value.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an attribute with the given key and int value.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Any returns an attribute with the given key and value.
+func Any(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// The attribute keys used by the bot.
+const (
+	AttrMethod      = "telego.method"
+	AttrChatId      = "telego.chat_id"
+	AttrErrorCode   = "telego.error_code"
+	AttrUpdateId    = "telego.update_id"
+	AttrUpdateType  = "telego.update_type"
+	AttrHandlerKind = "telego.handler"
+	// AttrDropped is set on the update span when the update is dropped. Its value is the reason, like the reason label of the dropped updates metric.
+	AttrDropped = "telego.dropped"
+)
+
+var (
+	tracerMu sync.RWMutex
+	tracer   Tracer = nopTracer{}
+)
+
+// SetTracer sets the tracer used by the bot. Passing nil restores the default tracer which does nothing.
+func SetTracer(t Tracer) {
+	if t == nil {
+		t = nopTracer{}
+	}
+	tracerMu.Lock()
+	tracer = t
+	tracerMu.Unlock()
+}
+
+// GetTracer returns the tracer used by the bot.
+func GetTracer() Tracer {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+	return tracer
+}
+
+// Start starts a new span using the tracer of the bot. A nil ctx is treated as context.Background().
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return GetTracer().Start(ctx, name, attrs...)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx which contains the given span. Tracers can use it to store their spans in the context.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span stored in ctx. If ctx has no span, a span which does nothing is returned.
+func SpanFromContext(ctx context.Context) Span {
+	if ctx != nil {
+		if s, ok := ctx.Value(spanKey{}).(Span); ok {
+			return s
+		}
+	}
+	return nopSpan{}
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+func (nopSpan) RecordError(error)          {}
+func (nopSpan) End()                       {}
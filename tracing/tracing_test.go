@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	SetTracer(rec)
+	defer SetTracer(nil)
+
+	ctx, root := Start(context.Background(), "update", Int(AttrUpdateId, 1))
+	_, child := Start(ctx, "api", String(AttrMethod, "sendMessage"))
+	child.RecordError(errors.New("failed"))
+	child.End()
+	root.End()
+	_, other := Start(context.Background(), "other")
+	other.End()
+
+	spans := rec.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	r, c, o := spans[0], spans[1], spans[2]
+	if r.ParentId != 0 || c.ParentId != r.SpanId || c.TraceId != r.TraceId {
+		t.Fatal("child span is not linked to the root span")
+	}
+	if o.ParentId != 0 || o.TraceId == r.TraceId {
+		t.Fatal("spans of different traces are linked")
+	}
+	if c.Attributes[AttrMethod] != "sendMessage" || len(c.Errors) != 1 {
+		t.Fatal("attributes or errors of the child span are not recorded")
+	}
+	if r.EndTime.IsZero() || r.Duration() < c.Duration() {
+		t.Fatal("root span is not ended correctly")
+	}
+}
+
+func TestNopTracer(t *testing.T) {
+	ctx, span := Start(context.Background(), "update")
+	span.End()
+	if _, ok := SpanFromContext(ctx).(nopSpan); !ok {
+		t.Fatal("default tracer should not store spans in the context")
+	}
+}
@@ -0,0 +1,175 @@
+package telego
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	logger "github.com/hamidteimouri/telego/logger"
+)
+
+// WebhookStatus contains the status of the webhook as reported by the API server in the last check.
+type WebhookStatus struct {
+	//CheckedAt is the time of the last check.
+	CheckedAt time.Time
+	//URL is the webhook url set on the API server.
+	URL string
+	//PendingUpdateCount is the number of updates awaiting delivery.
+	PendingUpdateCount int
+	//LastErrorDate is the time of the most recent error that happened when trying to deliver an update via webhook. Zero if there has been no error.
+	LastErrorDate time.Time
+	//LastErrorMessage is the most recent error that happened when trying to deliver an update via webhook.
+	LastErrorMessage string
+	//MaxConnections is the maximum allowed number of simultaneous connections set on the API server.
+	MaxConnections int
+	//MaxConnectionsDrift is true if MaxConnections differs from the one in the webhook configs.
+	MaxConnectionsDrift bool
+	//AllowedUpdates is the list of the update types set on the API server.
+	AllowedUpdates []string
+	//ReregisterCount is the number of times the webhook has been set again because it differed from the configs.
+	ReregisterCount int
+	//LastReregisterAt is the last time the webhook has been set again.
+	LastReregisterAt time.Time
+	//Err is the error occurred in the last check. Nil if the check was successful.
+	Err error
+}
+
+/*Periodically checks the webhook status on the API server and sets the webhook again if it differs from the configs.*/
+type webhookReconciler struct {
+	bot *Bot
+	//mu guards status. It is never held during a request to the API server, so reading the status doesn't wait for the checks.
+	mu     sync.RWMutex
+	status WebhookStatus
+	//checkMu makes the checks run one at a time.
+	checkMu sync.Mutex
+	//loopMu guards stop and done, which are nil while the periodic checks are not running.
+	loopMu sync.Mutex
+	stop   chan bool
+	done   chan bool
+}
+
+/*Starts checking the webhook every "interval". Does nothing if the checks are already running.*/
+func (wr *webhookReconciler) run(interval time.Duration) {
+	wr.loopMu.Lock()
+	defer wr.loopMu.Unlock()
+	if wr.stop != nil {
+		return
+	}
+	wr.stop = make(chan bool)
+	wr.done = make(chan bool)
+	go wr.start(interval, wr.stop, wr.done)
+}
+
+/*Stops the periodic checks and waits until the running check (if any) is completed.*/
+func (wr *webhookReconciler) halt() {
+	wr.loopMu.Lock()
+	defer wr.loopMu.Unlock()
+	if wr.stop == nil {
+		return
+	}
+	close(wr.stop)
+	<-wr.done
+	wr.stop = nil
+	wr.done = nil
+}
+
+func (wr *webhookReconciler) start(interval time.Duration, stop <-chan bool, done chan<- bool) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			//The tick and the stop signal may be ready at the same time.
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			wr.reconcile()
+		}
+	}
+}
+
+func (wr *webhookReconciler) reconcile() WebhookStatus {
+	wr.checkMu.Lock()
+	defer wr.checkMu.Unlock()
+	st := wr.check()
+	wr.mu.Lock()
+	wr.status = st
+	wr.mu.Unlock()
+	return st
+}
+
+/*Does the check and returns the new status. Only called by reconcile.*/
+func (wr *webhookReconciler) check() WebhookStatus {
+	st := wr.getStatus()
+	st.CheckedAt = time.Now()
+	whcfg := wr.bot.botCfg.WebHookConfigs
+	if !wr.bot.botCfg.Webhook || whcfg == nil {
+		st.Err = errors.New("the bot does not use webhook")
+		return st
+	}
+	wi, err := wr.bot.apiInterface.GetWebhookInfo()
+	if err != nil {
+		st.Err = err
+		wr.bot.logger.Error("Webhook reconciler : Unable to get the webhook info", logger.Method("getWebhookInfo"), logger.Err(err))
+		return st
+	}
+	info := wi.Result
+	st.Err = nil
+	st.URL = info.URL
+	st.PendingUpdateCount = info.PendingUpdateCount
+	st.LastErrorMessage = info.LastErrorMessage
+	st.LastErrorDate = time.Time{}
+	if info.LastErrorDate != 0 {
+		st.LastErrorDate = time.Unix(int64(info.LastErrorDate), 0)
+	}
+	st.MaxConnections = info.MaxConnection
+	st.MaxConnectionsDrift = whcfg.MaxConnections != 0 && whcfg.MaxConnections != info.MaxConnection
+	st.AllowedUpdates = info.AllowedUpdates
+	if st.LastErrorMessage != "" {
+		wr.bot.logger.Warn("Webhook reconciler : Update delivery failed", logger.Any("last_error_date", st.LastErrorDate), logger.String("last_error_message", st.LastErrorMessage), logger.Any("pending_update_count", st.PendingUpdateCount))
+	}
+	if st.MaxConnectionsDrift {
+		wr.bot.logger.Warn("Webhook reconciler : max_connections differs from the configs", logger.Any("max_connections", info.MaxConnection), logger.Any("configured_max_connections", whcfg.MaxConnections))
+	}
+	if info.URL != whcfg.URL || (whcfg.AllowedUpdates != nil && !sameUpdateTypes(info.AllowedUpdates, whcfg.AllowedUpdates)) {
+		wr.bot.logger.Warn("Webhook reconciler : Webhook differs from the configs. Setting it again", logger.String("url", info.URL), logger.Any("allowed_updates", info.AllowedUpdates))
+		err = wr.bot.setWebhook()
+		if err != nil {
+			st.Err = err
+			wr.bot.logger.Error("Webhook reconciler : Unable to set the webhook", logger.Method("setWebhook"), logger.Err(err))
+			return st
+		}
+		st.ReregisterCount++
+		st.LastReregisterAt = time.Now()
+	}
+	return st
+}
+
+func (wr *webhookReconciler) getStatus() WebhookStatus {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+	return wr.status
+}
+
+/*Checks if two lists of update types are equal regardless of their order. An empty list means all update types except chat_member.*/
+func sameUpdateTypes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a2 := append([]string{}, a...)
+	b2 := append([]string{}, b...)
+	sort.Strings(a2)
+	sort.Strings(b2)
+	for i := range a2 {
+		if a2[i] != b2[i] {
+			return false
+		}
+	}
+	return true
+}
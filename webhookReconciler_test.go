@@ -0,0 +1,141 @@
+package telego
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfg "github.com/hamidteimouri/telego/configs"
+)
+
+func newWebhookTestBot(t *testing.T, handler func(method string, r *http.Request) string) *Bot {
+	bot := newTestBot(t, handler)
+	bot.botCfg.Webhook = true
+	bot.botCfg.WebHookConfigs = &cfg.WebHookConfigs{URL: "https://example.com/token", MaxConnections: 40, AllowedUpdates: []string{"message"}}
+	return bot
+}
+
+func TestReconcileDrift(t *testing.T) {
+	var sets int32
+	bot := newWebhookTestBot(t, func(method string, r *http.Request) string {
+		switch method {
+		case "getWebhookInfo":
+			return okResult(map[string]any{
+				"url": "https://example.com/token", "pending_update_count": 3, "max_connections": 10, "allowed_updates": []string{"message"},
+				"last_error_date": 1700000000, "last_error_message": "Connection timed out",
+			})
+		case "setWebhook":
+			atomic.AddInt32(&sets, 1)
+		}
+		return okResult(true)
+	})
+	st := bot.ReconcileWebhook()
+	if st.Err != nil {
+		t.Fatal(st.Err)
+	}
+	if !st.MaxConnectionsDrift || st.MaxConnections != 10 || st.PendingUpdateCount != 3 {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if st.LastErrorMessage != "Connection timed out" || !st.LastErrorDate.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected last error %q at %v", st.LastErrorMessage, st.LastErrorDate)
+	}
+	if atomic.LoadInt32(&sets) != 0 || st.ReregisterCount != 0 {
+		t.Error("the webhook should not be set again when only max_connections differs")
+	}
+	if got := bot.GetWebhookStatus(); got.PendingUpdateCount != 3 {
+		t.Errorf("the status should be saved, got %+v", got)
+	}
+}
+
+func TestReconcileSetsWebhookAgain(t *testing.T) {
+	tests := []struct {
+		name string
+		info map[string]any
+	}{
+		{"url", map[string]any{"url": ""}},
+		{"allowed updates", map[string]any{"url": "https://example.com/token", "allowed_updates": []string{"message", "callback_query"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var setURL atomic.Value
+			bot := newWebhookTestBot(t, func(method string, r *http.Request) string {
+				switch method {
+				case "getWebhookInfo":
+					return okResult(tt.info)
+				case "setWebhook":
+					setURL.Store(requestParams(r)["url"])
+				}
+				return okResult(true)
+			})
+			st := bot.ReconcileWebhook()
+			if st.Err != nil {
+				t.Fatal(st.Err)
+			}
+			if setURL.Load() != "https://example.com/token" {
+				t.Errorf("the webhook should be set again, got %v", setURL.Load())
+			}
+			if st.ReregisterCount != 1 || st.LastReregisterAt.IsZero() {
+				t.Errorf("unexpected status %+v", st)
+			}
+			st = bot.ReconcileWebhook()
+			if st.ReregisterCount != 2 {
+				t.Errorf("the count should be kept between the checks, got %d", st.ReregisterCount)
+			}
+		})
+	}
+}
+
+func TestReconcilerStop(t *testing.T) {
+	var checks int32
+	block := make(chan bool)
+	blocked := make(chan bool, 1)
+	bot := newWebhookTestBot(t, func(method string, r *http.Request) string {
+		if method == "getWebhookInfo" {
+			if atomic.AddInt32(&checks, 1) == 2 {
+				blocked <- true
+				<-block
+			}
+		}
+		return okResult(map[string]any{"url": "https://example.com/token", "allowed_updates": []string{"message"}})
+	})
+	bot.whReconciler.run(5 * time.Millisecond)
+	select {
+	case <-blocked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook is not checked periodically")
+	}
+	statusRead := make(chan bool)
+	go func() {
+		bot.GetWebhookStatus()
+		close(statusRead)
+	}()
+	select {
+	case <-statusRead:
+	case <-time.After(time.Second):
+		t.Fatal("reading the status should not wait for the request to the API server")
+	}
+	stopped := make(chan bool)
+	go func() {
+		_ = bot.stopWebhook()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("stopWebhook should wait for the running check")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(block)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stopWebhook did not return")
+	}
+	n := atomic.LoadInt32(&checks)
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&checks) != n {
+		t.Error("the webhook should not be checked after stopping the reconciler")
+	}
+	//Stopping again does nothing.
+	_ = bot.stopWebhook()
+}